@@ -4,27 +4,41 @@ Copyright © 2026 cturner8
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"cturner8/binmate/internal/cli/add"
 	"cturner8/binmate/internal/cli/check"
 	configcmd "cturner8/binmate/internal/cli/config"
+	execcmd "cturner8/binmate/internal/cli/exec"
 	importcmd "cturner8/binmate/internal/cli/import"
 	"cturner8/binmate/internal/cli/install"
 	"cturner8/binmate/internal/cli/list"
+	"cturner8/binmate/internal/cli/pin"
+	"cturner8/binmate/internal/cli/prune"
+	"cturner8/binmate/internal/cli/releases"
 	"cturner8/binmate/internal/cli/remove"
+	"cturner8/binmate/internal/cli/rollback"
 	"cturner8/binmate/internal/cli/root"
+	"cturner8/binmate/internal/cli/shim"
 	switchcmd "cturner8/binmate/internal/cli/switch"
 	"cturner8/binmate/internal/cli/sync"
+	"cturner8/binmate/internal/cli/uninstall"
+	"cturner8/binmate/internal/cli/unpin"
 	"cturner8/binmate/internal/cli/update"
 	versioncmd "cturner8/binmate/internal/cli/version"
 	"cturner8/binmate/internal/cli/versions"
 	"cturner8/binmate/internal/core/buildinfo"
 	"cturner8/binmate/internal/core/config"
+	"cturner8/binmate/internal/core/runner"
 	"cturner8/binmate/internal/database"
 	"cturner8/binmate/internal/database/repository"
+	"cturner8/binmate/internal/providers/github"
 
 	"github.com/spf13/cobra"
 )
@@ -40,7 +54,16 @@ var (
 )
 
 func Execute() {
-	err := rootCmd.Execute()
+	// Cancel in-flight requests, downloads and extractions on Ctrl-C or termination
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	err := rootCmd.ExecuteContext(ctx)
+	stop()
+
+	// Pass on the exit code of binaries run through shims and exec
+	var exitErr *runner.ExitError
+	if errors.As(err, &exitErr) {
+		os.Exit(exitErr.Code)
+	}
 	if err != nil {
 		os.Exit(1)
 	}
@@ -56,6 +79,11 @@ func SetBuildMetadata(version, commit, date string) {
 	versioncmd.BuildDate = date
 }
 
+// SetSchema sets the JSON schema config files are validated against
+func SetSchema(data []byte) {
+	config.SetSchema(data)
+}
+
 func init() {
 	var (
 		configPath string
@@ -100,7 +128,8 @@ func init() {
 		}
 
 		// Read config file
-		cfg = config.ReadConfig(config.ConfigFlags{
+		var sources config.Sources
+		cfg, sources = config.ReadConfigWithSources(config.ConfigFlags{
 			ConfigPath: configPath,
 			LogLevel:   logLevel,
 		})
@@ -108,6 +137,15 @@ func init() {
 		// Configure logger with appropriate level (handles silent mode)
 		config.ConfigureLogger(cfg.LogLevel)
 
+		// Apply provider HTTP client settings (mirrors, proxy, CA certificates, timeouts)
+		clientSettings, err := config.GitHubClientSettings(cfg.Global)
+		if err != nil {
+			log.Fatalf("Invalid provider client configuration: %v", err)
+		}
+		if err := github.Configure(clientSettings); err != nil {
+			log.Fatalf("Invalid provider client configuration: %v", err)
+		}
+
 		// Resolve database path
 		dbPath, err := database.GetDefaultDBPath()
 		if err != nil {
@@ -125,10 +163,13 @@ func init() {
 
 		// Set package variables for commands
 		root.Config = &cfg
+		root.Sources = &sources
 		root.DBService = dbService
 		install.Config = &cfg
+		install.Sources = &sources
 		install.DBService = dbService
 		sync.Config = &cfg
+		sync.Sources = &sources
 		sync.DBService = dbService
 		add.Config = &cfg
 		add.DBService = dbService
@@ -143,6 +184,7 @@ func init() {
 		importcmd.Config = &cfg
 		importcmd.DBService = dbService
 		configcmd.Config = &cfg
+		configcmd.Sources = &sources
 		configcmd.DBService = dbService
 		versions.Config = &cfg
 		versions.DBService = dbService
@@ -151,6 +193,22 @@ func init() {
 		versioncmd.BuildDate = buildDate
 		check.Config = &cfg
 		check.DBService = dbService
+		pin.Config = &cfg
+		pin.DBService = dbService
+		unpin.Config = &cfg
+		unpin.DBService = dbService
+		releases.Config = &cfg
+		releases.DBService = dbService
+		rollback.Config = &cfg
+		rollback.DBService = dbService
+		uninstall.Config = &cfg
+		uninstall.DBService = dbService
+		prune.Config = &cfg
+		prune.DBService = dbService
+		shim.Config = &cfg
+		shim.DBService = dbService
+		execcmd.Config = &cfg
+		execcmd.DBService = dbService
 	}
 
 	rootCmd.PersistentPostRun = func(cmd *cobra.Command, args []string) {
@@ -164,6 +222,7 @@ func init() {
 	// Register subcommands
 	rootCmd.AddCommand(install.NewCommand())
 	rootCmd.AddCommand(sync.NewCommand())
+	rootCmd.AddCommand(sync.NewApplyCommand())
 	rootCmd.AddCommand(add.NewCommand())
 	rootCmd.AddCommand(list.NewCommand())
 	rootCmd.AddCommand(remove.NewCommand())
@@ -174,4 +233,12 @@ func init() {
 	rootCmd.AddCommand(versions.NewCommand())
 	rootCmd.AddCommand(versioncmd.NewCommand())
 	rootCmd.AddCommand(check.NewCommand())
+	rootCmd.AddCommand(pin.NewCommand())
+	rootCmd.AddCommand(unpin.NewCommand())
+	rootCmd.AddCommand(releases.NewCommand())
+	rootCmd.AddCommand(rollback.NewCommand())
+	rootCmd.AddCommand(uninstall.NewCommand())
+	rootCmd.AddCommand(prune.NewCommand())
+	rootCmd.AddCommand(shim.NewCommand())
+	rootCmd.AddCommand(execcmd.NewCommand())
 }
@@ -7,8 +7,10 @@ import (
 
 	binarySvc "cturner8/binmate/internal/core/binary"
 	"cturner8/binmate/internal/core/config"
+	installSvc "cturner8/binmate/internal/core/install"
+	"cturner8/binmate/internal/core/parallel"
+	"cturner8/binmate/internal/database"
 	"cturner8/binmate/internal/database/repository"
-	"cturner8/binmate/internal/providers/github"
 )
 
 // Package variables will be set by cmd package
@@ -19,8 +21,9 @@ var (
 
 func NewCommand() *cobra.Command {
 	var (
-		binaryID string
-		checkAll bool
+		binaryID    string
+		checkAll    bool
+		concurrency int
 	)
 
 	cmd := &cobra.Command{
@@ -48,33 +51,52 @@ Examples:
 					return nil
 				}
 
-				updatesAvailable := 0
-				for _, b := range binaries {
+				type checkResult struct {
+					line      string
+					hasUpdate bool
+				}
+
+				workers := config.ResolveConcurrency(concurrency, Config.Global)
+				results := parallel.Map(binaries, workers, func(b *repository.BinaryWithVersionDetails) checkResult {
 					binaryConfig, err := DBService.Binaries.GetByUserID(b.Binary.UserID)
 					if err != nil {
-						fmt.Fprintf(cmd.OutOrStdout(), "⚠ Failed to check %s: %v\n", b.Binary.Name, err)
-						continue
+						return checkResult{line: fmt.Sprintf("⚠ Failed to check %s: %v", b.Binary.Name, err)}
 					}
 
 					if binaryConfig.Provider != "github" {
-						fmt.Fprintf(cmd.OutOrStdout(), "⚠ Skipping %s: only github provider is supported\n", b.Binary.Name)
-						continue
+						return checkResult{line: fmt.Sprintf("⚠ Skipping %s: only github provider is supported", b.Binary.Name)}
 					}
 
-					release, _, err := github.FetchReleaseAsset(binaryConfig, "latest")
+					release, _, err := installSvc.ResolveRelease(cmd.Context(), binaryConfig, "latest")
 					if err != nil {
-						fmt.Fprintf(cmd.OutOrStdout(), "⚠ Failed to check %s: %v\n", b.Binary.Name, err)
-						continue
+						return checkResult{line: fmt.Sprintf("⚠ Failed to check %s: %v", b.Binary.Name, err)}
+					}
+
+					latest := installSvc.DisplayVersion(binaryConfig, release.TagName)
+					active := installSvc.DisplayVersion(binaryConfig, b.ActiveVersion)
+
+					if b.PinnedVersion != "" {
+						return checkResult{line: fmt.Sprintf("ℹ %s: Pinned at %s (latest: %s)", b.Binary.Name, installSvc.DisplayVersion(binaryConfig, b.PinnedVersion), latest)}
 					}
 
 					if b.ActiveVersion == "none" {
-						fmt.Fprintf(cmd.OutOrStdout(), "ℹ %s: No version installed (latest: %s)\n", b.Binary.Name, release.TagName)
-						updatesAvailable++
-					} else if b.ActiveVersion != release.TagName {
-						fmt.Fprintf(cmd.OutOrStdout(), "⬆ %s: Update available %s → %s\n", b.Binary.Name, b.ActiveVersion, release.TagName)
+						return checkResult{line: fmt.Sprintf("ℹ %s: No version installed (latest: %s)", b.Binary.Name, latest), hasUpdate: true}
+					}
+
+					switch installSvc.CompareVersions(binaryConfig, release.TagName, b.ActiveVersion) {
+					case 1:
+						return checkResult{line: fmt.Sprintf("⬆ %s: Update available %s → %s", b.Binary.Name, active, latest), hasUpdate: true}
+					case -1:
+						return checkResult{line: fmt.Sprintf("✓ %s: Up to date (%s, newer than latest release %s)", b.Binary.Name, active, latest)}
+					}
+					return checkResult{line: fmt.Sprintf("✓ %s: Up to date (%s)", b.Binary.Name, active)}
+				})
+
+				updatesAvailable := 0
+				for _, r := range results {
+					fmt.Fprintln(cmd.OutOrStdout(), r.line)
+					if r.hasUpdate {
 						updatesAvailable++
-					} else {
-						fmt.Fprintf(cmd.OutOrStdout(), "✓ %s: Up to date (%s)\n", b.Binary.Name, b.ActiveVersion)
 					}
 				}
 
@@ -96,28 +118,44 @@ Examples:
 				return fmt.Errorf("only github provider is currently supported")
 			}
 
-			release, _, err := github.FetchReleaseAsset(binaryConfig, "latest")
+			release, _, err := installSvc.ResolveRelease(cmd.Context(), binaryConfig, "latest")
 			if err != nil {
 				return fmt.Errorf("failed to fetch latest release: %w", err)
 			}
 
+			latest := installSvc.DisplayVersion(binaryConfig, release.TagName)
+
+			// Pinned binaries are held at their pin, as with --all
+			pin, err := DBService.Pins.Get(binaryConfig.ID)
+			if err != nil && err != database.ErrNotFound {
+				return fmt.Errorf("failed to get pin: %w", err)
+			}
+			if err == nil {
+				fmt.Fprintf(cmd.OutOrStdout(), "ℹ %s: Pinned at %s (latest: %s)\n", binaryID, installSvc.DisplayVersion(binaryConfig, pin.Version), latest)
+				return nil
+			}
+
 			// Get current active version
 			activeVersion, err := DBService.Versions.Get(binaryConfig.ID)
 			if err != nil {
-				fmt.Fprintf(cmd.OutOrStdout(), "No version currently installed. Latest version: %s\n", release.TagName)
+				fmt.Fprintf(cmd.OutOrStdout(), "No version currently installed. Latest version: %s\n", latest)
 				return nil
 			}
 
 			installation, err := DBService.Installations.GetByID(activeVersion.InstallationID)
 			if err != nil {
-				fmt.Fprintf(cmd.OutOrStdout(), "No version currently installed. Latest version: %s\n", release.TagName)
+				fmt.Fprintf(cmd.OutOrStdout(), "No version currently installed. Latest version: %s\n", latest)
 				return nil
 			}
-
-			if installation.Version == release.TagName {
-				fmt.Fprintf(cmd.OutOrStdout(), "✓ %s is up to date (version %s)\n", binaryID, installation.Version)
-			} else {
-				fmt.Fprintf(cmd.OutOrStdout(), "⬆ Update available for %s: %s → %s\n", binaryID, installation.Version, release.TagName)
+			active := installSvc.DisplayVersion(binaryConfig, installation.Version)
+
+			switch installSvc.CompareVersions(binaryConfig, release.TagName, installation.Version) {
+			case 1:
+				fmt.Fprintf(cmd.OutOrStdout(), "⬆ Update available for %s: %s → %s\n", binaryID, active, latest)
+			case -1:
+				fmt.Fprintf(cmd.OutOrStdout(), "✓ %s is up to date (version %s is newer than latest release %s)\n", binaryID, active, latest)
+			default:
+				fmt.Fprintf(cmd.OutOrStdout(), "✓ %s is up to date (version %s)\n", binaryID, active)
 			}
 
 			return nil
@@ -126,6 +164,7 @@ Examples:
 
 	cmd.Flags().StringVarP(&binaryID, "binary", "b", "", "Binary ID to check for updates")
 	cmd.Flags().BoolVarP(&checkAll, "all", "a", false, "Check all binaries for updates")
+	cmd.Flags().IntVarP(&concurrency, "concurrency", "j", 0, "Number of binaries to check in parallel with --all (defaults to global.concurrency or 4)")
 
 	// Make binary required unless --all is specified
 	cmd.MarkFlagsOneRequired("binary", "all")
@@ -3,6 +3,7 @@ package configcmd
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 
 	"github.com/spf13/cobra"
 
@@ -13,12 +14,14 @@ import (
 // Package variables will be set by cmd package
 var (
 	Config    *config.Config
+	Sources   *config.Sources
 	DBService *repository.Service
 )
 
 func NewCommand() *cobra.Command {
 	var (
-		showJSON bool
+		showJSON    bool
+		showSources bool
 	)
 
 	cmd := &cobra.Command{
@@ -28,12 +31,28 @@ func NewCommand() *cobra.Command {
 
 This shows the configuration file contents and database location.
 
+Config files may be written in JSON, YAML or TOML. A binmate.json (or
+binmate.yaml, binmate.yml or binmate.toml) in the current directory or one of
+its parents is layered over the user's config file: its binaries are added or
+override those with the same id, and the values it sets override the user's
+global settings.
+
 Example:
   binmate config                 # Show config as table
-  binmate config --json          # Show config as JSON`,
+  binmate config --json          # Show config as JSON
+  binmate config --sources       # Show which file each value came from
+  binmate config validate        # Check the config files for errors
+  binmate config set gh.pin v2.40.0
+  binmate config edit            # Edit the config file in $EDITOR
+  binmate config export          # Export manually added binaries as config`,
 		SilenceUsage:  true,
 		SilenceErrors: false,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if showSources {
+				printSources(cmd.OutOrStdout())
+				return nil
+			}
+
 			if showJSON {
 				// Output config as JSON
 				jsonData, err := json.MarshalIndent(Config, "", "  ")
@@ -63,6 +82,86 @@ Example:
 	}
 
 	cmd.Flags().BoolVar(&showJSON, "json", false, "Output configuration as JSON")
+	cmd.Flags().BoolVar(&showSources, "sources", false, "Show the config file each value was read from")
+	cmd.MarkFlagsMutuallyExclusive("json", "sources")
+
+	cmd.AddCommand(newValidateCommand())
+	cmd.AddCommand(newAddBinaryCommand())
+	cmd.AddCommand(newSetCommand())
+	cmd.AddCommand(newRemoveBinaryCommand())
+	cmd.AddCommand(newEditCommand())
+	cmd.AddCommand(newExportCommand())
+
+	return cmd
+}
+
+func newValidateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate [file...]",
+		Short: "Check config files for errors",
+		Long: `Check config files against the binmate JSON schema and for semantic errors.
+
+Reports unknown or misspelt keys, values of the wrong type, invalid regexes and
+version constraints, duplicate binary ids and binaries installing commands with
+the same name into the same install path, each with the line it occurs on.
+
+JSON, YAML and TOML files are all checked against the schema. Without arguments
+the user's config file and any project config file are validated together. The same checks run automatically before sync.
+
+Example:
+  binmate config validate
+  binmate config validate ./binmate.json
+  binmate config validate ./binmate.toml`,
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			files := args
+			if len(files) == 0 && Sources != nil {
+				files = Sources.Files
+			}
+			if len(files) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No config files found, nothing to validate")
+				return nil
+			}
+
+			errs := config.ValidateFiles(files, projectFileIn(files))
+			if len(errs) > 0 {
+				for _, err := range errs {
+					fmt.Fprintln(cmd.OutOrStdout(), err.Error())
+				}
+				return fmt.Errorf("found %d problem(s) in config", len(errs))
+			}
+
+			for _, file := range files {
+				fmt.Fprintf(cmd.OutOrStdout(), "✓ %s is valid\n", file)
+			}
+			return nil
+		},
+	}
 
 	return cmd
 }
+
+// printSources lists the config files read and the file setting each value
+func printSources(w io.Writer) {
+	if Sources == nil || len(Sources.Files) == 0 {
+		fmt.Fprintln(w, "No config files found, using defaults")
+		return
+	}
+
+	fmt.Fprintln(w, "Config files (later files take precedence):")
+	for _, include := range Sources.Includes {
+		fmt.Fprintf(w, "  %s (included by %s)\n", include.Source, include.From)
+	}
+	for _, file := range Sources.Files {
+		fmt.Fprintf(w, "  %s\n", file)
+	}
+	fmt.Fprintln(w)
+
+	fmt.Fprintf(w, "%-35s %-25s %s\n", "Key", "Value", "Source")
+	fmt.Fprintln(w, "---")
+	for _, path := range Sources.Paths() {
+		source := Sources.Values[path]
+		fmt.Fprintf(w, "%-35s %-25s %s\n", path, source.Value, source.File)
+	}
+}
@@ -0,0 +1,116 @@
+package configcmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"cturner8/binmate/internal/core/config"
+)
+
+func TestConfigCommand_Sources(t *testing.T) {
+	Config = &config.Config{Version: 1, Binaries: []config.Binary{}}
+	Sources = &config.Sources{
+		Files: []string{"/home/user/.config/.binmate/config.json", "/repo/binmate.json"},
+		Values: map[string]config.Source{
+			"global.installPath": {File: "/home/user/.config/.binmate/config.json", Value: "/usr/local/bin"},
+			"binaries.gh.pin":    {File: "/repo/binmate.json", Value: "v2.40.0"},
+		},
+	}
+	t.Cleanup(func() { Sources = nil })
+
+	cmd := NewCommand()
+	cmd.SetArgs([]string{"--sources"})
+
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	output := buf.String()
+	for _, want := range []string{"/repo/binmate.json", "binaries.gh.pin", "v2.40.0", "global.installPath"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got: %s", want, output)
+		}
+	}
+
+	// binaries.gh.pin sorts before global.installPath
+	if strings.Index(output, "binaries.gh.pin") > strings.Index(output, "global.installPath") {
+		t.Errorf("Expected values sorted by key, got: %s", output)
+	}
+}
+
+func TestConfigCommand_SourcesWithoutFiles(t *testing.T) {
+	Config = &config.Config{Version: 1, Binaries: []config.Binary{}}
+	Sources = &config.Sources{}
+	t.Cleanup(func() { Sources = nil })
+
+	cmd := NewCommand()
+	cmd.SetArgs([]string{"--sources"})
+
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "No config files found") {
+		t.Errorf("Expected no config files message, got: %s", buf.String())
+	}
+}
+
+func TestConfigValidateCommand(t *testing.T) {
+	schema, err := os.ReadFile("../../../schema.json")
+	if err != nil {
+		t.Fatalf("Failed to read schema: %v", err)
+	}
+	config.SetSchema(schema)
+	t.Cleanup(func() { config.SetSchema(nil) })
+
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.json")
+	invalid := filepath.Join(dir, "invalid.json")
+	os.WriteFile(valid, []byte(`{"version": 1, "binaries": []}`), 0o644)
+	os.WriteFile(invalid, []byte("{\n  \"version\": 1,\n  \"binaries\": [],\n  \"loglevel\": \"info\"\n}"), 0o644)
+
+	Config = &config.Config{Version: 1, Binaries: []config.Binary{}}
+	Sources = &config.Sources{Files: []string{valid}}
+	t.Cleanup(func() { Sources = nil })
+
+	t.Run("validates config sources", func(t *testing.T) {
+		cmd := NewCommand()
+		cmd.SetArgs([]string{"validate"})
+		buf := new(bytes.Buffer)
+		cmd.SetOut(buf)
+		cmd.SetErr(buf)
+
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if !strings.Contains(buf.String(), "✓ "+valid+" is valid") {
+			t.Errorf("Expected valid message, got: %s", buf.String())
+		}
+	})
+
+	t.Run("reports errors with line numbers", func(t *testing.T) {
+		cmd := NewCommand()
+		cmd.SetArgs([]string{"validate", invalid})
+		buf := new(bytes.Buffer)
+		cmd.SetOut(buf)
+		cmd.SetErr(buf)
+
+		if err := cmd.Execute(); err == nil {
+			t.Fatal("Expected error for invalid config")
+		}
+		want := invalid + `:4:3: loglevel: unknown property "loglevel" (did you mean "logLevel"?)`
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Expected output to contain %q, got: %s", want, buf.String())
+		}
+	})
+}
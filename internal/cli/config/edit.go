@@ -0,0 +1,453 @@
+package configcmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"cturner8/binmate/internal/core/config"
+	"cturner8/binmate/internal/core/runner"
+	"cturner8/binmate/internal/database"
+)
+
+func newAddBinaryCommand() *cobra.Command {
+	var (
+		file   string
+		binary config.Binary
+	)
+
+	cmd := &cobra.Command{
+		Use:   "add-binary <id>",
+		Short: "Add a binary to the config file",
+		Long: `Add a binary entry to your config file and sync it to the database.
+
+The entry is formatted like the existing binaries, and the rest of the file is
+left as it is. The config file is created if it does not exist yet.
+
+Example:
+  binmate config add-binary jq --path jqlang/jq
+  binmate config add-binary rg --path BurntSushi/ripgrep --asset-regex 'x86_64-unknown-linux-musl'
+  binmate config add-binary gh --path cli/cli --file ./binmate.json`,
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			binary.Id = args[0]
+			if binary.Name == "" {
+				binary.Name = binary.Id
+			}
+
+			path := file
+			if path == "" {
+				var err error
+				if path, err = userConfigFile(); err != nil {
+					return err
+				}
+			}
+			path, _ = sourceFile(path)
+
+			doc, err := config.LoadDocument(path)
+			if err != nil {
+				return err
+			}
+			if err := doc.AddBinary(binary); err != nil {
+				return err
+			}
+			if err := saveAndSync(cmd, doc, binary.Id); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "✓ Added binary %s to %s\n", binary.Id, path)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Config file to edit (defaults to your config file)")
+	cmd.Flags().StringVar(&binary.Name, "name", "", "Binary name (defaults to the id)")
+	cmd.Flags().StringVar(&binary.Alias, "alias", "", "Command name the binary is installed as")
+	cmd.Flags().StringVar(&binary.Provider, "provider", "github", "Provider the binary is released on")
+	cmd.Flags().StringVar(&binary.Path, "path", "", "Provider path, e.g. owner/repo (required)")
+	cmd.Flags().StringVar(&binary.Format, "format", ".tar.gz", "Archive format of the release asset")
+	cmd.Flags().StringVar(&binary.InstallPath, "install-path", "", "Install path overriding global.installPath")
+	cmd.Flags().StringVar(&binary.AssetRegex, "asset-regex", "", "Regex selecting the release asset")
+	cmd.Flags().StringVar(&binary.ReleaseRegex, "release-regex", "", "Regex matching the binary's release tags")
+	cmd.Flags().StringVar(&binary.Version, "version", "", "Semver constraint for the latest release")
+	cmd.Flags().StringVar(&binary.Pin, "pin", "", "Version to hold the binary at")
+	cmd.Flags().StringVar(&binary.Channel, "channel", "", "Release channel: stable, prerelease or nightly")
+	cmd.Flags().BoolVar(&binary.Authenticated, "authenticated", false, "Use authentication for API calls")
+	cmd.MarkFlagRequired("path")
+
+	return cmd
+}
+
+func newSetCommand() *cobra.Command {
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "set <id>.<field> <value>",
+		Short: "Set a field of a binary in the config file",
+		Long: `Set a field of a binary in the config file that defines it and sync the
+binary to the database. An empty value removes the field.
+
+Only the value changed is rewritten, so the rest of the file keeps its
+formatting. The change is rejected if the resulting config is invalid.
+
+Example:
+  binmate config set gh.pin v2.40.0
+  binmate config set gh.authenticated true
+  binmate config set gh.pin ""`,
+		Args:          cobra.ExactArgs(2),
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			binaryID, field, ok := strings.Cut(args[0], ".")
+			if !ok || binaryID == "" || field == "" {
+				return fmt.Errorf("invalid key %q, expected <id>.<field>", args[0])
+			}
+
+			doc, err := loadBinaryDocument(file, binaryID)
+			if err != nil {
+				return err
+			}
+			if err := doc.SetBinaryField(binaryID, field, args[1]); err != nil {
+				return err
+			}
+			if err := saveAndSync(cmd, doc, binaryID); err != nil {
+				return err
+			}
+
+			if args[1] == "" {
+				fmt.Fprintf(cmd.OutOrStdout(), "✓ Removed %s from %s\n", args[0], doc.Path)
+			} else {
+				fmt.Fprintf(cmd.OutOrStdout(), "✓ Set %s to %s in %s\n", args[0], args[1], doc.Path)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Config file to edit (defaults to the file defining the binary)")
+
+	return cmd
+}
+
+func newRemoveBinaryCommand() *cobra.Command {
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "remove-binary <id>",
+		Short: "Remove a binary from the config file",
+		Long: `Remove a binary entry from the config file that defines it.
+
+If no other config file defines the binary, it is removed from the database
+along with its installation records, as on the next sync. Installed files are
+left in place; use 'binmate remove --files' first to delete them.
+
+Example:
+  binmate config remove-binary gh`,
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			binaryID := args[0]
+
+			doc, err := loadBinaryDocument(file, binaryID)
+			if err != nil {
+				return err
+			}
+			if err := doc.RemoveBinary(binaryID); err != nil {
+				return err
+			}
+			if err := saveAndSync(cmd, doc, binaryID); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "✓ Removed binary %s from %s\n", binaryID, doc.Path)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Config file to edit (defaults to the file defining the binary)")
+
+	return cmd
+}
+
+func newEditCommand() *cobra.Command {
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "edit",
+		Short: "Open the config file in your editor",
+		Long: `Open the config file in $VISUAL or $EDITOR, then validate and sync it.
+
+The file is edited as a copy and only saved if it is valid; otherwise the
+errors are reported and the copy is kept so your changes are not lost.
+Binaries removed from the config are removed from the database, and listed.
+
+Example:
+  binmate config edit
+  EDITOR="code --wait" binmate config edit --file ./binmate.json`,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := file
+			if path == "" {
+				var err error
+				if path, err = userConfigFile(); err != nil {
+					return err
+				}
+			}
+
+			original, err := os.ReadFile(path)
+			if errors.Is(err, os.ErrNotExist) {
+				if doc, err := config.LoadDocument(path); err == nil {
+					original = doc.Bytes()
+				}
+			} else if err != nil {
+				return fmt.Errorf("unable to read %s: %w", path, err)
+			}
+
+			// Edit a copy with the same extension so editors highlight it correctly, next to
+			// the file so relative includes resolve the same way
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				return fmt.Errorf("unable to create config directory: %w", err)
+			}
+			tmp, err := os.CreateTemp(filepath.Dir(path), ".binmate-config-*"+filepath.Ext(path))
+			if err != nil {
+				return fmt.Errorf("unable to create temporary file: %w", err)
+			}
+			tmpPath := tmp.Name()
+			_, err = tmp.Write(original)
+			tmp.Close()
+			if err != nil {
+				return fmt.Errorf("unable to write temporary file: %w", err)
+			}
+
+			editor := editorCommand()
+			if err := runner.Run(editor[0], append(editor[1:], tmpPath), cmd.InOrStdin(), cmd.OutOrStdout(), cmd.ErrOrStderr()); err != nil {
+				os.Remove(tmpPath)
+				return fmt.Errorf("editor failed: %w", err)
+			}
+
+			edited, err := os.ReadFile(tmpPath)
+			if err != nil {
+				return fmt.Errorf("unable to read edited config: %w", err)
+			}
+			if bytes.Equal(edited, original) {
+				os.Remove(tmpPath)
+				fmt.Fprintln(cmd.OutOrStdout(), "No changes made")
+				return nil
+			}
+
+			files := layeredFiles(path)
+			validateFiles := slices.Clone(files)
+			validateFiles[slices.Index(files, path)] = tmpPath
+			projectFile := projectFileIn(files)
+			if projectFile == path {
+				projectFile = tmpPath
+			}
+			if errs := config.ValidateFiles(validateFiles, projectFile); len(errs) > 0 {
+				for _, err := range errs {
+					fmt.Fprintln(cmd.OutOrStdout(), err.Error())
+				}
+				return fmt.Errorf("config not saved, found %d problem(s); your changes are kept in %s", len(errs), tmpPath)
+			}
+
+			if err := os.WriteFile(path, edited, 0o644); err != nil {
+				return fmt.Errorf("unable to write %s: %w", path, err)
+			}
+			os.Remove(tmpPath)
+
+			if err := reload(files); err != nil {
+				return err
+			}
+			if err := syncEdited(cmd.OutOrStdout()); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "✓ Saved %s\n", path)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Config file to edit (defaults to your config file)")
+
+	return cmd
+}
+
+// editorCommand returns the user's editor command and arguments
+func editorCommand() []string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(env)); len(fields) > 0 {
+			return fields
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return []string{"notepad"}
+	}
+	return []string{"vi"}
+}
+
+// userConfigFile returns the user's config file, which may not exist yet
+func userConfigFile() (string, error) {
+	if Sources != nil {
+		for _, file := range Sources.Files {
+			if file != Sources.ProjectFile {
+				return file, nil
+			}
+		}
+	}
+	return config.DefaultConfigPath()
+}
+
+// loadBinaryDocument loads the config file to edit for a binary: the file given, otherwise
+// the config file with the highest precedence defining the binary
+func loadBinaryDocument(file, binaryID string) (*config.Document, error) {
+	if file != "" {
+		return config.LoadDocument(file)
+	}
+
+	if Sources != nil {
+		for i := len(Sources.Files) - 1; i >= 0; i-- {
+			doc, err := config.LoadDocument(Sources.Files[i])
+			if err == nil && doc.HasBinary(binaryID) {
+				return doc, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("binary %q is not defined in any config file", binaryID)
+}
+
+// layeredFiles returns the config files read in increasing order of precedence, including
+// path as the user's config file if it was not read
+func layeredFiles(path string) []string {
+	var files []string
+	if Sources != nil {
+		files = append(files, Sources.Files...)
+	}
+	if _, ok := sourceFile(path); !ok {
+		files = append([]string{path}, files...)
+	}
+	return files
+}
+
+// projectFileIn returns the file among files that is the project config read, if any
+func projectFileIn(files []string) string {
+	if Sources == nil || Sources.ProjectFile == "" {
+		return ""
+	}
+	for _, file := range files {
+		if file == Sources.ProjectFile || config.SameFile(file, Sources.ProjectFile) {
+			return file
+		}
+	}
+	return ""
+}
+
+// sourceFile returns the config file read that a path refers to, such as a relative path to
+// it, reporting whether the path is one of the config files in use
+func sourceFile(path string) (string, bool) {
+	if Sources != nil {
+		for _, file := range Sources.Files {
+			if file == path || config.SameFile(file, path) {
+				return file, true
+			}
+		}
+	}
+	return path, false
+}
+
+// saveAndSync saves an edited config file if the layered config stays valid, reloads the
+// config and syncs the edited binary to the database
+func saveAndSync(cmd *cobra.Command, doc *config.Document, binaryID string) error {
+	files := layeredFiles(doc.Path)
+	if err := saveValidated(cmd, doc, files); err != nil {
+		return err
+	}
+
+	if err := reload(files); err != nil {
+		return err
+	}
+	return syncBinary(binaryID)
+}
+
+// saveValidated saves an edited config file if the given config files stay valid,
+// listing the problems found otherwise
+func saveValidated(cmd *cobra.Command, doc *config.Document, files []string) error {
+	if err := doc.SaveValidated(files, projectFileIn(files)); err != nil {
+		var errs config.ValidationErrors
+		if errors.As(err, &errs) {
+			for _, err := range errs {
+				fmt.Fprintln(cmd.ErrOrStderr(), err.Error())
+			}
+			return fmt.Errorf("config not changed, found %d problem(s)", len(errs))
+		}
+		return err
+	}
+	return nil
+}
+
+// reload re-reads the layered config files after an edit
+func reload(files []string) error {
+	cfg, sources, err := config.ReadConfigFiles(files, projectFileIn(files), Config.LogLevel)
+	if err != nil {
+		return err
+	}
+	*Config = cfg
+	if Sources == nil {
+		Sources = &config.Sources{}
+	}
+	*Sources = sources
+	return nil
+}
+
+// syncEdited syncs the reloaded config to the database after an edit, listing the binaries
+// removed as they are no longer in config. Binaries are kept while an included config
+// cannot be read, as they may be defined there.
+func syncEdited(w io.Writer) error {
+	plan, err := config.PlanSync(*Config, DBService)
+	if err != nil {
+		return fmt.Errorf("error syncing to database: %w", err)
+	}
+	keepRemoved := Sources != nil && len(Sources.IncludeErrors) > 0
+	if err := config.ApplySync(plan, DBService, config.SyncOptions{KeepRemoved: keepRemoved}); err != nil {
+		return fmt.Errorf("error syncing to database: %w", err)
+	}
+	if keepRemoved {
+		return nil
+	}
+	for _, binary := range plan.Remove {
+		fmt.Fprintf(w, "✓ %s removed, as it is no longer in config\n", binary.UserID)
+	}
+	return nil
+}
+
+// syncBinary syncs a binary's config entry to the database, removing config-managed
+// binaries that are no longer defined in any config file
+func syncBinary(binaryID string) error {
+	if _, err := config.GetBinary(binaryID, Config.Binaries); err == nil {
+		return config.SyncBinary(binaryID, *Config, DBService)
+	}
+
+	binary, err := DBService.Binaries.GetByUserID(binaryID)
+	if err == database.ErrNotFound {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get binary %s: %w", binaryID, err)
+	}
+	if binary.Source == "manual" {
+		return nil
+	}
+	if err := DBService.Binaries.Delete(binary.ID); err != nil {
+		return fmt.Errorf("failed to remove binary %s from database: %w", binaryID, err)
+	}
+	return nil
+}
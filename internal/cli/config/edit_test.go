@@ -0,0 +1,289 @@
+package configcmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"cturner8/binmate/internal/core/config"
+	"cturner8/binmate/internal/database"
+	"cturner8/binmate/internal/database/repository"
+)
+
+const editTestConfig = `{
+  "version": 1,
+  "binaries": [
+    {
+      "id": "gh",
+      "name": "gh",
+      "provider": "github",
+      "path": "cli/cli",
+      "format": ".tar.gz"
+    }
+  ]
+}
+`
+
+// setupEditEnv writes a config file, loads it as the current config and syncs it to a test database
+func setupEditEnv(t *testing.T) (*repository.Service, string) {
+	t.Helper()
+
+	schema, err := os.ReadFile("../../../schema.json")
+	if err != nil {
+		t.Fatalf("Failed to read schema: %v", err)
+	}
+	config.SetSchema(schema)
+
+	tmpDir := t.TempDir()
+	db, err := database.Initialize(filepath.Join(tmpDir, "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to initialize database: %v", err)
+	}
+	dbService := repository.NewService(db)
+
+	path := filepath.Join(tmpDir, "config.json")
+	if err := os.WriteFile(path, []byte(editTestConfig), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, sources, err := config.ReadConfigFiles([]string{path}, "", "silent")
+	if err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+	if err := config.SyncToDatabase(cfg, dbService); err != nil {
+		t.Fatalf("Failed to sync config: %v", err)
+	}
+
+	Config = &cfg
+	Sources = &sources
+	DBService = dbService
+	t.Cleanup(func() {
+		db.Close()
+		Sources = nil
+		config.SetSchema(nil)
+	})
+
+	return dbService, path
+}
+
+func runConfigCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	cmd := NewCommand()
+	cmd.SetArgs(args)
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+func TestConfigAddBinaryCommand(t *testing.T) {
+	dbService, path := setupEditEnv(t)
+
+	output, err := runConfigCommand(t, "add-binary", "jq", "--path", "jqlang/jq", "--asset-regex", "linux-amd64")
+	if err != nil {
+		t.Fatalf("Command failed: %v\n%s", err, output)
+	}
+	if !strings.Contains(output, "✓ Added binary jq to "+path) {
+		t.Errorf("Unexpected output: %s", output)
+	}
+
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), `"assetRegex": "linux-amd64"`) {
+		t.Errorf("Expected binary written to config, got:\n%s", data)
+	}
+	if _, err := config.GetBinary("jq", Config.Binaries); err != nil {
+		t.Errorf("Expected config reloaded with jq: %v", err)
+	}
+
+	binary, err := dbService.Binaries.GetByUserID("jq")
+	if err != nil {
+		t.Fatalf("Expected jq synced to database: %v", err)
+	}
+	if binary.ProviderPath != "jqlang/jq" || binary.Name != "jq" {
+		t.Errorf("Unexpected synced binary: %+v", binary)
+	}
+
+	if _, err := runConfigCommand(t, "add-binary", "jq", "--path", "jqlang/jq"); err == nil {
+		t.Error("Expected error adding duplicate binary")
+	}
+}
+
+func TestConfigSetCommand(t *testing.T) {
+	dbService, path := setupEditEnv(t)
+
+	output, err := runConfigCommand(t, "set", "gh.pin", "v2.40.0")
+	if err != nil {
+		t.Fatalf("Command failed: %v\n%s", err, output)
+	}
+
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), "\"format\": \".tar.gz\",\n      \"pin\": \"v2.40.0\"") {
+		t.Errorf("Expected pin written to config, got:\n%s", data)
+	}
+
+	binary, _ := dbService.Binaries.GetByUserID("gh")
+	pin, err := dbService.Pins.Get(binary.ID)
+	if err != nil || pin.Version != "v2.40.0" {
+		t.Errorf("Expected gh pinned to v2.40.0, got %+v (%v)", pin, err)
+	}
+
+	// Removing the pin unpins the binary
+	if output, err := runConfigCommand(t, "set", "gh.pin", ""); err != nil {
+		t.Fatalf("Command failed: %v\n%s", err, output)
+	}
+	if _, err := dbService.Pins.Get(binary.ID); err != database.ErrNotFound {
+		t.Errorf("Expected pin removed, got %v", err)
+	}
+	data, _ = os.ReadFile(path)
+	if string(data) != editTestConfig {
+		t.Errorf("Expected original config after removing pin, got:\n%s", data)
+	}
+}
+
+func TestConfigSetCommand_Invalid(t *testing.T) {
+	_, path := setupEditEnv(t)
+
+	output, err := runConfigCommand(t, "set", "gh.assetRegex", "linux_(amd64")
+	if err == nil {
+		t.Fatal("Expected error for invalid regex")
+	}
+	if !strings.Contains(output, "invalid regex") {
+		t.Errorf("Expected validation error in output, got: %s", output)
+	}
+
+	data, _ := os.ReadFile(path)
+	if string(data) != editTestConfig {
+		t.Errorf("Expected config unchanged, got:\n%s", data)
+	}
+
+	if _, err := runConfigCommand(t, "set", "missing.pin", "v1"); err == nil {
+		t.Error("Expected error for binary not in config")
+	}
+	if _, err := runConfigCommand(t, "set", "gh", "v1"); err == nil {
+		t.Error("Expected error for key without field")
+	}
+}
+
+func TestConfigRemoveBinaryCommand(t *testing.T) {
+	dbService, path := setupEditEnv(t)
+
+	output, err := runConfigCommand(t, "remove-binary", "gh")
+	if err != nil {
+		t.Fatalf("Command failed: %v\n%s", err, output)
+	}
+
+	data, _ := os.ReadFile(path)
+	if strings.Contains(string(data), `"gh"`) {
+		t.Errorf("Expected gh removed from config, got:\n%s", data)
+	}
+	if _, err := dbService.Binaries.GetByUserID("gh"); err != database.ErrNotFound {
+		t.Errorf("Expected gh removed from database, got %v", err)
+	}
+}
+
+func TestConfigEditCommand(t *testing.T) {
+	dbService, path := setupEditEnv(t)
+
+	// The editor appends a binary by rewriting the file it is given
+	edited := strings.Replace(editTestConfig, `".tar.gz"
+    }`, `".tar.gz"
+    },
+    {"id": "fzf", "name": "fzf", "provider": "github", "path": "junegunn/fzf", "format": ".zip"}`, 1)
+	editedPath := filepath.Join(t.TempDir(), "edited.json")
+	os.WriteFile(editedPath, []byte(edited), 0o644)
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "cp "+editedPath)
+
+	output, err := runConfigCommand(t, "edit")
+	if err != nil {
+		t.Fatalf("Command failed: %v\n%s", err, output)
+	}
+	if !strings.Contains(output, "✓ Saved "+path) {
+		t.Errorf("Unexpected output: %s", output)
+	}
+
+	data, _ := os.ReadFile(path)
+	if string(data) != edited {
+		t.Errorf("Expected edited config saved, got:\n%s", data)
+	}
+	if _, err := dbService.Binaries.GetByUserID("fzf"); err != nil {
+		t.Errorf("Expected fzf synced to database: %v", err)
+	}
+}
+
+func TestConfigEditCommand_RemovesBinary(t *testing.T) {
+	dbService, _ := setupEditEnv(t)
+
+	editedPath := filepath.Join(t.TempDir(), "edited.json")
+	os.WriteFile(editedPath, []byte(`{"version": 1, "binaries": []}`), 0o644)
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "cp "+editedPath)
+
+	output, err := runConfigCommand(t, "edit")
+	if err != nil {
+		t.Fatalf("Command failed: %v\n%s", err, output)
+	}
+	if !strings.Contains(output, "✓ gh removed, as it is no longer in config") {
+		t.Errorf("Expected the removal to be listed, got: %s", output)
+	}
+	if _, err := dbService.Binaries.GetByUserID("gh"); err != database.ErrNotFound {
+		t.Errorf("Expected gh removed from database, got %v", err)
+	}
+}
+
+func TestConfigEditCommand_Invalid(t *testing.T) {
+	_, path := setupEditEnv(t)
+
+	editedPath := filepath.Join(t.TempDir(), "edited.json")
+	os.WriteFile(editedPath, []byte(strings.Replace(editTestConfig, `"format"`, `"fromat"`, 1)), 0o644)
+	t.Setenv("TMPDIR", t.TempDir())
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "cp "+editedPath)
+
+	output, err := runConfigCommand(t, "edit")
+	if err == nil {
+		t.Fatal("Expected error for invalid edit")
+	}
+	if !strings.Contains(output, `did you mean "format"?`) {
+		t.Errorf("Expected validation error in output, got: %s", output)
+	}
+
+	data, _ := os.ReadFile(path)
+	if string(data) != editTestConfig {
+		t.Errorf("Expected config unchanged, got:\n%s", data)
+	}
+}
+
+func TestConfigSetCommand_YAML(t *testing.T) {
+	_, jsonPath := setupEditEnv(t)
+
+	content := `version: 1
+binaries:
+  # Held back until the new asset names are supported
+  - id: gh
+    name: gh
+    provider: github
+    path: cli/cli
+    format: .tar.gz
+`
+	path := filepath.Join(filepath.Dir(jsonPath), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	Sources.Files = []string{path}
+
+	if output, err := runConfigCommand(t, "set", "gh.pin", "v2.40.0"); err != nil {
+		t.Fatalf("Command failed: %v\n%s", err, output)
+	}
+
+	data, _ := os.ReadFile(path)
+	if string(data) != content+"    pin: v2.40.0\n" {
+		t.Errorf("Expected pin written as YAML with comments kept, got:\n%s", data)
+	}
+	if binary, err := config.GetBinary("gh", Config.Binaries); err != nil || binary.Pin != "v2.40.0" {
+		t.Errorf("Expected config reloaded with pin, got %+v (%v)", binary, err)
+	}
+}
@@ -0,0 +1,133 @@
+package configcmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"cturner8/binmate/internal/core/config"
+)
+
+func newExportCommand() *cobra.Command {
+	var (
+		mergeInto string
+		pinActive bool
+		format    string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export manually added binaries as config",
+		Long: `Export the binaries added with 'binmate add', 'binmate import' or the TUI as
+config entries, so they can be shared with a config file.
+
+Without --merge-into a config file with the exported binaries is printed, in the
+format of your config file unless --format is given. With --merge-into the
+binaries are appended to the given config file in its own format, skipping ids
+it already defines. When that file is one of your config files, the binaries
+are synced and become managed by it. A project config cannot set installPath
+or authenticated, so these are left out of binaries merged into it.
+
+Pins are exported with their binaries; --pin also pins the other binaries to
+their active version.
+
+Example:
+  binmate config export > tools.json
+  binmate config export --format yaml > tools.yaml
+  binmate config export --pin --merge-into ./binmate.json`,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if mergeInto != "" && format != "" {
+				return fmt.Errorf("--format cannot be used with --merge-into, which keeps the format of the file")
+			}
+
+			binaries, err := config.ExportBinaries(DBService, config.ExportOptions{PinActive: pinActive})
+			if err != nil {
+				return err
+			}
+			if len(binaries) == 0 {
+				fmt.Fprintln(cmd.ErrOrStderr(), "No manually added binaries to export")
+				return nil
+			}
+
+			if mergeInto == "" {
+				if format == "" {
+					if path, err := userConfigFile(); err == nil {
+						format = config.FormatOf(path)
+					}
+				}
+				doc, err := config.NewDocument("", format)
+				if err != nil {
+					return err
+				}
+				for _, binary := range binaries {
+					if err := doc.AddBinary(binary); err != nil {
+						return err
+					}
+				}
+				fmt.Fprint(cmd.OutOrStdout(), string(doc.Bytes()))
+				return nil
+			}
+
+			doc, err := config.LoadDocument(mergeInto)
+			if err != nil {
+				return err
+			}
+
+			source, active := sourceFile(mergeInto)
+			project := active && projectFileIn([]string{source}) != ""
+
+			added := 0
+			for _, binary := range binaries {
+				if doc.HasBinary(binary.Id) {
+					fmt.Fprintf(cmd.OutOrStdout(), "- Skipped %s, already defined in %s\n", binary.Id, mergeInto)
+					continue
+				}
+				if project {
+					var cleared []string
+					if binary, cleared = config.RestrictProjectBinary(binary); len(cleared) > 0 {
+						fmt.Fprintf(cmd.ErrOrStderr(), "- Left out %s of %s, as project configs cannot set them\n", strings.Join(cleared, ", "), binary.Id)
+					}
+				}
+				if err := doc.AddBinary(binary); err != nil {
+					return err
+				}
+				added++
+			}
+			if added == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No new binaries to export")
+				return nil
+			}
+
+			// Binaries merged into a config file in use are synced so the file manages them
+			files := []string{mergeInto}
+			if active {
+				files = layeredFiles(source)
+			}
+			if err := saveValidated(cmd, doc, files); err != nil {
+				return err
+			}
+			if active {
+				if err := reload(files); err != nil {
+					return err
+				}
+				// Exporting only adds binaries, so none are removed
+				if err := config.SyncToDatabaseWithOptions(*Config, DBService, config.SyncOptions{KeepRemoved: true}); err != nil {
+					return fmt.Errorf("error syncing to database: %w", err)
+				}
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "✓ Exported %d binaries to %s\n", added, mergeInto)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&mergeInto, "merge-into", "", "Config file to add the exported binaries to")
+	cmd.Flags().BoolVar(&pinActive, "pin", false, "Pin exported binaries to their active version")
+	cmd.Flags().StringVar(&format, "format", "", "Format of the printed config: json, yaml or toml (default: the format of your config file)")
+
+	return cmd
+}
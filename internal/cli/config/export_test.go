@@ -0,0 +1,150 @@
+package configcmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"cturner8/binmate/internal/core/config"
+	"cturner8/binmate/internal/database"
+)
+
+func TestConfigExportCommand(t *testing.T) {
+	dbService, path := setupEditEnv(t)
+
+	alias := "rg"
+	binary := &database.Binary{UserID: "ripgrep", Name: "ripgrep", Alias: &alias, Provider: "github",
+		ProviderPath: "BurntSushi/ripgrep", Format: ".tar.gz", Authenticated: true}
+	if err := dbService.Binaries.Create(binary); err != nil {
+		t.Fatalf("Failed to create binary: %v", err)
+	}
+	installation := &database.Installation{BinaryID: binary.ID, Version: "14.1.0", InstalledPath: "/tmp/rg"}
+	dbService.Installations.Create(installation)
+	dbService.Versions.Set(binary.ID, installation.ID, "/tmp/bin/rg")
+
+	t.Run("prints config", func(t *testing.T) {
+		output, err := runConfigCommand(t, "export", "--pin")
+		if err != nil {
+			t.Fatalf("Command failed: %v\n%s", err, output)
+		}
+
+		var exported struct {
+			Version  int              `json:"version"`
+			Binaries []map[string]any `json:"binaries"`
+		}
+		if err := json.Unmarshal([]byte(output), &exported); err != nil {
+			t.Fatalf("Expected JSON output, got %v:\n%s", err, output)
+		}
+		if exported.Version != 1 || len(exported.Binaries) != 1 {
+			t.Fatalf("Unexpected export: %s", output)
+		}
+		entry := exported.Binaries[0]
+		if entry["id"] != "ripgrep" || entry["alias"] != "rg" || entry["authenticated"] != true || entry["pin"] != "14.1.0" {
+			t.Errorf("Unexpected exported binary: %v", entry)
+		}
+	})
+
+	t.Run("prints config in another format", func(t *testing.T) {
+		output, err := runConfigCommand(t, "export", "--format", "toml")
+		if err != nil {
+			t.Fatalf("Command failed: %v\n%s", err, output)
+		}
+		if !strings.HasPrefix(output, "version = 1\n\n[[binaries]]\nid = \"ripgrep\"\n") {
+			t.Errorf("Expected TOML output, got:\n%s", output)
+		}
+
+		if _, err := runConfigCommand(t, "export", "--format", "ini"); err == nil {
+			t.Error("Expected error for unsupported format")
+		}
+	})
+
+	t.Run("merges into config file", func(t *testing.T) {
+		// A relative path to the config file in use is recognised as it
+		t.Chdir(filepath.Dir(path))
+		relative := filepath.Base(path)
+
+		output, err := runConfigCommand(t, "export", "--merge-into", relative)
+		if err != nil {
+			t.Fatalf("Command failed: %v\n%s", err, output)
+		}
+		if !strings.Contains(output, "✓ Exported 1 binaries to "+relative) {
+			t.Errorf("Unexpected output: %s", output)
+		}
+
+		data, _ := os.ReadFile(path)
+		if !strings.HasPrefix(string(data), editTestConfig[:strings.Index(editTestConfig, "    }")]) || !strings.Contains(string(data), `"id": "ripgrep"`) {
+			t.Errorf("Expected ripgrep appended to config, got:\n%s", data)
+		}
+
+		// The exported binary is now managed by the config file
+		synced, err := dbService.Binaries.GetByUserID("ripgrep")
+		if err != nil || synced.Source != "config" {
+			t.Errorf("Expected ripgrep synced from config, got %+v (%v)", synced, err)
+		}
+
+		output, err = runConfigCommand(t, "export", "--merge-into", relative)
+		if err != nil {
+			t.Fatalf("Command failed: %v\n%s", err, output)
+		}
+		if !strings.Contains(output, "No manually added binaries") {
+			t.Errorf("Expected nothing left to export, got: %s", output)
+		}
+	})
+
+	t.Run("merges into other file", func(t *testing.T) {
+		other := filepath.Join(t.TempDir(), "team.json")
+		binary := &database.Binary{UserID: "fd", Name: "fd", Provider: "github", ProviderPath: "sharkdp/fd", Format: ".tar.gz"}
+		dbService.Binaries.Create(binary)
+
+		output, err := runConfigCommand(t, "export", "--merge-into", other)
+		if err != nil {
+			t.Fatalf("Command failed: %v\n%s", err, output)
+		}
+		data, _ := os.ReadFile(other)
+		if !strings.Contains(string(data), `"path": "sharkdp/fd"`) {
+			t.Errorf("Expected fd exported, got:\n%s", data)
+		}
+
+		// Files outside the config in use are not synced
+		if synced, _ := dbService.Binaries.GetByUserID("fd"); synced.Source != "manual" {
+			t.Errorf("Expected fd to stay manual, got %s", synced.Source)
+		}
+	})
+
+	t.Run("merges into project config", func(t *testing.T) {
+		installPath := "/opt/bin"
+		binary := &database.Binary{UserID: "bat", Name: "bat", Provider: "github", ProviderPath: "sharkdp/bat",
+			Format: ".tar.gz", InstallPath: &installPath, Authenticated: true}
+		dbService.Binaries.Create(binary)
+
+		dir := t.TempDir()
+		project := filepath.Join(dir, config.ProjectConfigFile)
+		if err := os.WriteFile(project, []byte("{\"version\": 1, \"binaries\": []}\n"), 0o644); err != nil {
+			t.Fatalf("Failed to write project config: %v", err)
+		}
+		cfg, sources, err := config.ReadConfigFiles([]string{path, project}, project, "silent")
+		if err != nil {
+			t.Fatalf("Failed to read config: %v", err)
+		}
+		Config, Sources = &cfg, &sources
+		t.Chdir(dir)
+
+		output, err := runConfigCommand(t, "export", "--pin", "--merge-into", "./"+config.ProjectConfigFile)
+		if err != nil {
+			t.Fatalf("Command failed: %v\n%s", err, output)
+		}
+		if !strings.Contains(output, "- Left out installPath, authenticated of bat") {
+			t.Errorf("Expected the restricted fields reported, got: %s", output)
+		}
+
+		data, _ := os.ReadFile(project)
+		if !strings.Contains(string(data), `"path": "sharkdp/bat"`) || strings.Contains(string(data), "installPath") || strings.Contains(string(data), "authenticated") {
+			t.Errorf("Expected bat exported without restricted fields, got:\n%s", data)
+		}
+		if synced, _ := dbService.Binaries.GetByUserID("bat"); synced.Source != "project" {
+			t.Errorf("Expected bat synced from the project config, got %s", synced.Source)
+		}
+	})
+}
@@ -0,0 +1,116 @@
+package execcmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"cturner8/binmate/internal/core/config"
+	installSvc "cturner8/binmate/internal/core/install"
+	"cturner8/binmate/internal/core/progress"
+	"cturner8/binmate/internal/core/runner"
+	versionSvc "cturner8/binmate/internal/core/version"
+	"cturner8/binmate/internal/database"
+	"cturner8/binmate/internal/database/repository"
+)
+
+// Package variables will be set by cmd package
+var (
+	Config    *config.Config
+	DBService *repository.Service
+)
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "exec <binary>[@version] [--] [args...]",
+		Short: "Run a specific version of a binary without switching to it",
+		Long: `Run a version of a binary once, leaving the active version unchanged.
+
+The version is installed first if it is missing, without being activated.
+Standard input, output and error are passed through, and binmate exits with
+the binary's exit code. Without a version, the active version is run.
+
+Examples:
+  binmate exec gh@v2.30.0 -- --version      # Run gh 2.30.0
+  binmate exec gh@2.30.0 pr list            # The "v" may be omitted once installed
+  binmate exec gh -- auth status            # Run the active version`,
+		Args:          cobra.MinimumNArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			binaryID, version, _ := strings.Cut(args[0], "@")
+			binaryArgs := args[1:]
+			if len(binaryArgs) > 0 && binaryArgs[0] == "--" {
+				binaryArgs = binaryArgs[1:]
+			}
+
+			binary, err := getBinary(binaryID)
+			if err != nil {
+				return err
+			}
+
+			installation, err := resolveInstallation(cmd, binary, version)
+			if err != nil {
+				return err
+			}
+
+			err = runner.Run(installation.InstalledPath, binaryArgs, cmd.InOrStdin(), cmd.OutOrStdout(), cmd.ErrOrStderr())
+			var exitErr *runner.ExitError
+			if errors.As(err, &exitErr) {
+				// The binary has reported its own errors, only its exit code is passed on
+				cmd.SilenceErrors = true
+			}
+			return err
+		},
+	}
+
+	// Flags after the binary belong to the binary, so "--" is optional
+	cmd.Flags().SetInterspersed(false)
+
+	return cmd
+}
+
+// getBinary returns a binary from the database, syncing it from config if it is not a manual binary
+func getBinary(binaryID string) (*database.Binary, error) {
+	binary, err := DBService.Binaries.GetByUserID(binaryID)
+	if err == nil && binary.Source == "manual" {
+		return binary, nil
+	}
+
+	if err := config.SyncBinary(binaryID, *Config, DBService); err != nil {
+		return nil, fmt.Errorf("binary '%s' not found in database or config: %w", binaryID, err)
+	}
+	return DBService.Binaries.GetByUserID(binaryID)
+}
+
+// resolveInstallation returns the installation to run, installing the version without activating it if needed
+func resolveInstallation(cmd *cobra.Command, binary *database.Binary, version string) (*database.Installation, error) {
+	if version == "" {
+		_, installation, err := DBService.Versions.GetWithInstallation(binary.ID)
+		if err != nil {
+			return nil, fmt.Errorf("no active version of %s, specify one with %s@<version>: %w", binary.UserID, binary.UserID, err)
+		}
+		return installation, nil
+	}
+
+	installation, err := versionSvc.FindInstallation(binary, version, DBService)
+	if err == nil {
+		return installation, nil
+	} else if !errors.Is(err, database.ErrNotFound) {
+		return nil, fmt.Errorf("failed to get installation: %w", err)
+	}
+
+	// Progress goes to stderr so the binary's output can be piped
+	fmt.Fprintf(cmd.ErrOrStderr(), "Installing %s version %s...\n", binary.UserID, version)
+	opts := installSvc.InstallOptions{
+		Progress:   progress.NewBar(cmd.ErrOrStderr(), binary.UserID),
+		NoActivate: true,
+	}
+	result, err := installSvc.InstallBinaryWithOptions(cmd.Context(), binary.UserID, version, DBService, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to install %s version %s: %w", binary.UserID, version, err)
+	}
+	return result.Installation, nil
+}
@@ -0,0 +1,157 @@
+package execcmd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"cturner8/binmate/internal/core/config"
+	"cturner8/binmate/internal/core/runner"
+	versionSvc "cturner8/binmate/internal/core/version"
+	"cturner8/binmate/internal/database"
+	"cturner8/binmate/internal/database/repository"
+)
+
+func setupTestEnv(t *testing.T) (*repository.Service, *config.Config, func()) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "test.db")
+
+	db, err := database.Initialize(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to initialize database: %v", err)
+	}
+
+	dbService := repository.NewService(db)
+
+	cfg := &config.Config{
+		Version:  1,
+		Binaries: []config.Binary{},
+	}
+
+	cleanup := func() {
+		db.Close()
+	}
+
+	return dbService, cfg, cleanup
+}
+
+// createTestBinary creates a binary with an active v1.1.0 and an inactive v1.0.0,
+// each a script printing its version and arguments and exiting with its first argument
+func createTestBinary(t *testing.T, dbService *repository.Service) *database.Binary {
+	binary := &database.Binary{
+		UserID:       "testbin",
+		Name:         "testbin",
+		Provider:     "github",
+		ProviderPath: "owner/repo",
+		Format:       ".tar.gz",
+	}
+	if err := dbService.Binaries.Create(binary); err != nil {
+		t.Fatalf("Failed to create test binary: %v", err)
+	}
+
+	for _, version := range []string{"v1.0.0", "v1.1.0"} {
+		binaryPath := filepath.Join(t.TempDir(), "testbin")
+		script := "#!/bin/sh\necho " + version + " \"$@\"\nexit $1\n"
+		if err := os.WriteFile(binaryPath, []byte(script), 0755); err != nil {
+			t.Fatalf("Failed to create test binary file: %v", err)
+		}
+		installation := &database.Installation{
+			BinaryID:      binary.ID,
+			Version:       version,
+			InstalledPath: binaryPath,
+			SourceURL:     "https://example.com/" + version,
+		}
+		if err := dbService.Installations.Create(installation); err != nil {
+			t.Fatalf("Failed to create installation: %v", err)
+		}
+		if err := versionSvc.ActivateInstallation(binary, installation, dbService); err != nil {
+			t.Fatalf("Failed to activate installation: %v", err)
+		}
+	}
+	return binary
+}
+
+func TestExecCommand_NonExistent(t *testing.T) {
+	dbService, cfg, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	Config = cfg
+	DBService = dbService
+
+	cmd := NewCommand()
+	cmd.SetArgs([]string{"nonexistent@v1.0.0"})
+
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+
+	if err := cmd.Execute(); err == nil {
+		t.Error("Expected error for non-existent binary, got none")
+	}
+}
+
+func TestExecCommand_Version(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell scripts are not executable on windows")
+	}
+
+	dbService, cfg, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	Config = cfg
+	DBService = dbService
+
+	t.Setenv("HOME", t.TempDir())
+	binary := createTestBinary(t, dbService)
+	before, err := dbService.Versions.Get(binary.ID)
+	if err != nil {
+		t.Fatalf("Failed to get active version: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		want     string
+		wantCode int
+	}{
+		{name: "explicit version", args: []string{"testbin@v1.0.0", "--", "0", "--help"}, want: "v1.0.0 0 --help\n"},
+		{name: "version without v", args: []string{"testbin@1.0.0", "0", "--flag"}, want: "v1.0.0 0 --flag\n"},
+		{name: "active version", args: []string{"testbin", "0"}, want: "v1.1.0 0\n"},
+		{name: "exit code", args: []string{"testbin@v1.0.0", "--", "3"}, want: "v1.0.0 3\n", wantCode: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewCommand()
+			cmd.SetArgs(tt.args)
+
+			buf := new(bytes.Buffer)
+			cmd.SetOut(buf)
+			cmd.SetErr(buf)
+
+			err := cmd.Execute()
+			var exitErr *runner.ExitError
+			switch {
+			case tt.wantCode != 0 && (!errors.As(err, &exitErr) || exitErr.Code != tt.wantCode):
+				t.Errorf("Expected exit status %d, got: %v", tt.wantCode, err)
+			case tt.wantCode == 0 && err != nil:
+				t.Fatalf("Command failed: %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("Output = %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+
+	// The active version is left untouched
+	after, err := dbService.Versions.Get(binary.ID)
+	if err != nil {
+		t.Fatalf("Failed to get active version: %v", err)
+	}
+	if after.InstallationID != before.InstallationID || after.ActivatedAt != before.ActivatedAt {
+		t.Errorf("Active version changed from %+v to %+v", before, after)
+	}
+}
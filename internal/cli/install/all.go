@@ -0,0 +1,120 @@
+package install
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	binarySvc "cturner8/binmate/internal/core/binary"
+	"cturner8/binmate/internal/core/config"
+	installSvc "cturner8/binmate/internal/core/install"
+	"cturner8/binmate/internal/core/parallel"
+)
+
+// installAll syncs the config, refreshed and validated as by sync, and brings every configured
+// binary to its desired version. Binaries removed from config are kept unless prune is set,
+// when their files are removed.
+func installAll(ctx context.Context, out io.Writer, concurrency int, prune bool) error {
+	if err := config.PrepareSync(Config, Sources); err != nil {
+		return err
+	}
+
+	plan, err := config.PlanSync(*Config, DBService)
+	if err != nil {
+		return err
+	}
+	if err := config.ApplySync(plan, DBService, config.SyncOptions{KeepRemoved: true}); err != nil {
+		return fmt.Errorf("error syncing to database: %w", err)
+	}
+
+	ids := make([]string, len(Config.Binaries))
+	for i, binary := range Config.Binaries {
+		ids[i] = binary.Id
+	}
+	if len(ids) == 0 {
+		fmt.Fprintln(out, "No binaries configured")
+	}
+
+	type reconcileResult struct {
+		result *installSvc.ReconcileResult
+		err    error
+	}
+
+	workers := config.ResolveConcurrency(concurrency, Config.Global)
+	results := parallel.Map(ids, workers, func(binary string) reconcileResult {
+		// Progress bars are not shown for parallel installs as they would interleave
+		result, err := reconcileWithLogging(ctx, binary)
+		return reconcileResult{result: result, err: err}
+	})
+
+	counts := map[string]int{}
+	var failed []string
+	for i, binary := range ids {
+		r := results[i]
+		if r.err != nil {
+			fmt.Fprintf(out, "⚠ Failed to install %s: %v\n", binary, r.err)
+			failed = append(failed, binary)
+			continue
+		}
+		counts[r.result.Action]++
+
+		version := installSvc.DisplayVersion(r.result.Binary, r.result.Version)
+		switch {
+		case r.result.Action == installSvc.ReconcileUpToDate:
+			fmt.Fprintf(out, "✓ %s %s is up to date\n", binary, version)
+		case r.result.Previous != "":
+			fmt.Fprintf(out, "✓ %s %s %s (was %s)\n", binary, version, r.result.Action, installSvc.DisplayVersion(r.result.Binary, r.result.Previous))
+		default:
+			fmt.Fprintf(out, "✓ %s %s %s\n", binary, version, r.result.Action)
+		}
+	}
+
+	installed := len(ids) - len(failed)
+	removed := 0
+	for _, binary := range plan.Remove {
+		if !prune {
+			fmt.Fprintf(out, "- %s is no longer in config, kept (use --prune to remove it)\n", binary.UserID)
+			continue
+		}
+		if err := binarySvc.RemoveBinary(binary.UserID, DBService, true); err != nil {
+			fmt.Fprintf(out, "⚠ Failed to remove %s: %v\n", binary.UserID, err)
+			failed = append(failed, binary.UserID)
+			continue
+		}
+		fmt.Fprintf(out, "✓ %s removed, as it is no longer in config\n", binary.UserID)
+		removed++
+	}
+
+	fmt.Fprintf(out, "\n✓ Reconciled %d/%d binaries: %d installed, %d activated, %d up to date, %d removed\n",
+		installed, len(ids),
+		counts[installSvc.ReconcileInstalled], counts[installSvc.ReconcileActivated], counts[installSvc.ReconcileUpToDate], removed)
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to install: %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// reconcileWithLogging reconciles a binary and records the operation in the logs table
+func reconcileWithLogging(ctx context.Context, binary string) (*installSvc.ReconcileResult, error) {
+	start := time.Now()
+
+	id, err := DBService.Logs.LogStart("install", "", "", "start install process")
+	if err != nil {
+		return nil, fmt.Errorf("install start error: %w", err)
+	}
+
+	DBService.Logs.LogEntity(id, binary, "")
+
+	result, err := installSvc.ReconcileBinary(ctx, binary, DBService, installSvc.InstallOptions{})
+	if err != nil {
+		msg := "installation failed"
+		DBService.Logs.LogFailure(id, msg, int64(time.Since(start)))
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	DBService.Logs.LogEntity(id, binary, result.Version)
+	DBService.Logs.LogSuccess(id, int64(time.Since(start)))
+	return result, nil
+}
@@ -1,83 +1,178 @@
 package install
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	"cturner8/binmate/internal/core/config"
 	installSvc "cturner8/binmate/internal/core/install"
+	"cturner8/binmate/internal/core/parallel"
+	"cturner8/binmate/internal/core/progress"
 	"cturner8/binmate/internal/database/repository"
 )
 
-// Package variable will be set by cmd package
+// Package variables will be set by cmd package
 var (
 	Config    *config.Config
+	Sources   *config.Sources
 	DBService *repository.Service
 )
 
 func NewCommand() *cobra.Command {
 	var (
-		binary  string
-		version string
+		binaries    []string
+		version     string
+		concurrency int
+		all         bool
+		prune       bool
 	)
 
 	cmd := &cobra.Command{
-		Use:           "install",
-		Short:         "Install a new binary version",
+		Use:   "install",
+		Short: "Install a new binary version",
+		Long: `Install a version of one or more binaries and set it as the active version.
+
+When multiple binaries are given they are downloaded and extracted in parallel.
+
+With --all the config is synced and every configured binary is brought to its
+desired version: its pin, or the latest release allowed by its version constraint
+and channel. Versions already installed are only activated, so it can be run
+repeatedly, e.g. from a dotfiles bootstrap script. Binaries no longer in config
+are kept unless --prune is given, which removes them and their files.
+
+Examples:
+  binmate install --binary gh                      # Install the latest gh release
+  binmate install --binary gh --version v2.40.0    # Install a specific version
+  binmate install -b gh -b fzf -b ripgrep -j 8     # Install several binaries in parallel
+  binmate install --all                            # Install every configured binary
+  binmate install --all --prune                    # Also remove binaries no longer in config`,
 		Aliases:       []string{"i"},
 		SilenceUsage:  true,  // Don't show usage on runtime errors
 		SilenceErrors: false, // Still print errors
 		PreRunE: func(cmd *cobra.Command, args []string) error {
-			// Check if binary exists in database first
-			existingBinary, err := DBService.Binaries.GetByUserID(binary)
-			if err == nil {
-				// Binary exists - check if it's manually added
-				if existingBinary.Source == "manual" {
-					// Manually added binary, don't sync from config
-					return nil
+			if all {
+				if len(binaries) > 0 {
+					return fmt.Errorf("--binary cannot be used with --all")
 				}
+				if cmd.Flags().Changed("version") {
+					return fmt.Errorf("--version cannot be used with --all, pin binaries in config instead")
+				}
+				return nil
+			}
+			if prune {
+				return fmt.Errorf("--prune can only be used with --all")
+			}
+			if len(binaries) == 0 {
+				return fmt.Errorf("--binary or --all is required")
 			}
 
-			// try to sync from config
-			if err := config.SyncBinary(binary, *Config, DBService); err != nil {
-				return fmt.Errorf("binary '%s' not found in database or config: %w", binary, err)
+			if len(binaries) > 1 && version != "latest" {
+				return fmt.Errorf("--version can only be used when installing a single binary")
+			}
+
+			for _, binary := range binaries {
+				// Check if binary exists in database first
+				existingBinary, err := DBService.Binaries.GetByUserID(binary)
+				if err == nil {
+					// Binary exists - check if it's manually added
+					if existingBinary.Source == "manual" {
+						// Manually added binary, don't sync from config
+						continue
+					}
+				}
+
+				// try to sync from config
+				if err := config.SyncBinary(binary, *Config, DBService); err != nil {
+					return fmt.Errorf("binary '%s' not found in database or config: %w", binary, err)
+				}
 			}
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			start := time.Now()
-
-			id, err := DBService.Logs.LogStart("install", "", "", "start install process")
-			if err != nil {
-				return fmt.Errorf("sync start error: %w", err)
+			if all {
+				return installAll(cmd.Context(), cmd.OutOrStdout(), concurrency, prune)
 			}
 
-			fmt.Fprintf(cmd.OutOrStdout(), "Installing %s version %s...\n", binary, version)
+			if len(binaries) == 1 {
+				fmt.Fprintf(cmd.OutOrStdout(), "Installing %s version %s...\n", binaries[0], version)
+
+				opts := installSvc.InstallOptions{
+					Progress: progress.NewBar(cmd.OutOrStdout(), binaries[0]),
+				}
+				result, err := installWithLogging(cmd.Context(), binaries[0], version, opts)
+				if err != nil {
+					return err
+				}
+
+				fmt.Fprintf(cmd.OutOrStdout(), "✓ Successfully installed %s version %s\n", binaries[0], result.Version)
+				return nil
+			}
 
-			DBService.Logs.LogEntity(id, binary, version)
+			fmt.Fprintf(cmd.OutOrStdout(), "Installing %d binaries...\n", len(binaries))
 
-			// Use the service layer to install the binary
-			result, err := installSvc.InstallBinary(binary, version, DBService)
-			if err != nil {
-				msg := "installation failed"
-				DBService.Logs.LogFailure(id, msg, int64(time.Since(start)))
-				return fmt.Errorf("%s: %w", msg, err)
+			type installResult struct {
+				result *installSvc.InstallBinaryResult
+				err    error
 			}
 
-			DBService.Logs.LogSuccess(id, int64(time.Since(start)))
+			workers := config.ResolveConcurrency(concurrency, Config.Global)
+			results := parallel.Map(binaries, workers, func(binary string) installResult {
+				// Progress bars are not shown for parallel installs as they would interleave
+				result, err := installWithLogging(cmd.Context(), binary, version, installSvc.InstallOptions{})
+				return installResult{result: result, err: err}
+			})
+
+			var failed []string
+			for i, binary := range binaries {
+				r := results[i]
+				if r.err != nil {
+					fmt.Fprintf(cmd.OutOrStdout(), "⚠ Failed to install %s: %v\n", binary, r.err)
+					failed = append(failed, binary)
+					continue
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "✓ Successfully installed %s version %s\n", binary, r.result.Version)
+			}
 
-			fmt.Fprintf(cmd.OutOrStdout(), "✓ Successfully installed %s version %s\n", binary, result.Version)
+			fmt.Fprintf(cmd.OutOrStdout(), "\n✓ Installed %d/%d binaries\n", len(binaries)-len(failed), len(binaries))
+			if len(failed) > 0 {
+				return fmt.Errorf("failed to install: %s", strings.Join(failed, ", "))
+			}
 			return nil
 		},
 	}
 
-	cmd.Flags().StringVarP(&binary, "binary", "b", "", "binary to be installed")
+	cmd.Flags().StringSliceVarP(&binaries, "binary", "b", nil, "binary to be installed (repeatable or comma-separated)")
 	cmd.Flags().StringVarP(&version, "version", "v", "latest", "version of the binary to be installed")
-
-	// Mark required flags
-	cmd.MarkFlagRequired("binary")
+	cmd.Flags().IntVarP(&concurrency, "concurrency", "j", 0, "number of binaries to install in parallel (defaults to global.concurrency or 4)")
+	cmd.Flags().BoolVarP(&all, "all", "a", false, "install the desired version of every configured binary")
+	cmd.Flags().BoolVar(&prune, "prune", false, "with --all, remove binaries no longer in config and their files")
 
 	return cmd
 }
+
+// installWithLogging installs a binary version and records the operation in the logs table
+func installWithLogging(ctx context.Context, binary string, version string, opts installSvc.InstallOptions) (*installSvc.InstallBinaryResult, error) {
+	start := time.Now()
+
+	id, err := DBService.Logs.LogStart("install", "", "", "start install process")
+	if err != nil {
+		return nil, fmt.Errorf("sync start error: %w", err)
+	}
+
+	DBService.Logs.LogEntity(id, binary, version)
+
+	// Use the service layer to install the binary
+	result, err := installSvc.InstallBinaryWithOptions(ctx, binary, version, DBService, opts)
+	if err != nil {
+		msg := "installation failed"
+		DBService.Logs.LogFailure(id, msg, int64(time.Since(start)))
+		return nil, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	DBService.Logs.LogSuccess(id, int64(time.Since(start)))
+	return result, nil
+}
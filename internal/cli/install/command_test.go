@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"cturner8/binmate/internal/core/config"
@@ -388,3 +389,154 @@ func TestMain(m *testing.M) {
 	code := m.Run()
 	os.Exit(code)
 }
+
+func TestInstallCommand_VersionWithMultipleBinaries(t *testing.T) {
+	Config = &config.Config{Version: 1}
+
+	cmd := NewCommand()
+	if err := cmd.ParseFlags([]string{"--binary", "gh,fzf", "--version", "v1.0.0"}); err != nil {
+		t.Fatalf("Failed to parse flags: %v", err)
+	}
+
+	err := cmd.PreRunE(cmd, []string{})
+	if err == nil {
+		t.Fatal("PreRunE should fail when --version is used with multiple binaries")
+	}
+	if !strings.Contains(err.Error(), "single binary") {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestInstallCommand_AllFlags(t *testing.T) {
+	Config = &config.Config{Version: 1}
+
+	tests := []struct {
+		args    []string
+		message string
+	}{
+		{[]string{"--all", "--binary", "gh"}, "--binary cannot be used with --all"},
+		{[]string{"--all", "--version", "v1.0.0"}, "--version cannot be used with --all"},
+		{[]string{"--binary", "gh", "--prune"}, "--prune can only be used with --all"},
+		{[]string{}, "--binary or --all is required"},
+	}
+
+	for _, tt := range tests {
+		cmd := NewCommand()
+		if err := cmd.ParseFlags(tt.args); err != nil {
+			t.Fatalf("Failed to parse flags: %v", err)
+		}
+		err := cmd.PreRunE(cmd, []string{})
+		if err == nil || !strings.Contains(err.Error(), tt.message) {
+			t.Errorf("%v: expected error containing %q, got %v", tt.args, tt.message, err)
+		}
+	}
+}
+
+func TestInstallCommand_AllPrunesRemovedBinaries(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+
+	db, err := database.Initialize(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to initialize test database: %v", err)
+	}
+	defer db.Close()
+
+	dbService := repository.NewService(db)
+	DBService = dbService
+	Config = &config.Config{
+		Version: 1,
+		Binaries: []config.Binary{
+			{Id: "old", Name: "old", Provider: "github", Path: "owner/old", Format: ".tar.gz"},
+		},
+	}
+	if err := config.SyncToDatabase(*Config, dbService); err != nil {
+		t.Fatalf("Failed to sync config: %v", err)
+	}
+
+	old, _ := dbService.Binaries.GetByUserID("old")
+	installedPath := filepath.Join(t.TempDir(), "old")
+	if err := os.WriteFile(installedPath, []byte("old"), 0o755); err != nil {
+		t.Fatalf("Failed to write binary: %v", err)
+	}
+	installation := &database.Installation{BinaryID: old.ID, Version: "v1.0.0", InstalledPath: installedPath, SourceURL: "https://example.com/old"}
+	if err := dbService.Installations.Create(installation); err != nil {
+		t.Fatalf("Failed to create installation: %v", err)
+	}
+
+	Config.Binaries = nil
+
+	run := func(args ...string) string {
+		t.Helper()
+		cmd := NewCommand()
+		cmd.SetArgs(args)
+		var buf bytes.Buffer
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("%v failed: %v\n%s", args, err, buf.String())
+		}
+		return buf.String()
+	}
+
+	// Binaries no longer in config are kept by default
+	output := run("--all")
+	if !strings.Contains(output, "old is no longer in config, kept") {
+		t.Errorf("Expected old to be kept, got:\n%s", output)
+	}
+	if _, err := os.Stat(installedPath); err != nil {
+		t.Errorf("Expected old files to be kept: %v", err)
+	}
+
+	output = run("--all", "--prune")
+	if !strings.Contains(output, "✓ old removed") || !strings.Contains(output, "0 up to date, 1 removed") {
+		t.Errorf("Expected old to be removed, got:\n%s", output)
+	}
+	if _, err := os.Stat(installedPath); !os.IsNotExist(err) {
+		t.Errorf("Expected old files to be removed, got %v", err)
+	}
+	if _, err := dbService.Binaries.GetByUserID("old"); err != database.ErrNotFound {
+		t.Errorf("Expected old to be removed from the database, got %v", err)
+	}
+
+	// Running again changes nothing
+	output = run("--all", "--prune")
+	if !strings.Contains(output, "0 installed, 0 activated, 0 up to date, 0 removed") {
+		t.Errorf("Expected no changes, got:\n%s", output)
+	}
+}
+
+func TestInstallCommand_AllValidatesConfig(t *testing.T) {
+	db, err := database.Initialize(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to initialize test database: %v", err)
+	}
+	defer db.Close()
+
+	dbService := repository.NewService(db)
+	DBService = dbService
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	content := `{"version": 1, "binaries": [{"id": "gh", "name": "gh", "provider": "github", "path": "cli/cli", "format": ".tar.gz", "assetRegex": "["}]}`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	cfg, sources, err := config.ReadConfigFiles([]string{path}, "", "silent")
+	if err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+	Config, Sources = &cfg, &sources
+	t.Cleanup(func() { Sources = nil })
+
+	cmd := NewCommand()
+	cmd.SetArgs([]string{"--all"})
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "invalid config") {
+		t.Errorf("Expected an invalid config error, got %v", err)
+	}
+	if _, err := dbService.Binaries.GetByUserID("gh"); err != database.ErrNotFound {
+		t.Errorf("Expected gh not to be synced, got %v", err)
+	}
+}
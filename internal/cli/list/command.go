@@ -7,6 +7,7 @@ import (
 
 	binarySvc "cturner8/binmate/internal/core/binary"
 	"cturner8/binmate/internal/core/config"
+	installSvc "cturner8/binmate/internal/core/install"
 	"cturner8/binmate/internal/database/repository"
 )
 
@@ -40,13 +41,18 @@ Example:
 				return nil
 			}
 
-			fmt.Fprintf(cmd.OutOrStdout(), "%-20s %-15s %-10s %s\n", "Binary", "Active Version", "Installed", "Provider")
+			fmt.Fprintf(cmd.OutOrStdout(), "%-20s %-15s %-10s %-15s %s\n", "Binary", "Active Version", "Installed", "Pinned", "Provider")
 			fmt.Fprintln(cmd.OutOrStdout(), "---")
 			for _, b := range binaries {
-				fmt.Fprintf(cmd.OutOrStdout(), "%-20s %-15s %-10d %s:%s\n",
+				pinned := "-"
+				if b.PinnedVersion != "" {
+					pinned = installSvc.DisplayVersion(b.Binary, b.PinnedVersion)
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "%-20s %-15s %-10d %-15s %s:%s\n",
 					b.Binary.Name,
-					b.ActiveVersion,
+					installSvc.DisplayVersion(b.Binary, b.ActiveVersion),
 					b.InstallCount,
+					pinned,
 					b.Binary.Provider,
 					b.Binary.ProviderPath,
 				)
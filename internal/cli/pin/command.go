@@ -0,0 +1,63 @@
+package pin
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"cturner8/binmate/internal/core/config"
+	installSvc "cturner8/binmate/internal/core/install"
+	versionSvc "cturner8/binmate/internal/core/version"
+	"cturner8/binmate/internal/database/repository"
+)
+
+// Package variables will be set by cmd package
+var (
+	Config    *config.Config
+	DBService *repository.Service
+)
+
+func NewCommand() *cobra.Command {
+	var (
+		binaryID string
+		version  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "pin",
+		Short: "Pin a binary to a version",
+		Long: `Pin a binary to a version so that 'update --all', 'check --all' and the TUI "update all" skip it.
+
+Without --version the active version is pinned. With --version the version is installed
+if needed and activated before being pinned.
+
+Examples:
+  binmate pin --binary gh                    # Hold gh at its active version
+  binmate pin --binary gh --version v2.40.0  # Install, activate and hold gh at v2.40.0`,
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if version != "" {
+				result, err := installSvc.InstallBinary(cmd.Context(), binaryID, version, DBService)
+				if err != nil {
+					return fmt.Errorf("failed to install version %s: %w", version, err)
+				}
+				version = result.Version
+			}
+
+			pin, err := versionSvc.PinVersion(binaryID, version, DBService)
+			if err != nil {
+				return fmt.Errorf("failed to pin binary: %w", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "✓ Pinned %s to version %s\n", binaryID, pin.Version)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&binaryID, "binary", "b", "", "Binary ID (required)")
+	cmd.Flags().StringVarP(&version, "version", "v", "", "Version to pin (defaults to the active version)")
+	cmd.MarkFlagRequired("binary")
+
+	return cmd
+}
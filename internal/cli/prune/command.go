@@ -0,0 +1,106 @@
+package prune
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"cturner8/binmate/internal/core/config"
+	installSvc "cturner8/binmate/internal/core/install"
+	versionSvc "cturner8/binmate/internal/core/version"
+	"cturner8/binmate/internal/database"
+	"cturner8/binmate/internal/database/repository"
+)
+
+// Package variables will be set by cmd package
+var (
+	Config    *config.Config
+	DBService *repository.Service
+)
+
+func NewCommand() *cobra.Command {
+	var (
+		binaryID string
+		keep     int
+		dryRun   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Remove old installed versions",
+		Long: `Remove old installed versions, keeping the newest ones.
+
+Without --keep, each binary's keepVersions setting is used and binaries without
+one are skipped. The active and pinned versions are never removed.
+
+Examples:
+  binmate prune --keep 3                  # Keep the newest 3 versions of every binary
+  binmate prune --binary gh --keep 1      # Keep only the newest version of gh
+  binmate prune                           # Apply each binary's keepVersions setting
+  binmate prune --keep 2 --dry-run        # Show what would be removed`,
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			keepSet := cmd.Flags().Changed("keep")
+			if keepSet && keep < 1 {
+				return fmt.Errorf("--keep must be at least 1")
+			}
+
+			var binaries []*database.Binary
+			if binaryID != "" {
+				binary, err := DBService.Binaries.GetByUserID(binaryID)
+				if err != nil {
+					return fmt.Errorf("binary not found: %w", err)
+				}
+				binaries = append(binaries, binary)
+			} else {
+				all, err := DBService.Binaries.List()
+				if err != nil {
+					return fmt.Errorf("failed to list binaries: %w", err)
+				}
+				binaries = all
+			}
+
+			verb := "Removed"
+			if dryRun {
+				verb = "Would remove"
+			}
+
+			total := 0
+			for _, binary := range binaries {
+				binaryKeep := keep
+				if !keepSet {
+					if binary.KeepVersions == nil || *binary.KeepVersions <= 0 {
+						if binaryID != "" {
+							return fmt.Errorf("%s has no keepVersions setting, use --keep", binary.UserID)
+						}
+						continue
+					}
+					binaryKeep = *binary.KeepVersions
+				}
+
+				pruned, err := versionSvc.PruneVersions(binary.UserID, binaryKeep, dryRun, DBService)
+				for _, installation := range pruned {
+					fmt.Fprintf(cmd.OutOrStdout(), "✓ %s %s version %s\n", verb, binary.UserID, installSvc.DisplayVersion(binary, installation.Version))
+				}
+				total += len(pruned)
+				if err != nil {
+					return fmt.Errorf("failed to prune %s: %w", binary.UserID, err)
+				}
+			}
+
+			if total == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No versions to prune")
+				return nil
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "\n%s %d version(s)\n", verb, total)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&binaryID, "binary", "b", "", "Binary ID to prune (defaults to all binaries)")
+	cmd.Flags().IntVarP(&keep, "keep", "k", 0, "Number of newest versions to keep (defaults to each binary's keepVersions)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the versions that would be removed without removing them")
+
+	return cmd
+}
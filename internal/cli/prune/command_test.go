@@ -0,0 +1,180 @@
+package prune
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"cturner8/binmate/internal/core/config"
+	versionSvc "cturner8/binmate/internal/core/version"
+	"cturner8/binmate/internal/database"
+	"cturner8/binmate/internal/database/repository"
+)
+
+func setupTestEnv(t *testing.T) (*repository.Service, *config.Config, func()) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "test.db")
+
+	db, err := database.Initialize(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to initialize database: %v", err)
+	}
+
+	dbService := repository.NewService(db)
+
+	cfg := &config.Config{
+		Version:  1,
+		Binaries: []config.Binary{},
+	}
+
+	cleanup := func() {
+		db.Close()
+	}
+
+	return dbService, cfg, cleanup
+}
+
+func TestPruneCommand_NonExistent(t *testing.T) {
+	dbService, cfg, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	Config = cfg
+	DBService = dbService
+
+	cmd := NewCommand()
+	cmd.SetArgs([]string{"--binary", "nonexistent"})
+
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+
+	if err := cmd.Execute(); err == nil {
+		t.Error("Expected error for non-existent binary, got none")
+	}
+}
+
+func createTestBinaryWithVersions(t *testing.T, dbService *repository.Service, keepVersions *int, versions ...string) *database.Binary {
+	binary := &database.Binary{
+		UserID:       "testbin",
+		Name:         "testbin",
+		Provider:     "github",
+		ProviderPath: "owner/repo",
+		Format:       ".tar.gz",
+		KeepVersions: keepVersions,
+	}
+	if err := dbService.Binaries.Create(binary); err != nil {
+		t.Fatalf("Failed to create test binary: %v", err)
+	}
+
+	for _, version := range versions {
+		binaryPath := filepath.Join(t.TempDir(), "testbin")
+		if err := os.WriteFile(binaryPath, []byte(version), 0755); err != nil {
+			t.Fatalf("Failed to create test binary file: %v", err)
+		}
+		installation := &database.Installation{
+			BinaryID:      binary.ID,
+			Version:       version,
+			InstalledPath: binaryPath,
+			SourceURL:     "https://example.com/" + version,
+		}
+		if err := dbService.Installations.Create(installation); err != nil {
+			t.Fatalf("Failed to create installation: %v", err)
+		}
+		if err := versionSvc.ActivateInstallation(binary, installation, dbService); err != nil {
+			t.Fatalf("Failed to activate installation: %v", err)
+		}
+	}
+	return binary
+}
+
+func TestPruneCommand_Keep(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	dbService, cfg, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	Config = cfg
+	DBService = dbService
+
+	binary := createTestBinaryWithVersions(t, dbService, nil, "v1.0.0", "v1.1.0", "v1.2.0")
+
+	// Dry run leaves every version installed
+	cmd := NewCommand()
+	cmd.SetArgs([]string{"--keep", "1", "--dry-run"})
+
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Would remove 2 version(s)") {
+		t.Errorf("Expected dry run summary, got: %s", buf.String())
+	}
+	if installations, _ := dbService.Installations.ListByBinary(binary.ID); len(installations) != 3 {
+		t.Errorf("Expected 3 installations after dry run, got %d", len(installations))
+	}
+
+	cmd = NewCommand()
+	cmd.SetArgs([]string{"--keep", "1"})
+	buf.Reset()
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Removed testbin version v1.0.0") {
+		t.Errorf("Expected removal message, got: %s", buf.String())
+	}
+	if installations, _ := dbService.Installations.ListByBinary(binary.ID); len(installations) != 1 {
+		t.Errorf("Expected 1 installation after prune, got %d", len(installations))
+	}
+}
+
+func TestPruneCommand_KeepVersionsSetting(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	dbService, cfg, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	Config = cfg
+	DBService = dbService
+
+	keep := 2
+	binary := createTestBinaryWithVersions(t, dbService, &keep, "v1.0.0", "v1.1.0", "v1.2.0")
+
+	cmd := NewCommand()
+	cmd.SetArgs([]string{})
+
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if installations, _ := dbService.Installations.ListByBinary(binary.ID); len(installations) != 2 {
+		t.Errorf("Expected 2 installations after prune, got %d", len(installations))
+	}
+}
+
+func TestPruneCommand_InvalidKeep(t *testing.T) {
+	dbService, cfg, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	Config = cfg
+	DBService = dbService
+
+	cmd := NewCommand()
+	cmd.SetArgs([]string{"--keep", "0"})
+
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+
+	if err := cmd.Execute(); err == nil {
+		t.Error("Expected error for --keep 0, got none")
+	}
+}
@@ -0,0 +1,126 @@
+package releases
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"cturner8/binmate/internal/core/config"
+	"cturner8/binmate/internal/core/format"
+	installSvc "cturner8/binmate/internal/core/install"
+	"cturner8/binmate/internal/database/repository"
+	"cturner8/binmate/internal/providers/github"
+)
+
+// Package variables will be set by cmd package
+var (
+	Config    *config.Config
+	DBService *repository.Service
+)
+
+// releasesPerPage is the page size requested from the provider
+const releasesPerPage = 30
+
+func NewCommand() *cobra.Command {
+	var (
+		binaryID string
+		listAll  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "releases",
+		Short: "List releases available from the provider",
+		Long: `List releases published by the provider for a binary, newest first.
+Installed versions are marked, with '*' marking the active version.
+
+By default only the most recent page of releases is shown. Use --all to page
+through the full release history.
+
+Examples:
+  binmate releases --binary gh              # List recent releases of gh
+  binmate releases --binary gh --all        # List every release of gh`,
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			binary, err := DBService.Binaries.GetByUserID(binaryID)
+			if err != nil {
+				return fmt.Errorf("binary not found: %w", err)
+			}
+
+			if binary.Provider != "github" {
+				return fmt.Errorf("only github provider is currently supported")
+			}
+
+			// Collect installed and active versions for the markers
+			installations, err := DBService.Installations.ListByBinary(binary.ID)
+			if err != nil {
+				return fmt.Errorf("failed to list installations: %w", err)
+			}
+			installed := make(map[string]bool, len(installations))
+			for _, installation := range installations {
+				installed[installation.Version] = true
+			}
+			activeVersion := ""
+			if _, installation, err := DBService.Versions.GetWithInstallation(binary.ID); err == nil {
+				activeVersion = installation.Version
+			}
+
+			dateFormat := ""
+			if Config != nil && Config.DateFormat != "" {
+				dateFormat = Config.DateFormat
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Releases for %s:\n", binaryID)
+			fmt.Fprintln(cmd.OutOrStdout(), "---")
+
+			count := 0
+			pageURL := ""
+			for {
+				page, err := github.ListReleasesPage(cmd.Context(), binary, pageURL, releasesPerPage)
+				if err != nil {
+					return fmt.Errorf("failed to list releases: %w", err)
+				}
+
+				for _, release := range page.Releases {
+					marker := " "
+					if release.TagName == activeVersion {
+						marker = "*"
+					}
+
+					details := ""
+					if release.Prerelease {
+						details += " [pre-release]"
+					}
+					if installed[release.TagName] {
+						details += " (installed)"
+					}
+
+					published := format.FormatTimestamp(release.PublishedAt.Unix(), dateFormat)
+					fmt.Fprintf(cmd.OutOrStdout(), "%s %s (published: %s)%s\n", marker, installSvc.DisplayVersion(binary, release.TagName), published, details)
+					count++
+				}
+
+				if page.NextURL == "" {
+					break
+				}
+				// Pages can be empty when another component's releases fill them, so keep going until one matches
+				if !listAll && count > 0 {
+					fmt.Fprintln(cmd.OutOrStdout(), "\nMore releases available, use --all to list every release")
+					break
+				}
+				pageURL = page.NextURL
+			}
+
+			if count == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No releases found")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&binaryID, "binary", "b", "", "Binary ID to list releases for (required)")
+	cmd.Flags().BoolVarP(&listAll, "all", "a", false, "List every release rather than only the most recent page")
+	cmd.MarkFlagRequired("binary")
+
+	return cmd
+}
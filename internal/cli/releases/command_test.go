@@ -0,0 +1,194 @@
+package releases
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"cturner8/binmate/internal/core/config"
+	"cturner8/binmate/internal/database"
+	"cturner8/binmate/internal/database/repository"
+	"cturner8/binmate/internal/providers/github"
+)
+
+func setupTestEnv(t *testing.T) (*repository.Service, *config.Config, func()) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "test.db")
+
+	db, err := database.Initialize(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to initialize database: %v", err)
+	}
+
+	dbService := repository.NewService(db)
+
+	cfg := &config.Config{
+		Version:  1,
+		Binaries: []config.Binary{},
+	}
+
+	cleanup := func() {
+		db.Close()
+	}
+
+	return dbService, cfg, cleanup
+}
+
+// useTestServer serves two pages of releases through a GitHub mirror
+func useTestServer(t *testing.T) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `[{"tag_name": "v1.0.0", "published_at": "2025-01-01T00:00:00Z"}]`)
+			return
+		}
+		w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/releases?page=2>; rel="next"`)
+		fmt.Fprint(w, `[
+			{"tag_name": "v1.2.0-rc.1", "prerelease": true, "published_at": "2025-03-01T00:00:00Z"},
+			{"tag_name": "v1.1.0", "published_at": "2025-02-01T00:00:00Z"}
+		]`)
+	}))
+	t.Cleanup(server.Close)
+
+	if err := github.Configure(github.ClientSettings{Mirrors: []github.Mirror{{URL: server.URL}}}); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	t.Cleanup(func() { github.Configure(github.ClientSettings{}) })
+}
+
+func TestReleasesCommand_NonExistent(t *testing.T) {
+	dbService, cfg, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	Config = cfg
+	DBService = dbService
+
+	cmd := NewCommand()
+	cmd.SetArgs([]string{"--binary", "nonexistent"})
+
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+
+	if err := cmd.Execute(); err == nil {
+		t.Error("Expected error for non-existent binary, got none")
+	}
+}
+
+func TestReleasesCommand_MissingBinaryFlag(t *testing.T) {
+	dbService, cfg, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	Config = cfg
+	DBService = dbService
+
+	cmd := NewCommand()
+	cmd.SetArgs([]string{})
+
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+
+	if err := cmd.Execute(); err == nil {
+		t.Error("Expected error when binary flag missing, got none")
+	}
+}
+
+func TestReleasesCommand_Pagination(t *testing.T) {
+	dbService, cfg, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	Config = cfg
+	DBService = dbService
+	useTestServer(t)
+
+	binary := &database.Binary{
+		UserID:       "testbin",
+		Name:         "testbin",
+		Provider:     "github",
+		ProviderPath: "owner/repo",
+		Format:       ".tar.gz",
+	}
+	if err := dbService.Binaries.Create(binary); err != nil {
+		t.Fatalf("Failed to create test binary: %v", err)
+	}
+
+	for _, version := range []string{"v1.0.0", "v1.1.0"} {
+		installation := &database.Installation{
+			BinaryID:      binary.ID,
+			Version:       version,
+			InstalledPath: filepath.Join(t.TempDir(), version),
+			SourceURL:     "https://example.com/" + version,
+		}
+		if err := dbService.Installations.Create(installation); err != nil {
+			t.Fatalf("Failed to create installation: %v", err)
+		}
+		if version == "v1.1.0" {
+			if err := dbService.Versions.Set(binary.ID, installation.ID, filepath.Join(t.TempDir(), "testbin")); err != nil {
+				t.Fatalf("Failed to set active version: %v", err)
+			}
+		}
+	}
+
+	tests := []struct {
+		name        string
+		args        []string
+		contains    []string
+		notContains []string
+	}{
+		{
+			name: "first page only",
+			args: []string{"--binary", "testbin"},
+			contains: []string{
+				"  v1.2.0-rc.1 (published: ",
+				"[pre-release]",
+				"* v1.1.0 (published: ",
+				"use --all to list every release",
+			},
+			notContains: []string{"v1.0.0"},
+		},
+		{
+			name: "all pages",
+			args: []string{"-b", "testbin", "--all"},
+			contains: []string{
+				"* v1.1.0",
+				"  v1.0.0 (published: ",
+				"(installed)",
+			},
+			notContains: []string{"use --all"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewCommand()
+			cmd.SetArgs(tt.args)
+
+			buf := new(bytes.Buffer)
+			cmd.SetOut(buf)
+			cmd.SetErr(buf)
+
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("Command failed: %v", err)
+			}
+
+			output := buf.String()
+			for _, want := range tt.contains {
+				if !strings.Contains(output, want) {
+					t.Errorf("Expected output to contain %q, got: %s", want, output)
+				}
+			}
+			for _, unwanted := range tt.notContains {
+				if strings.Contains(output, unwanted) {
+					t.Errorf("Expected output not to contain %q, got: %s", unwanted, output)
+				}
+			}
+		})
+	}
+}
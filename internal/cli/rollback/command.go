@@ -0,0 +1,62 @@
+package rollback
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"cturner8/binmate/internal/core/config"
+	installSvc "cturner8/binmate/internal/core/install"
+	versionSvc "cturner8/binmate/internal/core/version"
+	"cturner8/binmate/internal/database/repository"
+)
+
+// Package variables will be set by cmd package
+var (
+	Config    *config.Config
+	DBService *repository.Service
+)
+
+func NewCommand() *cobra.Command {
+	var (
+		binaryID string
+		steps    int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "rollback",
+		Short: "Roll back to the previously active version",
+		Long: `Re-activate the version of a binary that was active before the current one.
+
+Every switch, install and update is recorded in the binary's activation history.
+Use --steps to go further back. A rollback is itself recorded, so running rollback
+twice returns to the original version.
+
+Examples:
+  binmate rollback --binary gh              # Return gh to its previous version
+  binmate rollback --binary gh --steps 2    # Return gh to the version active two activations ago`,
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			result, err := versionSvc.RollbackVersion(binaryID, steps, DBService)
+			if err != nil {
+				return fmt.Errorf("failed to roll back: %w", err)
+			}
+
+			binary, _ := DBService.Binaries.GetByUserID(binaryID)
+			to := installSvc.DisplayVersion(binary, result.To.Version)
+			if result.From == nil {
+				fmt.Fprintf(cmd.OutOrStdout(), "✓ Rolled back %s to version %s\n", binaryID, to)
+				return nil
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "✓ Rolled back %s from %s to %s\n", binaryID, installSvc.DisplayVersion(binary, result.From.Version), to)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&binaryID, "binary", "b", "", "Binary ID (required)")
+	cmd.Flags().IntVarP(&steps, "steps", "n", 1, "Number of activations to go back")
+	cmd.MarkFlagRequired("binary")
+
+	return cmd
+}
@@ -13,6 +13,7 @@ import (
 // Package variables will be set by cmd package
 var (
 	Config    *config.Config
+	Sources   *config.Sources
 	DBService *repository.Service
 )
 
@@ -24,14 +25,19 @@ func NewCommand() *cobra.Command {
 		SilenceUsage:  true,
 		SilenceErrors: false,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
-			// Sync config to database before launching TUI
-			if err := config.SyncToDatabase(*Config, DBService); err != nil {
+			if err := config.ValidateSources(Sources); err != nil {
+				return fmt.Errorf("invalid config:\n%w", err)
+			}
+
+			// Sync config to database before launching TUI. Binaries removed from config
+			// are only deleted by sync and apply, which list them first.
+			if err := config.SyncToDatabaseWithOptions(*Config, DBService, config.SyncOptions{KeepRemoved: true}); err != nil {
 				return fmt.Errorf("sync error: %w", err)
 			}
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			p := tui.InitProgram(DBService, Config)
+			p := tui.InitProgram(cmd.Context(), DBService, Config)
 			if _, err := p.Run(); err != nil {
 				return fmt.Errorf("TUI error: %w", err)
 			}
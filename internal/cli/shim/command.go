@@ -0,0 +1,181 @@
+package shim
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"cturner8/binmate/internal/core/config"
+	installSvc "cturner8/binmate/internal/core/install"
+	"cturner8/binmate/internal/core/runner"
+	versionSvc "cturner8/binmate/internal/core/version"
+	"cturner8/binmate/internal/database"
+	"cturner8/binmate/internal/database/repository"
+)
+
+// Package variables will be set by cmd package
+var (
+	Config    *config.Config
+	DBService *repository.Service
+)
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "shim",
+		Short: "Manage per-project version shims",
+		Long: `Manage shims that run the version of a binary requested by the current project.
+
+Binaries with "shim": true (or global.shims.enabled) get a shim in their install path
+instead of a symlink. The shim looks for the nearest .binmate-version or .tool-versions
+file from the current directory upwards and runs the version it names, falling back to
+the active version. Version files hold one "<binary> <version>" entry per line:
+
+  terraform 1.5.7
+  golangci-lint v1.55.2
+
+Set global.shims.autoInstall to install missing versions on first use.
+
+Examples:
+  binmate shim which terraform    # Show the version the shim would run here
+  binmate shim refresh            # Rewrite symlinks and shims after changing config`,
+		SilenceUsage:  true,
+		SilenceErrors: false,
+	}
+
+	cmd.AddCommand(newExecCommand(), newWhichCommand(), newRefreshCommand())
+
+	return cmd
+}
+
+// newExecCommand returns the command invoked by shims to run the resolved version
+func newExecCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:           "exec <binary> -- [args...]",
+		Short:         "Run the version of a binary requested by the current project",
+		Hidden:        true,
+		Args:          cobra.MinimumNArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			binary, target, err := resolve(args[0])
+			if err != nil {
+				return err
+			}
+
+			if target.Installation == nil {
+				if !Config.Global.Shims.AutoInstall {
+					return fmt.Errorf("%s version %s requested by %s is not installed, run 'binmate install --binary %s --version %s' or enable global.shims.autoInstall",
+						binary.UserID, target.Version, target.Source, binary.UserID, target.Version)
+				}
+
+				fmt.Fprintf(cmd.ErrOrStderr(), "Installing %s version %s requested by %s\n", binary.UserID, target.Version, target.Source)
+				result, err := installSvc.InstallBinaryWithOptions(cmd.Context(), binary.UserID, target.Version, DBService, installSvc.InstallOptions{NoActivate: true})
+				if err != nil {
+					return fmt.Errorf("failed to install %s version %s: %w", binary.UserID, target.Version, err)
+				}
+				target.Installation = result.Installation
+			}
+
+			err = runner.Run(target.Installation.InstalledPath, args[1:], cmd.InOrStdin(), cmd.OutOrStdout(), cmd.ErrOrStderr())
+			var exitErr *runner.ExitError
+			if errors.As(err, &exitErr) {
+				// The binary has reported its own errors, only its exit code is passed on
+				cmd.SilenceErrors = true
+			}
+			return err
+		},
+	}
+}
+
+func newWhichCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:           "which <binary>",
+		Short:         "Show the version a shim runs in the current directory",
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			binary, target, err := resolve(args[0])
+			if err != nil {
+				return err
+			}
+
+			source := "active version"
+			if target.Source != "" {
+				source = "set by " + target.Source
+			}
+			version := installSvc.DisplayVersion(binary, target.Version)
+
+			if target.Installation == nil {
+				fmt.Fprintf(cmd.OutOrStdout(), "⚠ %s %s (%s) is not installed\n", binary.UserID, version, source)
+				return nil
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%s %s (%s)\n", binary.UserID, installSvc.DisplayVersion(binary, target.Installation.Version), source)
+			fmt.Fprintf(cmd.OutOrStdout(), "  %s\n", target.Installation.InstalledPath)
+			return nil
+		},
+	}
+}
+
+func newRefreshCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "refresh",
+		Short: "Rewrite the symlink or shim of every binary",
+		Long: `Rewrite the command of every binary in its install path, writing shims for binaries
+using shims and symlinks to the active version for the rest. Run after enabling or
+disabling shims in the config file and syncing.`,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			binaries, err := DBService.Binaries.List()
+			if err != nil {
+				return fmt.Errorf("failed to list binaries: %w", err)
+			}
+
+			for _, binary := range binaries {
+				_, installation, err := DBService.Versions.GetWithInstallation(binary.ID)
+				switch {
+				case err == nil:
+					err = versionSvc.ActivateInstallation(binary, installation, DBService)
+				case errors.Is(err, database.ErrNotFound) && binary.Shim:
+					// Shims can run versions requested by version files without an active version
+					_, err = versionSvc.WriteShim(binary)
+				case errors.Is(err, database.ErrNotFound):
+					continue
+				}
+				if err != nil {
+					return fmt.Errorf("failed to refresh %s: %w", binary.UserID, err)
+				}
+
+				kind := "symlink"
+				if binary.Shim {
+					kind = "shim"
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "✓ Refreshed %s (%s)\n", binary.UserID, kind)
+			}
+			return nil
+		},
+	}
+}
+
+// resolve finds the version of a binary requested from the current directory
+func resolve(binaryID string) (*database.Binary, *versionSvc.ShimTarget, error) {
+	binary, err := DBService.Binaries.GetByUserID(binaryID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("binary not found: %w", err)
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to get working directory: %w", err)
+	}
+
+	target, err := versionSvc.ResolveShim(binary, dir, DBService)
+	if err != nil {
+		return nil, nil, err
+	}
+	return binary, target, nil
+}
@@ -0,0 +1,214 @@
+package shim
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"cturner8/binmate/internal/core/config"
+	"cturner8/binmate/internal/core/runner"
+	"cturner8/binmate/internal/database"
+	"cturner8/binmate/internal/database/repository"
+)
+
+func setupTestEnv(t *testing.T) (*repository.Service, *config.Config, func()) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "test.db")
+
+	db, err := database.Initialize(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to initialize database: %v", err)
+	}
+
+	dbService := repository.NewService(db)
+
+	cfg := &config.Config{
+		Version:  1,
+		Binaries: []config.Binary{},
+	}
+
+	cleanup := func() {
+		db.Close()
+	}
+
+	return dbService, cfg, cleanup
+}
+
+// createShimBinary creates a binary with an active v1.0.0 and an inactive v1.1.0,
+// each a script printing its version, its arguments and exiting with its first argument
+func createShimBinary(t *testing.T, dbService *repository.Service) *database.Binary {
+	binary := &database.Binary{
+		UserID:       "testbin",
+		Name:         "testbin",
+		Provider:     "github",
+		ProviderPath: "owner/repo",
+		Format:       ".tar.gz",
+		Shim:         true,
+	}
+	if err := dbService.Binaries.Create(binary); err != nil {
+		t.Fatalf("Failed to create test binary: %v", err)
+	}
+
+	for _, version := range []string{"v1.1.0", "v1.0.0"} {
+		binaryPath := filepath.Join(t.TempDir(), "testbin")
+		script := "#!/bin/sh\necho " + version + " \"$@\"\nexit $1\n"
+		if err := os.WriteFile(binaryPath, []byte(script), 0755); err != nil {
+			t.Fatalf("Failed to create test binary file: %v", err)
+		}
+		installation := &database.Installation{
+			BinaryID:      binary.ID,
+			Version:       version,
+			InstalledPath: binaryPath,
+			SourceURL:     "https://example.com/" + version,
+		}
+		if err := dbService.Installations.Create(installation); err != nil {
+			t.Fatalf("Failed to create installation: %v", err)
+		}
+		if err := dbService.Versions.Set(binary.ID, installation.ID, filepath.Join(t.TempDir(), "testbin")); err != nil {
+			t.Fatalf("Failed to set active version: %v", err)
+		}
+	}
+	return binary
+}
+
+func TestShimCommand_Which(t *testing.T) {
+	dbService, cfg, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	Config = cfg
+	DBService = dbService
+	createShimBinary(t, dbService)
+
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	cmd := NewCommand()
+	cmd.SetArgs([]string{"which", "testbin"})
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "testbin v1.0.0 (active version)") {
+		t.Errorf("Expected active version, got: %s", buf.String())
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, ".tool-versions"), []byte("testbin 2.0.0\n"), 0644); err != nil {
+		t.Fatalf("Failed to write version file: %v", err)
+	}
+
+	cmd = NewCommand()
+	cmd.SetArgs([]string{"which", "testbin"})
+	buf.Reset()
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "2.0.0") || !strings.Contains(buf.String(), "is not installed") {
+		t.Errorf("Expected uninstalled version warning, got: %s", buf.String())
+	}
+}
+
+func TestShimCommand_Exec(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell scripts are not executable on windows")
+	}
+
+	dbService, cfg, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	Config = cfg
+	DBService = dbService
+	createShimBinary(t, dbService)
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, ".binmate-version"), []byte("testbin 1.1.0\n"), 0644); err != nil {
+		t.Fatalf("Failed to write version file: %v", err)
+	}
+	t.Chdir(dir)
+
+	cmd := NewCommand()
+	cmd.SetArgs([]string{"exec", "testbin", "--", "0", "--flag"})
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if buf.String() != "v1.1.0 0 --flag\n" {
+		t.Errorf("Expected output of v1.1.0, got: %q", buf.String())
+	}
+
+	// Exit codes are passed through
+	cmd = NewCommand()
+	cmd.SetArgs([]string{"exec", "testbin", "--", "4"})
+	buf.Reset()
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+
+	var exitErr *runner.ExitError
+	if err := cmd.Execute(); !errors.As(err, &exitErr) || exitErr.Code != 4 {
+		t.Errorf("Expected exit status 4, got: %v", err)
+	}
+
+	// Requested versions that are not installed fail without autoInstall
+	if err := os.WriteFile(filepath.Join(dir, ".binmate-version"), []byte("testbin 2.0.0\n"), 0644); err != nil {
+		t.Fatalf("Failed to write version file: %v", err)
+	}
+
+	cmd = NewCommand()
+	cmd.SetArgs([]string{"exec", "testbin"})
+	buf.Reset()
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "not installed") {
+		t.Errorf("Expected not installed error, got: %v", err)
+	}
+}
+
+func TestShimCommand_Refresh(t *testing.T) {
+	dbService, cfg, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	Config = cfg
+	DBService = dbService
+	binary := createShimBinary(t, dbService)
+
+	installPath := t.TempDir()
+	binary.InstallPath = &installPath
+	if err := dbService.Binaries.Update(binary); err != nil {
+		t.Fatalf("Failed to update binary: %v", err)
+	}
+
+	cmd := NewCommand()
+	cmd.SetArgs([]string{"refresh"})
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Refreshed testbin (shim)") {
+		t.Errorf("Expected refresh message, got: %s", buf.String())
+	}
+
+	version, err := dbService.Versions.Get(binary.ID)
+	if err != nil {
+		t.Fatalf("Failed to get active version: %v", err)
+	}
+	content, err := os.ReadFile(version.SymlinkPath)
+	if err != nil || !strings.Contains(string(content), "shim exec") {
+		t.Errorf("Expected shim at %s, got: %s (%v)", version.SymlinkPath, content, err)
+	}
+}
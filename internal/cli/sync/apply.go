@@ -0,0 +1,75 @@
+package sync
+
+import (
+	"fmt"
+	"time"
+
+	"cturner8/binmate/internal/core/config"
+
+	"github.com/spf13/cobra"
+)
+
+func NewApplyCommand() *cobra.Command {
+	var prune bool
+
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Show and make the changes syncing the configuration would make.",
+		Long: `Show and make the changes syncing the configuration would make.
+
+The plan shown by 'binmate sync --plan' is printed, then applied to the database.
+Binaries removed from config are deleted along with their installation records,
+leaving their files behind, unless --prune=false is given.
+
+Examples:
+  binmate apply                 # Apply the sync plan
+  binmate apply --prune=false   # Apply it, keeping binaries removed from config`,
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			start := time.Now()
+
+			if DBService == nil {
+				return fmt.Errorf("database service not initialised")
+			}
+
+			id, err := DBService.Logs.LogStart("apply", "", "", "start apply process")
+			if err != nil {
+				return fmt.Errorf("apply start error: %w", err)
+			}
+
+			if err := config.PrepareSync(Config, Sources); err != nil {
+				DBService.Logs.LogFailure(id, "invalid config", int64(time.Since(start)))
+				return err
+			}
+
+			plan, err := config.PlanSync(*Config, DBService)
+			if err != nil {
+				DBService.Logs.LogFailure(id, err.Error(), int64(time.Since(start)))
+				return err
+			}
+			printPlan(cmd.OutOrStdout(), plan, !prune)
+
+			if !planChanges(plan, !prune) {
+				DBService.Logs.LogSuccess(id, int64(time.Since(start)))
+				return nil
+			}
+
+			if err := config.ApplySync(plan, DBService, config.SyncOptions{KeepRemoved: !prune}); err != nil {
+				msg := "error applying sync plan"
+				DBService.Logs.LogFailure(id, msg, int64(time.Since(start)))
+				return fmt.Errorf("%s: %w", msg, err)
+			}
+
+			DBService.Logs.LogSuccess(id, int64(time.Since(start)))
+
+			fmt.Fprintln(cmd.OutOrStdout(), "\n✓ Apply complete")
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&prune, "prune", true, "Delete binaries removed from config")
+
+	return cmd
+}
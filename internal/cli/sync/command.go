@@ -13,13 +13,32 @@ import (
 // Package variables will be set by cmd package
 var (
 	Config    *config.Config
+	Sources   *config.Sources
 	DBService *repository.Service
 )
 
 func NewCommand() *cobra.Command {
+	var (
+		planOnly bool
+		prune    bool
+	)
+
 	cmd := &cobra.Command{
-		Use:           "sync",
-		Short:         "Sync the local configuration file with the database.",
+		Use:   "sync",
+		Short: "Sync the local configuration file with the database.",
+		Long: `Sync the local configuration file with the database.
+
+Configs included by URL are fetched again first, so changes to shared configs
+roll out on the next sync.
+
+Binaries removed from config are deleted along with their installation records,
+leaving their files behind. Use --plan to see what a sync would change first,
+and --prune=false to keep removed binaries.
+
+Examples:
+  binmate sync                  # Sync the config to the database
+  binmate sync --plan           # Show the changes without making them
+  binmate sync --prune=false    # Keep binaries removed from config`,
 		SilenceUsage:  true,
 		SilenceErrors: false,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -37,7 +56,26 @@ func NewCommand() *cobra.Command {
 				return fmt.Errorf("%s", msg)
 			}
 
-			if err := config.SyncToDatabase(*Config, DBService); err != nil {
+			if err := config.PrepareSync(Config, Sources); err != nil {
+				DBService.Logs.LogFailure(id, "invalid config", int64(time.Since(start)))
+				return err
+			}
+
+			if planOnly {
+				plan, err := config.PlanSync(*Config, DBService)
+				if err != nil {
+					DBService.Logs.LogFailure(id, err.Error(), int64(time.Since(start)))
+					return err
+				}
+				printPlan(cmd.OutOrStdout(), plan, !prune)
+				if planChanges(plan, !prune) {
+					fmt.Fprintln(cmd.OutOrStdout(), "Run 'binmate apply' to make these changes.")
+				}
+				DBService.Logs.LogSuccess(id, int64(time.Since(start)))
+				return nil
+			}
+
+			if err := config.SyncToDatabaseWithOptions(*Config, DBService, config.SyncOptions{KeepRemoved: !prune}); err != nil {
 				msg := "error syncing to database"
 				DBService.Logs.LogFailure(id, msg, int64(time.Since(start)))
 				return fmt.Errorf("%s: %w", msg, err)
@@ -51,5 +89,8 @@ func NewCommand() *cobra.Command {
 		},
 	}
 
+	cmd.Flags().BoolVar(&planOnly, "plan", false, "Show the changes a sync would make without making them")
+	cmd.Flags().BoolVar(&prune, "prune", true, "Delete binaries removed from config")
+
 	return cmd
 }
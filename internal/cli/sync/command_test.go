@@ -0,0 +1,140 @@
+package sync
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"cturner8/binmate/internal/core/config"
+	"cturner8/binmate/internal/database"
+	"cturner8/binmate/internal/database/repository"
+)
+
+func setupTestEnv(t *testing.T) *repository.Service {
+	db, err := database.Initialize(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to initialize database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	dbService := repository.NewService(db)
+	DBService = dbService
+	Sources = nil
+	Config = &config.Config{
+		Version: 1,
+		Binaries: []config.Binary{
+			{Id: "gh", Name: "gh", Provider: "github", Path: "cli/cli", Format: ".tar.gz"},
+			{Id: "jq", Name: "jq", Provider: "github", Path: "jqlang/jq", Format: ".tar.gz"},
+		},
+	}
+	if err := config.SyncToDatabase(*Config, dbService); err != nil {
+		t.Fatalf("Failed to sync config: %v", err)
+	}
+	return dbService
+}
+
+func run(t *testing.T, args ...string) string {
+	t.Helper()
+	cmd := NewCommand()
+	if len(args) > 0 && args[0] == "apply" {
+		cmd, args = NewApplyCommand(), args[1:]
+	}
+	cmd.SetArgs(args)
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("%v failed: %v\n%s", args, err, buf.String())
+	}
+	return buf.String()
+}
+
+func TestSyncCommand_Plan(t *testing.T) {
+	dbService := setupTestEnv(t)
+
+	jq, _ := dbService.Binaries.GetByUserID("jq")
+	installation := &database.Installation{BinaryID: jq.ID, Version: "jq-1.7.1", InstalledPath: "/opt/jq", SourceURL: "https://example.com/jq"}
+	if err := dbService.Installations.Create(installation); err != nil {
+		t.Fatalf("Failed to create installation: %v", err)
+	}
+
+	Config.Binaries = []config.Binary{
+		{Id: "gh", Name: "gh", Provider: "github", Path: "cli/cli", Format: ".zip", Pin: "v2.40.0"},
+		{Id: "fzf", Name: "fzf", Provider: "github", Path: "junegunn/fzf", Format: ".tar.gz"},
+	}
+
+	output := run(t, "--plan")
+	for _, want := range []string{
+		"+ fzf\n    name: \"fzf\"\n",
+		"~ gh\n    format: \".tar.gz\" -> \".zip\"\n    pin: (unset) -> \"v2.40.0\"\n",
+		"- jq\n    orphans jq-1.7.1 at /opt/jq\n",
+		"Plan: 1 to add, 1 to change, 1 to remove.",
+		"binmate apply",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected plan to contain %q, got:\n%s", want, output)
+		}
+	}
+	if binaries, _ := dbService.Binaries.List(); len(binaries) != 2 {
+		t.Errorf("Expected --plan to leave the database alone, got %d binaries", len(binaries))
+	}
+
+	output = run(t, "--plan", "--prune=false")
+	if !strings.Contains(output, "= jq (removed from config, kept)") || !strings.Contains(output, "0 to remove") {
+		t.Errorf("Expected jq to be kept, got:\n%s", output)
+	}
+}
+
+func TestApplyCommand(t *testing.T) {
+	dbService := setupTestEnv(t)
+	Config.Binaries = Config.Binaries[:1]
+
+	output := run(t, "apply", "--prune=false")
+	if !strings.Contains(output, "No changes") {
+		t.Errorf("Expected no changes with a kept binary, got:\n%s", output)
+	}
+	if _, err := dbService.Binaries.GetByUserID("jq"); err != nil {
+		t.Errorf("Expected jq to be kept: %v", err)
+	}
+
+	output = run(t, "apply")
+	if !strings.Contains(output, "- jq") || !strings.Contains(output, "✓ Apply complete") {
+		t.Errorf("Expected jq to be removed, got:\n%s", output)
+	}
+	if _, err := dbService.Binaries.GetByUserID("jq"); err != database.ErrNotFound {
+		t.Errorf("Expected jq to be removed, got err = %v", err)
+	}
+
+	output = run(t, "apply")
+	if !strings.Contains(output, "No changes") {
+		t.Errorf("Expected a second apply to make no changes, got:\n%s", output)
+	}
+}
+
+func TestApplyCommand_IncludeErrors(t *testing.T) {
+	dbService := setupTestEnv(t)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(path, []byte(`{"version": 1, "include": ["./tools.json"]}`), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	cfg, sources, err := config.ReadConfigFiles([]string{path}, "", "")
+	if err != nil {
+		t.Fatalf("ReadConfigFiles failed: %v", err)
+	}
+	Config, Sources = &cfg, &sources
+
+	cmd := NewApplyCommand()
+	cmd.SetArgs(nil)
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "unable to include ./tools.json") {
+		t.Errorf("Expected apply to fail on the missing include, got %v", err)
+	}
+	if binaries, _ := dbService.Binaries.List(); len(binaries) != 2 {
+		t.Errorf("Expected a failed apply to leave the database alone, got %d binaries", len(binaries))
+	}
+}
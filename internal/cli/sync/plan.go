@@ -0,0 +1,115 @@
+package sync
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"cturner8/binmate/internal/core/config"
+	installSvc "cturner8/binmate/internal/core/install"
+	"cturner8/binmate/internal/database/repository"
+)
+
+// planChanges reports whether applying a plan would change the database. Binaries removed
+// from config are left alone when they are kept.
+func planChanges(plan *config.SyncPlan, keepRemoved bool) bool {
+	return len(plan.Create) > 0 || len(plan.Update) > 0 || len(plan.Pins) > 0 || (len(plan.Remove) > 0 && !keepRemoved)
+}
+
+// printPlan prints the changes of a sync plan: "+" for binaries added, "~" for binaries
+// changed with their old and new values, and "-" for binaries removed with the
+// installations they leave behind
+func printPlan(w io.Writer, plan *config.SyncPlan, keepRemoved bool) {
+	if plan.Empty() {
+		fmt.Fprintln(w, "No changes. The database matches the config.")
+		return
+	}
+
+	for _, cb := range plan.Create {
+		fmt.Fprintf(w, "+ %s\n", cb.ID)
+		for _, field := range cb.Fields() {
+			fmt.Fprintf(w, "    %s: %s\n", field.Field, field.New)
+		}
+		if pin, ok := plan.Pins[cb.ID]; ok {
+			fmt.Fprintf(w, "    pin: %s\n", strconv.Quote(pin.New))
+		}
+	}
+
+	updated := map[string]bool{}
+	for _, update := range plan.Update {
+		updated[update.Config.ID] = true
+		fmt.Fprintf(w, "~ %s\n", update.Config.ID)
+		printChanges(w, update.Changes, plan.Pins[update.Config.ID], len(update.Changes) == 0)
+	}
+
+	// Binaries whose only change is their pin
+	var pinned []string
+	for id := range plan.Pins {
+		if !updated[id] && !isCreated(plan, id) {
+			pinned = append(pinned, id)
+		}
+	}
+	sort.Strings(pinned)
+	for _, id := range pinned {
+		fmt.Fprintf(w, "~ %s\n", id)
+		printChanges(w, nil, plan.Pins[id], false)
+	}
+
+	for _, binary := range plan.Remove {
+		if keepRemoved {
+			fmt.Fprintf(w, "= %s (removed from config, kept)\n", binary.UserID)
+			continue
+		}
+		fmt.Fprintf(w, "- %s\n", binary.UserID)
+		for _, installation := range plan.Orphaned[binary.UserID] {
+			fmt.Fprintf(w, "    orphans %s at %s\n", installSvc.DisplayVersion(binary, installation.Version), installation.InstalledPath)
+		}
+	}
+
+	if keepRemoved {
+		if !planChanges(plan, keepRemoved) {
+			fmt.Fprintln(w, "\nNo changes. Binaries removed from config are kept.")
+			return
+		}
+		fmt.Fprintf(w, "\nPlan: %d to add, %d to change, 0 to remove.\n", len(plan.Create), len(plan.Update)+len(pinned))
+		return
+	}
+	fmt.Fprintf(w, "\nPlan: %d to add, %d to change, %d to remove.\n", len(plan.Create), len(plan.Update)+len(pinned), len(plan.Remove))
+}
+
+// printChanges prints the fields and pin changed on a binary
+func printChanges(w io.Writer, changes []repository.FieldChange, pin config.PinChange, digestOnly bool) {
+	for _, change := range changes {
+		fmt.Fprintf(w, "    %s: %s -> %s\n", change.Field, unsetValue(change.Old), unsetValue(change.New))
+	}
+	if pin != (config.PinChange{}) {
+		fmt.Fprintf(w, "    pin: %s -> %s\n", unsetValue(quoteVersion(pin.Old)), unsetValue(quoteVersion(pin.New)))
+	}
+	if digestOnly && pin == (config.PinChange{}) {
+		fmt.Fprintln(w, "    (config updated, no setting changes)")
+	}
+}
+
+func isCreated(plan *config.SyncPlan, id string) bool {
+	for _, cb := range plan.Create {
+		if cb.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func quoteVersion(version string) string {
+	if version == "" {
+		return ""
+	}
+	return strconv.Quote(version)
+}
+
+func unsetValue(value string) string {
+	if value == "" {
+		return "(unset)"
+	}
+	return value
+}
@@ -0,0 +1,56 @@
+package uninstall
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"cturner8/binmate/internal/core/config"
+	installSvc "cturner8/binmate/internal/core/install"
+	versionSvc "cturner8/binmate/internal/core/version"
+	"cturner8/binmate/internal/database/repository"
+)
+
+// Package variables will be set by cmd package
+var (
+	Config    *config.Config
+	DBService *repository.Service
+)
+
+func NewCommand() *cobra.Command {
+	var (
+		binaryID string
+		version  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "uninstall",
+		Short: "Uninstall a single version of a binary",
+		Long: `Remove an installed version of a binary, deleting its files and installation record.
+
+The active version and the pinned version cannot be uninstalled. Switch to another
+version or unpin the binary first. Binaries imported in place keep their files.
+
+Example:
+  binmate uninstall --binary gh --version v2.39.0`,
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			installation, err := versionSvc.UninstallVersion(binaryID, version, DBService)
+			if err != nil {
+				return fmt.Errorf("failed to uninstall version: %w", err)
+			}
+
+			binary, _ := DBService.Binaries.GetByUserID(binaryID)
+			fmt.Fprintf(cmd.OutOrStdout(), "✓ Uninstalled %s version %s\n", binaryID, installSvc.DisplayVersion(binary, installation.Version))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&binaryID, "binary", "b", "", "Binary ID (required)")
+	cmd.Flags().StringVarP(&version, "version", "v", "", "Version to uninstall (required)")
+	cmd.MarkFlagRequired("binary")
+	cmd.MarkFlagRequired("version")
+
+	return cmd
+}
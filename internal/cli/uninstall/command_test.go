@@ -0,0 +1,142 @@
+package uninstall
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"cturner8/binmate/internal/core/config"
+	versionSvc "cturner8/binmate/internal/core/version"
+	"cturner8/binmate/internal/database"
+	"cturner8/binmate/internal/database/repository"
+)
+
+func setupTestEnv(t *testing.T) (*repository.Service, *config.Config, func()) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "test.db")
+
+	db, err := database.Initialize(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to initialize database: %v", err)
+	}
+
+	dbService := repository.NewService(db)
+
+	cfg := &config.Config{
+		Version:  1,
+		Binaries: []config.Binary{},
+	}
+
+	cleanup := func() {
+		db.Close()
+	}
+
+	return dbService, cfg, cleanup
+}
+
+func TestUninstallCommand_NonExistent(t *testing.T) {
+	dbService, cfg, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	Config = cfg
+	DBService = dbService
+
+	cmd := NewCommand()
+	cmd.SetArgs([]string{"--binary", "nonexistent"})
+
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+
+	if err := cmd.Execute(); err == nil {
+		t.Error("Expected error for non-existent binary, got none")
+	}
+}
+
+func TestUninstallCommand_MissingVersionFlag(t *testing.T) {
+	dbService, cfg, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	Config = cfg
+	DBService = dbService
+
+	cmd := NewCommand()
+	cmd.SetArgs([]string{"--binary", "testbin"})
+
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+
+	if err := cmd.Execute(); err == nil {
+		t.Error("Expected error when version flag missing, got none")
+	}
+}
+
+func TestUninstallCommand_InactiveVersion(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	dbService, cfg, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	Config = cfg
+	DBService = dbService
+
+	binary := &database.Binary{
+		UserID:       "testbin",
+		Name:         "testbin",
+		Provider:     "github",
+		ProviderPath: "owner/repo",
+		Format:       ".tar.gz",
+	}
+	if err := dbService.Binaries.Create(binary); err != nil {
+		t.Fatalf("Failed to create test binary: %v", err)
+	}
+
+	for _, version := range []string{"v1.0.0", "v1.1.0"} {
+		binaryPath := filepath.Join(t.TempDir(), "testbin")
+		if err := os.WriteFile(binaryPath, []byte(version), 0755); err != nil {
+			t.Fatalf("Failed to create test binary file: %v", err)
+		}
+		installation := &database.Installation{
+			BinaryID:      binary.ID,
+			Version:       version,
+			InstalledPath: binaryPath,
+			SourceURL:     "https://example.com/" + version,
+		}
+		if err := dbService.Installations.Create(installation); err != nil {
+			t.Fatalf("Failed to create installation: %v", err)
+		}
+		if err := versionSvc.ActivateInstallation(binary, installation, dbService); err != nil {
+			t.Fatalf("Failed to activate installation: %v", err)
+		}
+	}
+
+	// The active version cannot be uninstalled
+	cmd := NewCommand()
+	cmd.SetArgs([]string{"-b", "testbin", "-v", "v1.1.0"})
+
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+
+	if err := cmd.Execute(); err == nil {
+		t.Error("Expected error uninstalling the active version, got none")
+	}
+
+	cmd = NewCommand()
+	cmd.SetArgs([]string{"-b", "testbin", "-v", "v1.0.0"})
+	buf.Reset()
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Uninstalled testbin version v1.0.0") {
+		t.Errorf("Expected uninstall message, got: %s", buf.String())
+	}
+	if _, err := dbService.Installations.Get(binary.ID, "v1.0.0"); err != database.ErrNotFound {
+		t.Errorf("Expected installation record to be deleted, got: %v", err)
+	}
+}
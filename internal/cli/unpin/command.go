@@ -0,0 +1,49 @@
+package unpin
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"cturner8/binmate/internal/core/config"
+	versionSvc "cturner8/binmate/internal/core/version"
+	"cturner8/binmate/internal/database/repository"
+)
+
+// Package variables will be set by cmd package
+var (
+	Config    *config.Config
+	DBService *repository.Service
+)
+
+func NewCommand() *cobra.Command {
+	var binaryID string
+
+	cmd := &cobra.Command{
+		Use:   "unpin",
+		Short: "Unpin a binary so it can be updated again",
+		Long: `Remove the pin from a binary so that updates include it again.
+
+Example:
+  binmate unpin --binary gh      # Allow gh to be updated`,
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pin, err := versionSvc.UnpinVersion(binaryID, DBService)
+			if err != nil {
+				return fmt.Errorf("failed to unpin binary: %w", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "✓ Unpinned %s (was %s)\n", binaryID, pin.Version)
+			if pin.Source == "config" {
+				fmt.Fprintf(cmd.OutOrStdout(), "⚠ %s is pinned in the config file and will be pinned again on the next sync, remove its \"pin\" field to unpin permanently\n", binaryID)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&binaryID, "binary", "b", "", "Binary ID (required)")
+	cmd.MarkFlagRequired("binary")
+
+	return cmd
+}
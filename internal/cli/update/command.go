@@ -2,12 +2,15 @@ package update
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	binarySvc "cturner8/binmate/internal/core/binary"
 	"cturner8/binmate/internal/core/config"
 	installSvc "cturner8/binmate/internal/core/install"
+	"cturner8/binmate/internal/core/parallel"
+	"cturner8/binmate/internal/core/progress"
 	"cturner8/binmate/internal/database/repository"
 )
 
@@ -19,8 +22,9 @@ var (
 
 func NewCommand() *cobra.Command {
 	var (
-		binaryID  string
-		updateAll bool
+		binaryID    string
+		updateAll   bool
+		concurrency int
 	)
 
 	cmd := &cobra.Command{
@@ -30,9 +34,12 @@ func NewCommand() *cobra.Command {
 
 This will install the latest version and set it as the active version.
 
+Pinned binaries are skipped by --all and cannot be updated until unpinned.
+
 Examples:
   binmate update --binary gh              # Update gh to latest version
-  binmate update --all                    # Update all binaries to latest versions`,
+  binmate update --all                    # Update all binaries to latest versions
+  binmate update --all --concurrency 8    # Update up to 8 binaries in parallel`,
 		SilenceUsage:  true,
 		SilenceErrors: false,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -48,34 +55,67 @@ Examples:
 					return nil
 				}
 
-				updatedCount := 0
-				for _, b := range binaries {
-					result, err := installSvc.UpdateToLatest(b.Binary.UserID, DBService)
-					if err != nil {
-						fmt.Fprintf(cmd.OutOrStdout(), "⚠ Failed to update %s: %v\n", b.Binary.Name, err)
-						continue
+				type updateResult struct {
+					result *installSvc.InstallBinaryResult
+					err    error
+				}
+
+				workers := config.ResolveConcurrency(concurrency, Config.Global)
+				results := parallel.Map(binaries, workers, func(b *repository.BinaryWithVersionDetails) updateResult {
+					if b.PinnedVersion != "" {
+						return updateResult{}
+					}
+					result, err := installSvc.UpdateToLatest(cmd.Context(), b.Binary.UserID, DBService)
+					return updateResult{result: result, err: err}
+				})
+
+				updatedCount, upToDateCount, pinnedCount := 0, 0, 0
+				var failed []string
+				for i, b := range binaries {
+					r := results[i]
+					switch {
+					case b.PinnedVersion != "":
+						fmt.Fprintf(cmd.OutOrStdout(), "ℹ Skipping %s: pinned at %s\n", b.Binary.Name, installSvc.DisplayVersion(b.Binary, b.PinnedVersion))
+						pinnedCount++
+					case r.err != nil:
+						fmt.Fprintf(cmd.OutOrStdout(), "⚠ Failed to update %s: %v\n", b.Binary.Name, r.err)
+						failed = append(failed, b.Binary.Name)
+					case r.result.AlreadyInstalled && r.result.Version == b.ActiveVersion:
+						fmt.Fprintf(cmd.OutOrStdout(), "✓ %s is up to date (version %s)\n", b.Binary.Name, installSvc.DisplayVersion(r.result.Binary, r.result.Version))
+						upToDateCount++
+					default:
+						fmt.Fprintf(cmd.OutOrStdout(), "✓ Updated %s to version %s\n", b.Binary.Name, installSvc.DisplayVersion(r.result.Binary, r.result.Version))
+						updatedCount++
 					}
-					fmt.Fprintf(cmd.OutOrStdout(), "✓ Updated %s to version %s\n", b.Binary.Name, result.Version)
-					updatedCount++
 				}
 
-				fmt.Fprintf(cmd.OutOrStdout(), "\n✓ Updated %d/%d binaries\n", updatedCount, len(binaries))
+				fmt.Fprintf(cmd.OutOrStdout(), "\n✓ Updated %d/%d binaries (%d up to date, %d pinned, %d failed)\n", updatedCount, len(binaries), upToDateCount, pinnedCount, len(failed))
+				if len(failed) > 0 {
+					fmt.Fprintf(cmd.OutOrStdout(), "⚠ Failed: %s\n", strings.Join(failed, ", "))
+				}
 				return nil
 			}
 
 			// Update single binary
-			result, err := installSvc.UpdateToLatest(binaryID, DBService)
+			opts := installSvc.InstallOptions{
+				Progress: progress.NewBar(cmd.OutOrStdout(), binaryID),
+			}
+			result, err := installSvc.UpdateToLatestWithOptions(cmd.Context(), binaryID, DBService, opts)
 			if err != nil {
 				return fmt.Errorf("failed to update binary: %w", err)
 			}
 
-			fmt.Fprintf(cmd.OutOrStdout(), "✓ Updated %s to version %s\n", binaryID, result.Version)
+			fmt.Fprintf(cmd.OutOrStdout(), "✓ Updated %s to version %s\n", binaryID, installSvc.DisplayVersion(result.Binary, result.Version))
+			for _, installation := range result.Pruned {
+				fmt.Fprintf(cmd.OutOrStdout(), "✓ Removed old version %s\n", installSvc.DisplayVersion(result.Binary, installation.Version))
+			}
 			return nil
 		},
 	}
 
 	cmd.Flags().StringVarP(&binaryID, "binary", "b", "", "Binary ID to update")
 	cmd.Flags().BoolVarP(&updateAll, "all", "a", false, "Update all binaries to their latest versions")
+	cmd.Flags().IntVarP(&concurrency, "concurrency", "j", 0, "Number of binaries to update in parallel with --all (defaults to global.concurrency or 4)")
 
 	// Make binary required unless --all is specified
 	cmd.MarkFlagsOneRequired("binary", "all")
@@ -115,3 +115,94 @@ func TestUpdateCommand_Help(t *testing.T) {
 		t.Error("Help output missing binary flag")
 	}
 }
+
+func TestUpdateCommand_SkipsPinnedBinaries(t *testing.T) {
+	dbService, cfg, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	Config = cfg
+	DBService = dbService
+
+	binary := &database.Binary{
+		UserID:       "testbin",
+		Name:         "testbin",
+		Provider:     "github",
+		ProviderPath: "owner/repo",
+		Format:       ".tar.gz",
+	}
+	if err := dbService.Binaries.Create(binary); err != nil {
+		t.Fatalf("Failed to create test binary: %v", err)
+	}
+	if err := dbService.Pins.Set(binary.ID, "v1.0.0", "manual"); err != nil {
+		t.Fatalf("Failed to pin test binary: %v", err)
+	}
+
+	// Pinned binaries are skipped without contacting the provider
+	cmd := NewCommand()
+	cmd.SetArgs([]string{"--all"})
+
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "Skipping testbin: pinned at v1.0.0") {
+		t.Errorf("Expected pinned skip message, got: %s", output)
+	}
+	if !strings.Contains(output, "1 pinned, 0 failed") {
+		t.Errorf("Expected pinned count in summary, got: %s", output)
+	}
+
+	// Updating a pinned binary directly is refused
+	cmd = NewCommand()
+	cmd.SetArgs([]string{"--binary", "testbin"})
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "pinned at v1.0.0") {
+		t.Errorf("Expected pinned error, got: %v", err)
+	}
+}
+
+func TestUpdateCommand_PinnedDisplayVersion(t *testing.T) {
+	dbService, cfg, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	Config = cfg
+	DBService = dbService
+
+	releaseRegex := "^bun-v"
+	binary := &database.Binary{
+		UserID:       "bun",
+		Name:         "bun",
+		Provider:     "github",
+		ProviderPath: "oven-sh/bun",
+		Format:       ".zip",
+		ReleaseRegex: &releaseRegex,
+	}
+	if err := dbService.Binaries.Create(binary); err != nil {
+		t.Fatalf("Failed to create test binary: %v", err)
+	}
+	if err := dbService.Pins.Set(binary.ID, "bun-v1.1.0", "manual"); err != nil {
+		t.Fatalf("Failed to pin test binary: %v", err)
+	}
+
+	// Versions are shown without the tag prefix matched by releaseRegex
+	cmd := NewCommand()
+	cmd.SetArgs([]string{"--all"})
+
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if output := buf.String(); !strings.Contains(output, "Skipping bun: pinned at 1.1.0\n") {
+		t.Errorf("Expected pinned version without its prefix, got: %s", output)
+	}
+}
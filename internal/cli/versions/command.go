@@ -2,11 +2,13 @@ package versions
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/spf13/cobra"
 
 	"cturner8/binmate/internal/core/config"
 	"cturner8/binmate/internal/core/format"
+	installSvc "cturner8/binmate/internal/core/install"
 	versionSvc "cturner8/binmate/internal/core/version"
 	"cturner8/binmate/internal/database/repository"
 )
@@ -25,7 +27,7 @@ func NewCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "versions",
 		Short: "List installed versions of a binary",
-		Long: `List all installed versions of a specific binary.
+		Long: `List all installed versions of a specific binary, newest version first.
 
 Example:
   binmate versions --binary gh              # List versions of gh binary`,
@@ -38,6 +40,14 @@ Example:
 				return fmt.Errorf("failed to list versions: %w", err)
 			}
 
+			// Sort newest version first
+			binary, err := DBService.Binaries.GetByUserID(binaryID)
+			if err == nil {
+				sort.SliceStable(versions, func(i, j int) bool {
+					return installSvc.CompareVersions(binary, versions[i].Version, versions[j].Version) > 0
+				})
+			}
+
 			// Get active version
 			activeVersion, _ := versionSvc.GetActiveVersion(binaryID, DBService)
 
@@ -56,7 +66,7 @@ Example:
 					activeMarker = "*"
 				}
 				formattedDate := format.FormatTimestamp(v.InstalledAt, dateFormat)
-				fmt.Fprintf(cmd.OutOrStdout(), "%s %s (installed: %s)\n", activeMarker, v.Version, formattedDate)
+				fmt.Fprintf(cmd.OutOrStdout(), "%s %s (installed: %s)\n", activeMarker, installSvc.DisplayVersion(binary, v.Version), formattedDate)
 			}
 			return nil
 		},
@@ -316,12 +316,7 @@ func ImportBinaryWithOptions(path string, name string, url string, version strin
 	if keepLocation {
 		log.Printf("Skipping symlink creation for keep-location import: %s", installedPath)
 	} else {
-		customInstallPath := ""
-		if binary.InstallPath != nil {
-			customInstallPath = *binary.InstallPath
-		}
-
-		symlinkPath, err = v.SetActiveVersion(installedPath, customInstallPath, binary.Name, binary.Alias)
+		symlinkPath, err = v.LinkVersion(binary, installedPath)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create symlink: %w", err)
 		}
@@ -0,0 +1,16 @@
+package config
+
+// DefaultConcurrency is the number of binaries processed in parallel when not configured
+const DefaultConcurrency = 4
+
+// ResolveConcurrency returns the worker count for batch operations.
+// A positive override (e.g. from a --concurrency flag) takes precedence over global config.
+func ResolveConcurrency(override int, global GlobalConfig) int {
+	if override > 0 {
+		return override
+	}
+	if global.Concurrency > 0 {
+		return global.Concurrency
+	}
+	return DefaultConcurrency
+}
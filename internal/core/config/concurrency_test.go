@@ -0,0 +1,25 @@
+package config
+
+import "testing"
+
+func TestResolveConcurrency(t *testing.T) {
+	tests := []struct {
+		name     string
+		override int
+		global   GlobalConfig
+		want     int
+	}{
+		{name: "default", want: DefaultConcurrency},
+		{name: "global config", global: GlobalConfig{Concurrency: 8}, want: 8},
+		{name: "override takes precedence", override: 2, global: GlobalConfig{Concurrency: 8}, want: 2},
+		{name: "non-positive values ignored", override: -1, global: GlobalConfig{Concurrency: 0}, want: DefaultConcurrency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResolveConcurrency(tt.override, tt.global); got != tt.want {
+				t.Errorf("ResolveConcurrency() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -12,12 +12,58 @@ type Binary struct {
 	AssetRegex    string `mapstructure:"assetRegex"`
 	ReleaseRegex  string `mapstructure:"releaseRegex"`
 	Authenticated bool   `mapstructure:"authenticated"`
+	Version       string `mapstructure:"version"`      // Semver constraint for the latest release, e.g. "~1.4" or ">=2.40 <3"
+	Pin           string `mapstructure:"pin"`          // Version to hold the binary at, skipped by batch updates
+	Channel       string `mapstructure:"channel"`      // Release channel resolving latest: "stable" (default), "prerelease" or "nightly"
+	NightlyRegex  string `mapstructure:"nightlyRegex"` // Tag pattern for nightly releases, defaults to "nightly"
+	KeepVersions  int    `mapstructure:"keepVersions"` // Installed versions retained after each update, 0 keeps all
+	Shim          bool   `mapstructure:"shim"`         // Install a shim resolving the version from .binmate-version or .tool-versions
+	BinPath       string `mapstructure:"binPath"`      // Path of the binary inside the release archive, defaults to the file named after the binary
+
+	Platforms map[string]PlatformOverride `mapstructure:"platforms"` // Overrides keyed by "os/arch", e.g. "darwin/arm64"
+
+	projectFile string // Project config file declaring the binary, empty if the user's config or an include declares it
+}
+
+// PlatformOverride represents binary settings replaced when binmate runs on a platform
+type PlatformOverride struct {
+	Name       string `mapstructure:"name"`
+	Format     string `mapstructure:"format"`
+	AssetRegex string `mapstructure:"assetRegex"`
+	BinPath    string `mapstructure:"binPath"`
 }
 
 // GlobalConfig represents global defaults that apply to all binaries
 type GlobalConfig struct {
 	InstallPath string                      `mapstructure:"installPath"` // Default install path for all binaries
 	Providers   map[string]ProviderDefaults `mapstructure:"providers"`   // Provider-specific defaults (e.g., github.authenticated)
+	Mirrors     []Mirror                    `mapstructure:"mirrors"`     // Mirror base URLs tried in order before the origin
+	HTTP        HTTPConfig                  `mapstructure:"http"`        // Network settings applied to every provider client
+	Concurrency int                         `mapstructure:"concurrency"` // Number of binaries processed in parallel by batch operations
+	Shims       ShimConfig                  `mapstructure:"shims"`       // Per-project version shims
+}
+
+// ShimConfig represents settings for shims that resolve a binary's version per project
+type ShimConfig struct {
+	Enabled     bool `mapstructure:"enabled"`     // Install shims instead of symlinks for every binary
+	AutoInstall bool `mapstructure:"autoInstall"` // Install versions requested by a version file on first use
+}
+
+// HTTPConfig represents network settings for provider HTTP clients
+type HTTPConfig struct {
+	Proxy                 string   `mapstructure:"proxy"`                 // Proxy URL, overrides HTTP(S)_PROXY environment variables
+	NoProxy               []string `mapstructure:"noProxy"`               // Hosts, domains or CIDR ranges that bypass the proxy
+	CACertFiles           []string `mapstructure:"caCertFiles"`           // Extra PEM CA certificate files to trust
+	Timeout               string   `mapstructure:"timeout"`               // Overall timeout of API requests, not downloads, e.g. "1m"
+	ConnectTimeout        string   `mapstructure:"connectTimeout"`        // TCP connection timeout, e.g. "10s"
+	ResponseHeaderTimeout string   `mapstructure:"responseHeaderTimeout"` // Timeout waiting for response headers, e.g. "30s"
+}
+
+// Mirror represents a base URL rewrite for provider requests, e.g. an Artifactory remote repository
+type Mirror struct {
+	Origin      string `mapstructure:"origin"`      // URL prefix to rewrite, defaults to https://api.github.com
+	URL         string `mapstructure:"url"`         // Mirror base URL replacing the origin
+	ForwardAuth bool   `mapstructure:"forwardAuth"` // Whether to send provider credentials to the mirror
 }
 
 // ProviderDefaults represents provider-level configuration defaults
@@ -27,8 +73,11 @@ type ProviderDefaults struct {
 
 type Config struct {
 	Version    int          `mapstructure:"version"`
-	Global     GlobalConfig `mapstructure:"global"` // Global configuration defaults
+	Include    []string     `mapstructure:"include"` // Shared configs layered beneath this one: https:// URLs or paths, optionally suffixed "#sha256:<checksum>"
+	Global     GlobalConfig `mapstructure:"global"`  // Global configuration defaults
 	Binaries   []Binary     `mapstructure:"binaries"`
 	DateFormat string       `mapstructure:"dateFormat"` // Date format for display, e.g., "02/01/2006 15:04"
 	LogLevel   string       `mapstructure:"logLevel"`
+
+	projectFile string // Project config file read, empty if none was
 }
@@ -0,0 +1,411 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Document is a config file edited in place. Only the values changed are rewritten, so the
+// formatting, comments, key order and unknown fields of the rest of the file are preserved.
+type Document struct {
+	Path   string
+	format string
+	editor formatEditor
+	data   []byte
+	root   *node
+}
+
+// formatEditor writes the changes made to a Document in the syntax of a config format.
+// Each change rewrites part of the document's text with Document.replace.
+type formatEditor interface {
+	// emptyConfig returns the content of config files created by an edit
+	emptyConfig() string
+	// addBinary appends a binary to the binaries list, which is nil if the document has none
+	addBinary(d *Document, binaries *node, entries []binaryEntry) error
+	// removeItem removes an item of the binaries list
+	removeItem(d *Document, binaries *node, i int) error
+	// setValue replaces an existing value
+	setValue(d *Document, value *node, v any) error
+	// addField adds a field after the last field of an object
+	addField(d *Document, object *node, key string, v any) error
+	// removeField removes a field of an object
+	removeField(d *Document, object *node, i int) error
+}
+
+// binaryEntry is a set field of a binary to write to a config file
+type binaryEntry struct {
+	key   string
+	value any
+}
+
+// newFormatEditor returns the editor of a config format
+func newFormatEditor(format string) (formatEditor, error) {
+	switch format {
+	case FormatJSON:
+		return jsonEditor{}, nil
+	case FormatYAML:
+		return yamlEditor{}, nil
+	case FormatTOML:
+		return tomlEditor{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported config format %q (supported formats: %s)", format, strings.Join(Formats, ", "))
+	}
+}
+
+// NewDocument returns an empty config to be saved to path. The format is taken from the
+// path's extension if empty, defaulting to JSON for a path without a known extension.
+func NewDocument(path, format string) (*Document, error) {
+	if format == "" {
+		format = FormatOf(path)
+	}
+	if format == "" {
+		format = FormatJSON
+	}
+	editor, err := newFormatEditor(format)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Document{Path: path, format: format, editor: editor}
+	if err := d.setData([]byte(editor.emptyConfig())); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// LoadDocument reads a config file for editing. A missing file starts from an empty config
+// and is created on save.
+func LoadDocument(path string) (*Document, error) {
+	format := FormatOf(path)
+	if format == "" {
+		return nil, fmt.Errorf("unable to edit %s: %s config files are not supported", path, filepath.Ext(path))
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return NewDocument(path, format)
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to read %s: %w", path, err)
+	}
+
+	editor, err := newFormatEditor(format)
+	if err != nil {
+		return nil, err
+	}
+	d := &Document{Path: path, format: format, editor: editor}
+	if err := d.setData(data); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (d *Document) setData(data []byte) error {
+	root, err := parseNodes(d.format, data)
+	if err != nil {
+		return fmt.Errorf("unable to parse %s: %w", d.Path, err)
+	}
+	if root.kind != "object" {
+		return fmt.Errorf("unable to parse %s: expected a %s object", d.Path, strings.ToUpper(d.format))
+	}
+	d.data, d.root = data, root
+	return nil
+}
+
+// Format returns the config format of the document, e.g. "yaml"
+func (d *Document) Format() string {
+	return d.format
+}
+
+// Bytes returns the current contents of the document
+func (d *Document) Bytes() []byte {
+	return d.data
+}
+
+// HasBinary reports whether the document defines a binary
+func (d *Document) HasBinary(id string) bool {
+	return parsedFile{root: d.root}.binary(id) != nil
+}
+
+// AddBinary appends a binary to the binaries list, formatted like the existing entries
+func (d *Document) AddBinary(binary Binary) error {
+	if d.HasBinary(binary.Id) {
+		return fmt.Errorf("binary %q already exists in %s", binary.Id, d.Path)
+	}
+
+	binaries := d.root.field("binaries")
+	if binaries != nil && binaries.kind != "array" {
+		return fmt.Errorf("unable to add binary: binaries in %s is not a list", d.Path)
+	}
+	if err := d.editor.addBinary(d, binaries, binaryEntries(binary)); err != nil {
+		return fmt.Errorf("unable to add binary to %s: %w", d.Path, err)
+	}
+	return nil
+}
+
+// RemoveBinary removes a binary from the binaries list
+func (d *Document) RemoveBinary(id string) error {
+	binaries := d.root.field("binaries")
+	for i, item := range binaries.list() {
+		if item.field("id").str() == id {
+			if err := d.editor.removeItem(d, binaries, i); err != nil {
+				return fmt.Errorf("unable to remove binary from %s: %w", d.Path, err)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("binary %q not found in %s", id, d.Path)
+}
+
+// SetBinaryField sets a field of a binary from its string form, e.g. "true" for
+// authenticated or "3" for keepVersions. An empty value removes the field.
+func (d *Document) SetBinaryField(id, key, raw string) error {
+	binary := parsedFile{root: d.root}.binary(id)
+	if binary == nil {
+		return fmt.Errorf("binary %q not found in %s", id, d.Path)
+	}
+
+	field, ok := binaryField(key)
+	if !ok {
+		return fmt.Errorf("unknown binary field %q (valid fields: %s)", key, strings.Join(BinaryFields(), ", "))
+	}
+	if key == "id" {
+		return fmt.Errorf("unable to change the id of %q, remove and add the binary instead", id)
+	}
+	if field.Type.Kind() == reflect.Map {
+		return fmt.Errorf("unable to set %s from the command line, edit %s instead", key, d.Path)
+	}
+
+	var err error
+	existing := binary.field(key)
+	if raw == "" {
+		if existing == nil {
+			return nil
+		}
+		for i, entry := range binary.entries() {
+			if entry == existing {
+				err = d.editor.removeField(d, binary, i)
+			}
+		}
+	} else {
+		value, parseErr := parseFieldValue(field, raw)
+		if parseErr != nil {
+			return fmt.Errorf("invalid value for %s: %w", key, parseErr)
+		}
+		if existing != nil {
+			err = d.editor.setValue(d, existing, value)
+		} else {
+			err = d.editor.addField(d, binary, key, value)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("unable to set %s.%s in %s: %w", id, key, d.Path, err)
+	}
+	return nil
+}
+
+// Save writes the document to its file, creating the file's directory if needed. The file is
+// replaced in one step, keeping its mode.
+func (d *Document) Save() error {
+	tmp, err := d.writeTemp()
+	if err != nil {
+		return err
+	}
+	return d.replaceWith(tmp)
+}
+
+// SaveValidated validates the document layered with the other config files, given in
+// increasing order of precedence and including the document's path, with projectFile as in
+// ValidateFiles, and saves it if valid. Problems already present before the edit, such as
+// unknown properties, do not stop it. Otherwise the file is left unchanged and the
+// ValidationErrors are returned.
+func (d *Document) SaveValidated(files []string, projectFile string) error {
+	previous := ValidateFiles(files, projectFile)
+
+	tmp, err := d.writeTemp()
+	if err != nil {
+		return err
+	}
+
+	// The edited document is validated in place of its file, under the same directory and
+	// extension so includes and the format resolve as they would once saved
+	edited := make([]string, len(files))
+	for i, file := range files {
+		edited[i] = file
+		if file == d.Path {
+			edited[i] = tmp
+		}
+	}
+	if projectFile == d.Path {
+		projectFile = tmp
+	}
+
+	var errs ValidationErrors
+	for _, err := range ValidateFiles(edited, projectFile) {
+		if err.File == tmp {
+			err.File = d.Path
+		}
+		err.Message = strings.ReplaceAll(err.Message, tmp, d.Path)
+		if !slices.ContainsFunc(previous, func(p ValidationError) bool {
+			return p.File == err.File && p.Path == err.Path && p.Message == err.Message
+		}) {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		os.Remove(tmp)
+		return errs
+	}
+	return d.replaceWith(tmp)
+}
+
+// writeTemp writes the document to a new file beside its own, with the same extension and the
+// mode of the existing file
+func (d *Document) writeTemp() (string, error) {
+	dir := filepath.Dir(d.target())
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("unable to create config directory: %w", err)
+	}
+
+	var mode os.FileMode = 0o644
+	if info, err := os.Stat(d.Path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	ext := filepath.Ext(d.Path)
+	file, err := os.CreateTemp(dir, "."+strings.TrimSuffix(filepath.Base(d.Path), ext)+".*"+ext)
+	if err != nil {
+		return "", fmt.Errorf("unable to write %s: %w", d.Path, err)
+	}
+	_, err = file.Write(d.data)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(file.Name(), mode)
+	}
+	if err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("unable to write %s: %w", d.Path, err)
+	}
+	return file.Name(), nil
+}
+
+// target returns the file the document's path refers to, so a symlinked config file is
+// written through rather than replaced
+func (d *Document) target() string {
+	if path, err := filepath.EvalSymlinks(d.Path); err == nil {
+		return path
+	}
+	return d.Path
+}
+
+// replaceWith renames a file written by writeTemp over the document's file
+func (d *Document) replaceWith(tmp string) error {
+	if err := os.Rename(tmp, d.target()); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("unable to write %s: %w", d.Path, err)
+	}
+	return nil
+}
+
+// BinaryFields returns the config keys of binary fields
+func BinaryFields() []string {
+	return fieldKeys(reflect.TypeOf(Binary{}))
+}
+
+// binaryField returns the Binary struct field for a config key
+func binaryField(key string) (reflect.StructField, bool) {
+	typ := reflect.TypeOf(Binary{})
+	for i := 0; i < typ.NumField(); i++ {
+		if typ.Field(i).IsExported() && key != "" && typ.Field(i).Tag.Get("mapstructure") == key {
+			return typ.Field(i), true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// parseFieldValue converts the string form of a value to the type of a field
+func parseFieldValue(field reflect.StructField, raw string) (any, error) {
+	switch field.Type.Kind() {
+	case reflect.Bool:
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("expected true or false, got %q", raw)
+		}
+		return value, nil
+	case reflect.Int:
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("expected an integer, got %q", raw)
+		}
+		return value, nil
+	default:
+		return raw, nil
+	}
+}
+
+// binaryEntries returns the set fields of a binary in struct order
+func binaryEntries(binary Binary) []binaryEntry {
+	value := reflect.ValueOf(binary)
+	entries := []binaryEntry{}
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		key := field.Tag.Get("mapstructure")
+		if !field.IsExported() || key == "" || key == "-" || value.Field(i).IsZero() {
+			continue
+		}
+		entries = append(entries, binaryEntry{key: key, value: value.Field(i).Interface()})
+	}
+	return entries
+}
+
+// entries returns the values of an object in file order
+func (n *node) entries() []*node {
+	entries := make([]*node, len(n.keys))
+	for i, key := range n.keys {
+		entries[i] = n.fields[key]
+	}
+	return entries
+}
+
+// replace replaces the bytes between two offsets and re-parses the document
+func (d *Document) replace(start, end int, text string) error {
+	data := make([]byte, 0, len(d.data)-(end-start)+len(text))
+	data = append(data, d.data[:start]...)
+	data = append(data, text...)
+	data = append(data, d.data[end:]...)
+	return d.setData(data)
+}
+
+// multiline reports whether the bytes between two offsets span more than one line
+func (d *Document) multiline(start, end int) bool {
+	return bytes.IndexByte(d.data[start:end], '\n') >= 0
+}
+
+// indentAt returns the leading whitespace of the line containing an offset
+func (d *Document) indentAt(offset int) string {
+	start := lineStart(d.data, offset)
+	end := start
+	for end < len(d.data) && (d.data[end] == ' ' || d.data[end] == '\t') {
+		end++
+	}
+	return string(d.data[start:end])
+}
+
+// indentUnit returns the indentation of the first indented line, defaulting to two spaces
+func (d *Document) indentUnit() string {
+	for _, line := range bytes.Split(d.data, []byte("\n")) {
+		trimmed := bytes.TrimLeft(line, " \t")
+		if len(trimmed) > 0 && len(trimmed) < len(line) && trimmed[0] != '#' {
+			return string(line[:len(line)-len(trimmed)])
+		}
+	}
+	return "  "
+}
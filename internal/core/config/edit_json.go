@@ -0,0 +1,108 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// jsonEditor edits JSON config files
+type jsonEditor struct{}
+
+func (jsonEditor) emptyConfig() string {
+	return "{\n  \"version\": 1,\n  \"binaries\": []\n}\n"
+}
+
+func (e jsonEditor) addBinary(d *Document, binaries *node, entries []binaryEntry) error {
+	if binaries == nil {
+		if err := e.insertField(d, d.root, "binaries", "[]"); err != nil {
+			return err
+		}
+		binaries = d.root.field("binaries")
+	}
+
+	fields := make([]string, len(entries))
+	for i, entry := range entries {
+		fields[i] = encodeValue(entry.key) + ": " + encodeValue(entry.value)
+	}
+	unit := d.indentUnit()
+
+	if len(binaries.items) == 0 {
+		outer := d.indentAt(binaries.key)
+		itemIndent := outer + unit
+		text := "{\n" + itemIndent + unit + strings.Join(fields, ",\n"+itemIndent+unit) + "\n" + itemIndent + "}"
+		return d.replace(binaries.start, binaries.end, "[\n"+itemIndent+text+"\n"+outer+"]")
+	}
+
+	last := binaries.items[len(binaries.items)-1]
+	itemIndent := d.indentAt(last.start)
+
+	var text string
+	if d.multiline(last.start, last.end) {
+		fieldIndent := itemIndent + unit
+		if last.kind == "object" && len(last.keys) > 0 {
+			fieldIndent = d.indentAt(last.fields[last.keys[0]].key)
+		}
+		text = "{\n" + fieldIndent + strings.Join(fields, ",\n"+fieldIndent) + "\n" + itemIndent + "}"
+	} else {
+		text = "{" + strings.Join(fields, ", ") + "}"
+	}
+
+	if d.multiline(binaries.start, binaries.items[0].start) {
+		return d.replace(last.end, last.end, ",\n"+itemIndent+text)
+	}
+	return d.replace(last.end, last.end, ", "+text)
+}
+
+func (e jsonEditor) removeItem(d *Document, binaries *node, i int) error {
+	return e.removeEntry(d, binaries, binaries.items, i)
+}
+
+func (jsonEditor) setValue(d *Document, value *node, v any) error {
+	return d.replace(value.start, value.end, encodeValue(v))
+}
+
+func (e jsonEditor) addField(d *Document, object *node, key string, v any) error {
+	return e.insertField(d, object, key, encodeValue(v))
+}
+
+func (e jsonEditor) removeField(d *Document, object *node, i int) error {
+	return e.removeEntry(d, object, object.entries(), i)
+}
+
+// insertField adds a field after the last field of an object
+func (jsonEditor) insertField(d *Document, object *node, key, text string) error {
+	entry := encodeValue(key) + ": " + text
+	if len(object.keys) == 0 {
+		return d.replace(object.start, object.end, "{"+entry+"}")
+	}
+
+	first := object.fields[object.keys[0]]
+	last := object.fields[object.keys[len(object.keys)-1]]
+	if d.multiline(object.start, first.key) {
+		return d.replace(last.end, last.end, ",\n"+d.indentAt(last.key)+entry)
+	}
+	return d.replace(last.end, last.end, ", "+entry)
+}
+
+// removeEntry removes an object field or array item along with its separating comma
+func (jsonEditor) removeEntry(d *Document, container *node, entries []*node, i int) error {
+	switch {
+	case len(entries) == 1:
+		return d.replace(container.start+1, container.end-1, "")
+	case i > 0:
+		return d.replace(entries[i-1].end, entries[i].end, "")
+	default:
+		return d.replace(entries[0].key, entries[1].key, "")
+	}
+}
+
+// encodeValue encodes a value as JSON without escaping HTML characters such as ">" in
+// constraints. TOML basic strings share the JSON escapes, so TOML values use it too.
+func encodeValue(value any) string {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(value)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
@@ -0,0 +1,397 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func loadTestDocument(t *testing.T, content string) *Document {
+	t.Helper()
+	return loadNamedDocument(t, "config.json", content)
+}
+
+func loadNamedDocument(t *testing.T, name, content string) *Document {
+	t.Helper()
+	path := writeConfig(t, t.TempDir(), name, content)
+	doc, err := LoadDocument(path)
+	if err != nil {
+		t.Fatalf("LoadDocument failed: %v", err)
+	}
+	return doc
+}
+
+const editTestConfig = `{
+  "version": 1,
+  "binaries": [
+    {
+      "id": "gh",
+      "name": "gh",
+      "provider": "github",
+      "path": "cli/cli",
+      "format": ".tar.gz",
+      "x-note": "kept by edits"
+    },
+    {
+      "id": "fzf",
+      "name": "fzf",
+      "provider": "github",
+      "path": "junegunn/fzf",
+      "format": ".tar.gz"
+    }
+  ]
+}
+`
+
+func TestDocument_SetBinaryField(t *testing.T) {
+	tests := []struct {
+		name  string
+		id    string
+		key   string
+		value string
+		want  string
+	}{
+		{
+			name:  "replaces existing value",
+			id:    "fzf",
+			key:   "format",
+			value: ".zip",
+			want: strings.Replace(editTestConfig, `"junegunn/fzf",
+      "format": ".tar.gz"`, `"junegunn/fzf",
+      "format": ".zip"`, 1),
+		},
+		{
+			name:  "adds missing field",
+			id:    "fzf",
+			key:   "version",
+			value: ">=0.40 <1",
+			want: strings.Replace(editTestConfig, `"junegunn/fzf",
+      "format": ".tar.gz"`, `"junegunn/fzf",
+      "format": ".tar.gz",
+      "version": ">=0.40 <1"`, 1),
+		},
+		{
+			name:  "parses booleans",
+			id:    "gh",
+			key:   "authenticated",
+			value: "true",
+			want: strings.Replace(editTestConfig, `"x-note": "kept by edits"`, `"x-note": "kept by edits",
+      "authenticated": true`, 1),
+		},
+		{
+			name:  "parses integers",
+			id:    "gh",
+			key:   "keepVersions",
+			value: "3",
+			want: strings.Replace(editTestConfig, `"x-note": "kept by edits"`, `"x-note": "kept by edits",
+      "keepVersions": 3`, 1),
+		},
+		{
+			name:  "removes field with empty value",
+			id:    "gh",
+			key:   "format",
+			value: "",
+			want: strings.Replace(editTestConfig, `"format": ".tar.gz",
+      "x-note"`, `"x-note"`, 1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := loadTestDocument(t, editTestConfig)
+			if err := doc.SetBinaryField(tt.id, tt.key, tt.value); err != nil {
+				t.Fatalf("SetBinaryField failed: %v", err)
+			}
+			if got := string(doc.Bytes()); got != tt.want {
+				t.Errorf("Unexpected document:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDocument_SetBinaryField_Errors(t *testing.T) {
+	doc := loadTestDocument(t, editTestConfig)
+
+	if err := doc.SetBinaryField("missing", "pin", "v1"); err == nil {
+		t.Error("Expected error for unknown binary")
+	}
+	if err := doc.SetBinaryField("gh", "asetRegex", "x"); err == nil || !strings.Contains(err.Error(), "unknown binary field") {
+		t.Errorf("Expected unknown field error, got %v", err)
+	}
+	if err := doc.SetBinaryField("gh", "keepVersions", "many"); err == nil {
+		t.Error("Expected error for invalid integer")
+	}
+	if err := doc.SetBinaryField("gh", "id", "github-cli"); err == nil {
+		t.Error("Expected error when changing id")
+	}
+	if string(doc.Bytes()) != editTestConfig {
+		t.Errorf("Expected document unchanged after errors, got:\n%s", doc.Bytes())
+	}
+}
+
+func TestDocument_AddBinary(t *testing.T) {
+	// Unexported fields, such as the project config declaring a binary, are not written
+	binary := Binary{Id: "jq", Name: "jq", Provider: "github", Path: "jqlang/jq", Format: ".tar.gz", Authenticated: true, projectFile: "binmate.json"}
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "multiline entries",
+			content: editTestConfig,
+			want: strings.Replace(editTestConfig, `"format": ".tar.gz"
+    }
+  ]`, `"format": ".tar.gz"
+    },
+    {
+      "id": "jq",
+      "name": "jq",
+      "provider": "github",
+      "path": "jqlang/jq",
+      "format": ".tar.gz",
+      "authenticated": true
+    }
+  ]`, 1),
+		},
+		{
+			name: "single line entries",
+			content: `{
+	"version": 1,
+	"binaries": [
+		{"id": "gh", "name": "gh", "provider": "github", "path": "cli/cli", "format": ".tar.gz"}
+	]
+}`,
+			want: `{
+	"version": 1,
+	"binaries": [
+		{"id": "gh", "name": "gh", "provider": "github", "path": "cli/cli", "format": ".tar.gz"},
+		{"id": "jq", "name": "jq", "provider": "github", "path": "jqlang/jq", "format": ".tar.gz", "authenticated": true}
+	]
+}`,
+		},
+		{
+			name: "empty list",
+			content: `{
+  "version": 1,
+  "binaries": []
+}`,
+			want: `{
+  "version": 1,
+  "binaries": [
+    {
+      "id": "jq",
+      "name": "jq",
+      "provider": "github",
+      "path": "jqlang/jq",
+      "format": ".tar.gz",
+      "authenticated": true
+    }
+  ]
+}`,
+		},
+		{
+			name: "missing list",
+			content: `{
+  "version": 1
+}`,
+			want: `{
+  "version": 1,
+  "binaries": [
+    {
+      "id": "jq",
+      "name": "jq",
+      "provider": "github",
+      "path": "jqlang/jq",
+      "format": ".tar.gz",
+      "authenticated": true
+    }
+  ]
+}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := loadTestDocument(t, tt.content)
+			if err := doc.AddBinary(binary); err != nil {
+				t.Fatalf("AddBinary failed: %v", err)
+			}
+			if got := string(doc.Bytes()); got != tt.want {
+				t.Errorf("Unexpected document:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("duplicate id", func(t *testing.T) {
+		doc := loadTestDocument(t, editTestConfig)
+		if err := doc.AddBinary(Binary{Id: "gh"}); err == nil {
+			t.Error("Expected error for duplicate id")
+		}
+	})
+}
+
+func TestDocument_RemoveBinary(t *testing.T) {
+	t.Run("last entry", func(t *testing.T) {
+		doc := loadTestDocument(t, editTestConfig)
+		if err := doc.RemoveBinary("fzf"); err != nil {
+			t.Fatalf("RemoveBinary failed: %v", err)
+		}
+		want := `{
+  "version": 1,
+  "binaries": [
+    {
+      "id": "gh",
+      "name": "gh",
+      "provider": "github",
+      "path": "cli/cli",
+      "format": ".tar.gz",
+      "x-note": "kept by edits"
+    }
+  ]
+}
+`
+		if got := string(doc.Bytes()); got != want {
+			t.Errorf("Unexpected document:\n%s", got)
+		}
+	})
+
+	t.Run("first entry", func(t *testing.T) {
+		doc := loadTestDocument(t, editTestConfig)
+		if err := doc.RemoveBinary("gh"); err != nil {
+			t.Fatalf("RemoveBinary failed: %v", err)
+		}
+		if doc.HasBinary("gh") || !doc.HasBinary("fzf") {
+			t.Errorf("Expected only gh removed, got:\n%s", doc.Bytes())
+		}
+		if !strings.Contains(string(doc.Bytes()), "\"binaries\": [\n    {\n      \"id\": \"fzf\"") {
+			t.Errorf("Expected fzf entry formatting preserved, got:\n%s", doc.Bytes())
+		}
+	})
+
+	t.Run("only entry", func(t *testing.T) {
+		doc := loadTestDocument(t, `{"version": 1, "binaries": [{"id": "gh"}]}`)
+		if err := doc.RemoveBinary("gh"); err != nil {
+			t.Fatalf("RemoveBinary failed: %v", err)
+		}
+		if got := string(doc.Bytes()); got != `{"version": 1, "binaries": []}` {
+			t.Errorf("Unexpected document: %s", got)
+		}
+	})
+
+	t.Run("missing entry", func(t *testing.T) {
+		doc := loadTestDocument(t, editTestConfig)
+		if err := doc.RemoveBinary("jq"); err == nil {
+			t.Error("Expected error for missing binary")
+		}
+	})
+}
+
+func TestBinaryFields(t *testing.T) {
+	fields := BinaryFields()
+	if !slices.Contains(fields, "installPath") {
+		t.Errorf("Expected installPath in %v", fields)
+	}
+	if slices.Contains(fields, "") {
+		t.Errorf("Expected only config keys, got %q", fields)
+	}
+
+	doc := loadTestDocument(t, editTestConfig)
+	if err := doc.SetBinaryField("gh", "", "binmate.json"); err == nil || strings.Contains(err.Error(), ", ,") {
+		t.Errorf("Expected unknown field error listing config keys, got %v", err)
+	}
+}
+
+func TestDocument_SaveValidated(t *testing.T) {
+	loadSchema(t)
+	content := strings.Replace(editTestConfig, `".tar.gz",
+      "x-note": "kept by edits"`, `".tar.gz"`, 1)
+	doc := loadTestDocument(t, content)
+
+	if err := doc.SetBinaryField("gh", "assetRegex", "linux_(amd64"); err != nil {
+		t.Fatalf("SetBinaryField failed: %v", err)
+	}
+	err := doc.SaveValidated([]string{doc.Path}, "")
+	if err == nil || !strings.Contains(err.Error(), "invalid regex") {
+		t.Fatalf("Expected invalid regex error, got %v", err)
+	}
+	if errs, ok := err.(ValidationErrors); !ok || errs[0].File != doc.Path {
+		t.Errorf("Expected the error reported against %s, got %v", doc.Path, err)
+	}
+
+	data, _ := os.ReadFile(doc.Path)
+	if string(data) != content {
+		t.Errorf("Expected original file restored, got:\n%s", data)
+	}
+
+	doc, _ = LoadDocument(doc.Path)
+	doc.SetBinaryField("gh", "assetRegex", "linux_amd64")
+	if err := doc.SaveValidated([]string{doc.Path}, ""); err != nil {
+		t.Fatalf("SaveValidated failed: %v", err)
+	}
+	data, _ = os.ReadFile(doc.Path)
+	if !strings.Contains(string(data), `"assetRegex": "linux_amd64"`) {
+		t.Errorf("Expected saved change, got:\n%s", data)
+	}
+}
+
+func TestDocument_SaveValidated_ExistingProblems(t *testing.T) {
+	loadSchema(t)
+	// editTestConfig already has an unknown property, which an edit does not cause
+	doc := loadTestDocument(t, editTestConfig)
+	if err := os.Chmod(doc.Path, 0o600); err != nil {
+		t.Fatalf("Failed to chmod config: %v", err)
+	}
+
+	doc.SetBinaryField("gh", "assetRegex", "linux_amd64")
+	if err := doc.SaveValidated([]string{doc.Path}, ""); err != nil {
+		t.Fatalf("SaveValidated failed: %v", err)
+	}
+	data, _ := os.ReadFile(doc.Path)
+	if !strings.Contains(string(data), `"assetRegex": "linux_amd64"`) {
+		t.Errorf("Expected saved change, got:\n%s", data)
+	}
+	if info, _ := os.Stat(doc.Path); info.Mode().Perm() != 0o600 {
+		t.Errorf("Expected mode 0600 kept, got %v", info.Mode().Perm())
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(doc.Path)); len(entries) != 1 {
+		t.Errorf("Expected only the config file left, got %v", entries)
+	}
+
+	// New problems are still reported
+	doc.SetBinaryField("gh", "assetRegex", "linux_(amd64")
+	if err := doc.SaveValidated([]string{doc.Path}, ""); err == nil {
+		t.Error("Expected invalid regex error")
+	}
+}
+
+func TestLoadDocument_MissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".binmate", "config.json")
+	doc, err := LoadDocument(path)
+	if err != nil {
+		t.Fatalf("LoadDocument failed: %v", err)
+	}
+	if err := doc.AddBinary(Binary{Id: "jq", Name: "jq", Provider: "github", Path: "jqlang/jq", Format: ".tar.gz"}); err != nil {
+		t.Fatalf("AddBinary failed: %v", err)
+	}
+	if err := doc.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	config, err := readConfigFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read saved config: %v", err)
+	}
+	if config.Version != 1 || len(config.Binaries) != 1 || config.Binaries[0].Path != "jqlang/jq" {
+		t.Errorf("Unexpected saved config: %+v", config)
+	}
+}
+
+func TestLoadDocument_UnsupportedFormat(t *testing.T) {
+	if _, err := LoadDocument(filepath.Join(t.TempDir(), "config.ini")); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+}
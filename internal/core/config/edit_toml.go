@@ -0,0 +1,95 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// tomlEditor edits TOML config files. Binaries are written as [[binaries]] tables and
+// edited a line at a time, so comments on other lines are kept.
+type tomlEditor struct{}
+
+func (tomlEditor) emptyConfig() string {
+	return "version = 1\n"
+}
+
+func (tomlEditor) addBinary(d *Document, binaries *node, entries []binaryEntry) error {
+	var table strings.Builder
+	table.WriteString("[[binaries]]\n")
+	for _, entry := range entries {
+		table.WriteString(entry.key + " = " + encodeValue(entry.value) + "\n")
+	}
+
+	if binaries != nil && binaries.flow {
+		if len(binaries.items) > 0 {
+			return errors.New("binaries is an inline array, rewrite it as [[binaries]] tables to add binaries")
+		}
+		// An empty inline array is replaced by the first table
+		if err := d.replace(lineStart(d.data, binaries.key), lineEnd(d.data, binaries.key), ""); err != nil {
+			return err
+		}
+		binaries = nil
+	}
+
+	offset := len(d.data)
+	if binaries != nil {
+		offset = binaries.items[len(binaries.items)-1].end
+	}
+
+	// Tables are separated by a blank line
+	prefix := ""
+	if offset > 0 && !bytes.HasSuffix(d.data[:offset], []byte("\n\n")) {
+		prefix = newlineBefore(d.data, offset) + "\n"
+	}
+	suffix := ""
+	if offset < len(d.data) {
+		suffix = "\n"
+	}
+	return d.replace(offset, offset, prefix+table.String()+suffix)
+}
+
+func (tomlEditor) removeItem(d *Document, binaries *node, i int) error {
+	if binaries.flow {
+		return errors.New("binaries is an inline array, rewrite it as [[binaries]] tables to remove binaries")
+	}
+
+	item := binaries.items[i]
+	start := commentStart(d.data, item.start)
+	if item.end == len(d.data) {
+		// Drop the blank lines separating the last table from the one before
+		for start > 1 && bytes.HasSuffix(d.data[:start], []byte("\n\n")) {
+			start--
+		}
+	}
+	return d.replace(start, item.end, "")
+}
+
+func (tomlEditor) setValue(d *Document, value *node, v any) error {
+	if value.kind == "object" || value.kind == "array" {
+		return fmt.Errorf("unable to replace a %s value", value.kind)
+	}
+	return d.replace(value.start, value.end, encodeValue(v))
+}
+
+func (tomlEditor) addField(d *Document, object *node, key string, v any) error {
+	if object.flow {
+		return errors.New("binary is an inline table, rewrite it as a [[binaries]] table to add fields")
+	}
+
+	offset, indent := lineEnd(d.data, object.start), ""
+	if len(object.keys) > 0 {
+		last := object.fields[object.keys[len(object.keys)-1]]
+		offset, indent = lineEnd(d.data, last.end), d.indentAt(last.key)
+	}
+	return d.replace(offset, offset, newlineBefore(d.data, offset)+indent+key+" = "+encodeValue(v)+"\n")
+}
+
+func (tomlEditor) removeField(d *Document, object *node, i int) error {
+	if object.flow {
+		return errors.New("binary is an inline table, rewrite it as a [[binaries]] table to remove fields")
+	}
+	field := object.entries()[i]
+	return d.replace(lineStart(d.data, field.key), lineEnd(d.data, field.end), "")
+}
@@ -0,0 +1,203 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+const tomlTestConfig = `# Tools for this machine
+version = 1
+
+[global]
+installPath = "/usr/local/bin"
+
+# Pinned until the new release asset names are supported
+[[binaries]]
+id = "gh"
+name = "gh"
+provider = "github"
+path = "cli/cli"
+format = ".tar.gz" # upstream also ships .zip
+
+[[binaries]]
+id = "fzf"
+name = "fzf"
+provider = "github"
+path = "junegunn/fzf"
+format = '.tar.gz'
+`
+
+func TestTOMLDocument_SetBinaryField(t *testing.T) {
+	tests := []struct {
+		name  string
+		id    string
+		key   string
+		value string
+		want  string
+	}{
+		{
+			name:  "replaces value keeping line comment",
+			id:    "gh",
+			key:   "format",
+			value: ".zip",
+			want:  strings.Replace(tomlTestConfig, `format = ".tar.gz" #`, `format = ".zip" #`, 1),
+		},
+		{
+			name:  "replaces literal string",
+			id:    "fzf",
+			key:   "format",
+			value: ".zip",
+			want:  strings.Replace(tomlTestConfig, `format = '.tar.gz'`, `format = ".zip"`, 1),
+		},
+		{
+			name:  "adds missing field",
+			id:    "gh",
+			key:   "version",
+			value: ">=2 <3",
+			want:  strings.Replace(tomlTestConfig, "ships .zip\n", "ships .zip\nversion = \">=2 <3\"\n", 1),
+		},
+		{
+			name:  "parses booleans",
+			id:    "fzf",
+			key:   "authenticated",
+			value: "true",
+			want:  tomlTestConfig + "authenticated = true\n",
+		},
+		{
+			name:  "removes field with empty value",
+			id:    "gh",
+			key:   "format",
+			value: "",
+			want:  strings.Replace(tomlTestConfig, "format = \".tar.gz\" # upstream also ships .zip\n", "", 1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := loadNamedDocument(t, "config.toml", tomlTestConfig)
+			if err := doc.SetBinaryField(tt.id, tt.key, tt.value); err != nil {
+				t.Fatalf("SetBinaryField failed: %v", err)
+			}
+			if got := string(doc.Bytes()); got != tt.want {
+				t.Errorf("Unexpected document:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTOMLDocument_AddBinary(t *testing.T) {
+	binary := Binary{Id: "jq", Name: "jq", Provider: "github", Path: "jqlang/jq", Format: ".tar.gz", Authenticated: true}
+	table := `[[binaries]]
+id = "jq"
+name = "jq"
+provider = "github"
+path = "jqlang/jq"
+format = ".tar.gz"
+authenticated = true
+`
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "after last table",
+			content: tomlTestConfig,
+			want:    tomlTestConfig + "\n" + table,
+		},
+		{
+			name:    "before following table",
+			content: "version = 1\n\n[[binaries]]\nid = \"gh\"\n\n[global]\ninstallPath = \"/opt/bin\"\n",
+			want:    "version = 1\n\n[[binaries]]\nid = \"gh\"\n\n" + table + "\n[global]\ninstallPath = \"/opt/bin\"\n",
+		},
+		{
+			name:    "empty inline array",
+			content: "version = 1\nbinaries = []\n",
+			want:    "version = 1\n\n" + table,
+		},
+		{
+			name:    "new file",
+			content: "version = 1",
+			want:    "version = 1\n\n" + table,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := loadNamedDocument(t, "config.toml", tt.content)
+			if err := doc.AddBinary(binary); err != nil {
+				t.Fatalf("AddBinary failed: %v", err)
+			}
+			if got := string(doc.Bytes()); got != tt.want {
+				t.Errorf("Unexpected document:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("inline array with entries", func(t *testing.T) {
+		doc := loadNamedDocument(t, "config.toml", "version = 1\nbinaries = [{id = \"gh\"}]\n")
+		if err := doc.AddBinary(binary); err == nil || !strings.Contains(err.Error(), "inline array") {
+			t.Errorf("Expected inline array error, got %v", err)
+		}
+	})
+}
+
+func TestTOMLDocument_RemoveBinary(t *testing.T) {
+	t.Run("removes table with its comments", func(t *testing.T) {
+		doc := loadNamedDocument(t, "config.toml", tomlTestConfig)
+		if err := doc.RemoveBinary("gh"); err != nil {
+			t.Fatalf("RemoveBinary failed: %v", err)
+		}
+		want := strings.Replace(tomlTestConfig, `# Pinned until the new release asset names are supported
+[[binaries]]
+id = "gh"
+name = "gh"
+provider = "github"
+path = "cli/cli"
+format = ".tar.gz" # upstream also ships .zip
+
+`, "", 1)
+		if got := string(doc.Bytes()); got != want {
+			t.Errorf("Unexpected document:\n%s", got)
+		}
+	})
+
+	t.Run("last table", func(t *testing.T) {
+		doc := loadNamedDocument(t, "config.toml", tomlTestConfig)
+		if err := doc.RemoveBinary("fzf"); err != nil {
+			t.Fatalf("RemoveBinary failed: %v", err)
+		}
+		want := tomlTestConfig[:strings.Index(tomlTestConfig, "\n[[binaries]]\nid = \"fzf\"")]
+		if got := string(doc.Bytes()); got != want {
+			t.Errorf("Unexpected document:\n%s", got)
+		}
+	})
+}
+
+func TestNewDocument_Formats(t *testing.T) {
+	for _, format := range Formats {
+		doc, err := NewDocument("", format)
+		if err != nil {
+			t.Fatalf("NewDocument(%q) failed: %v", format, err)
+		}
+		if err := doc.AddBinary(Binary{Id: "jq", Name: "jq", Provider: "github", Path: "jqlang/jq", Format: ".tar.gz"}); err != nil {
+			t.Fatalf("AddBinary to %s document failed: %v", format, err)
+		}
+
+		root, err := parseNodes(format, doc.Bytes())
+		if err != nil {
+			t.Fatalf("Failed to parse %s document: %v\n%s", format, err, doc.Bytes())
+		}
+		if parsed := (parsedFile{root: root}); parsed.binary("jq").field("path").str() != "jqlang/jq" {
+			t.Errorf("Expected jq in %s document, got:\n%s", format, doc.Bytes())
+		}
+	}
+
+	if _, err := NewDocument("", "ini"); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+	if doc, _ := NewDocument("binmate.toml", ""); doc.Format() != FormatTOML {
+		t.Errorf("Expected format from path, got %s", doc.Format())
+	}
+}
@@ -0,0 +1,158 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// yamlEditor edits YAML config files. Entries are written in block style, or in flow style
+// where the binary being edited is written that way.
+type yamlEditor struct{}
+
+func (yamlEditor) emptyConfig() string {
+	return "version: 1\nbinaries: []\n"
+}
+
+func (e yamlEditor) addBinary(d *Document, binaries *node, entries []binaryEntry) error {
+	unit := d.indentUnit()
+
+	switch {
+	case binaries == nil:
+		text := "binaries:\n" + e.item(unit, entries)
+		return d.replace(len(d.data), len(d.data), newlineBefore(d.data, len(d.data))+text)
+
+	case len(binaries.items) == 0:
+		// An empty flow sequence is replaced by a block sequence
+		start := binaries.start
+		for start > 0 && (d.data[start-1] == ' ' || d.data[start-1] == '\t') {
+			start--
+		}
+		text := "\n" + strings.TrimSuffix(e.item(d.indentAt(binaries.key)+unit, entries), "\n")
+		return d.replace(start, binaries.end, text)
+
+	case binaries.flow:
+		return errors.New("binaries is a flow sequence, rewrite it as a block sequence to add binaries")
+	}
+
+	last := binaries.items[len(binaries.items)-1]
+	var text string
+	if last.flow {
+		fields := make([]string, len(entries))
+		for i, entry := range entries {
+			fields[i] = entry.key + ": " + yamlValue(entry.value)
+		}
+		text = d.indentAt(last.start) + "- {" + strings.Join(fields, ", ") + "}\n"
+	} else {
+		text = e.item(d.indentAt(last.start), entries)
+	}
+
+	end := lineEnd(d.data, last.end)
+	return d.replace(end, end, newlineBefore(d.data, end)+text)
+}
+
+// item formats a block sequence item with its fields aligned after the "- " indicator
+func (yamlEditor) item(indent string, entries []binaryEntry) string {
+	var text strings.Builder
+	for i, entry := range entries {
+		if i == 0 {
+			text.WriteString(indent + "- ")
+		} else {
+			text.WriteString(indent + "  ")
+		}
+		text.WriteString(entry.key + ": " + yamlValue(entry.value) + "\n")
+	}
+	return text.String()
+}
+
+func (yamlEditor) removeItem(d *Document, binaries *node, i int) error {
+	if binaries.flow {
+		return errors.New("binaries is a flow sequence, rewrite it as a block sequence to remove binaries")
+	}
+
+	item := binaries.items[i]
+	start := commentStart(d.data, lineStart(d.data, item.start))
+	end := lineEnd(d.data, item.end)
+	if len(binaries.items) > 1 {
+		return d.replace(start, end, "")
+	}
+
+	// Removing the only item leaves an empty list rather than a null value
+	colon := binaries.key + bytes.IndexByte(d.data[binaries.key:], ':')
+	if err := d.replace(start, end, ""); err != nil {
+		return err
+	}
+	return d.replace(colon+1, colon+1, " []")
+}
+
+func (yamlEditor) setValue(d *Document, value *node, v any) error {
+	switch {
+	case value.kind == "object" || value.kind == "array":
+		return fmt.Errorf("unable to replace a %s value", value.kind)
+	case value.kind == "null" && value.raw == "":
+		// An empty value has no source text, so the value is written after the key
+		colon := value.key + bytes.IndexByte(d.data[value.key:], ':')
+		return d.replace(colon+1, colon+1, " "+yamlValue(v))
+	}
+	return d.replace(value.start, value.end, yamlValue(v))
+}
+
+func (yamlEditor) addField(d *Document, object *node, key string, v any) error {
+	entry := key + ": " + yamlValue(v)
+	last := object.fields[object.keys[len(object.keys)-1]]
+	if object.flow {
+		return d.replace(last.end, last.end, ", "+entry)
+	}
+
+	first := object.fields[object.keys[0]]
+	indent := strings.Repeat(" ", first.key-lineStart(d.data, first.key))
+	end := lineEnd(d.data, last.end)
+	return d.replace(end, end, newlineBefore(d.data, end)+indent+entry+"\n")
+}
+
+func (yamlEditor) removeField(d *Document, object *node, i int) error {
+	entries := object.entries()
+	field := entries[i]
+
+	if object.flow {
+		switch {
+		case len(entries) == 1:
+			return d.replace(object.start+1, object.end-1, "")
+		case i > 0:
+			return d.replace(entries[i-1].end, field.end, "")
+		default:
+			return d.replace(field.key, entries[1].key, "")
+		}
+	}
+
+	// The first field of a sequence item shares its line with the "- " indicator, which
+	// the next field moves up to
+	if strings.TrimSpace(string(d.data[lineStart(d.data, field.key):field.key])) != "" {
+		if len(entries) == 1 {
+			return errors.New("unable to remove the only field of an entry")
+		}
+		return d.replace(field.key, entries[1].key, "")
+	}
+	return d.replace(lineStart(d.data, field.key), lineEnd(d.data, field.end), "")
+}
+
+// yamlValue encodes a scalar as YAML, quoting strings only where needed
+func yamlValue(value any) string {
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return encodeValue(value)
+	}
+	return strings.TrimSuffix(string(data), "\n")
+}
+
+// newlineBefore returns the newline needed to insert lines at an offset that may be at the
+// end of a file without a trailing newline
+func newlineBefore(data []byte, offset int) string {
+	if offset > 0 && data[offset-1] != '\n' {
+		return "\n"
+	}
+	return ""
+}
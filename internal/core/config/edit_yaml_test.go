@@ -0,0 +1,165 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+const yamlTestConfig = `# Tools for this machine
+version: 1
+
+binaries:
+  # Pinned until the new release asset names are supported
+  - id: gh
+    name: gh
+    provider: github
+    path: cli/cli
+    format: .tar.gz # upstream also ships .zip
+
+  - id: fzf
+    name: fzf
+    provider: github
+    path: junegunn/fzf
+    format: ".tar.gz"
+`
+
+func TestYAMLDocument_SetBinaryField(t *testing.T) {
+	tests := []struct {
+		name  string
+		id    string
+		key   string
+		value string
+		want  string
+	}{
+		{
+			name:  "replaces value keeping line comment",
+			id:    "gh",
+			key:   "format",
+			value: ".zip",
+			want:  strings.Replace(yamlTestConfig, "format: .tar.gz #", "format: .zip #", 1),
+		},
+		{
+			name:  "replaces quoted value",
+			id:    "fzf",
+			key:   "format",
+			value: ".zip",
+			want:  strings.Replace(yamlTestConfig, `format: ".tar.gz"`, "format: .zip", 1),
+		},
+		{
+			name:  "adds missing field",
+			id:    "gh",
+			key:   "version",
+			value: ">=2 <3",
+			want:  strings.Replace(yamlTestConfig, "ships .zip\n", "ships .zip\n    version: '>=2 <3'\n", 1),
+		},
+		{
+			name:  "parses integers",
+			id:    "fzf",
+			key:   "keepVersions",
+			value: "3",
+			want:  yamlTestConfig + "    keepVersions: 3\n",
+		},
+		{
+			name:  "removes field with empty value",
+			id:    "gh",
+			key:   "format",
+			value: "",
+			want:  strings.Replace(yamlTestConfig, "    format: .tar.gz # upstream also ships .zip\n", "", 1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := loadNamedDocument(t, "config.yaml", yamlTestConfig)
+			if err := doc.SetBinaryField(tt.id, tt.key, tt.value); err != nil {
+				t.Fatalf("SetBinaryField failed: %v", err)
+			}
+			if got := string(doc.Bytes()); got != tt.want {
+				t.Errorf("Unexpected document:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestYAMLDocument_AddBinary(t *testing.T) {
+	binary := Binary{Id: "jq", Name: "jq", Provider: "github", Path: "jqlang/jq", Format: ".tar.gz", Authenticated: true}
+	entry := `- id: jq
+    name: jq
+    provider: github
+    path: jqlang/jq
+    format: .tar.gz
+    authenticated: true
+`
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "block entries",
+			content: yamlTestConfig,
+			want:    yamlTestConfig + "  " + entry,
+		},
+		{
+			name:    "empty list",
+			content: "version: 1\nbinaries: []\n",
+			want:    "version: 1\nbinaries:\n  " + entry,
+		},
+		{
+			name:    "missing list",
+			content: "version: 1",
+			want:    "version: 1\nbinaries:\n  " + entry,
+		},
+		{
+			name:    "flow entries",
+			content: "version: 1\nbinaries:\n- {id: gh, name: gh}\n",
+			want:    "version: 1\nbinaries:\n- {id: gh, name: gh}\n- {id: jq, name: jq, provider: github, path: jqlang/jq, format: .tar.gz, authenticated: true}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := loadNamedDocument(t, "config.yaml", tt.content)
+			if err := doc.AddBinary(binary); err != nil {
+				t.Fatalf("AddBinary failed: %v", err)
+			}
+			if got := string(doc.Bytes()); got != tt.want {
+				t.Errorf("Unexpected document:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestYAMLDocument_RemoveBinary(t *testing.T) {
+	t.Run("removes entry with its comments", func(t *testing.T) {
+		doc := loadNamedDocument(t, "config.yaml", yamlTestConfig)
+		if err := doc.RemoveBinary("gh"); err != nil {
+			t.Fatalf("RemoveBinary failed: %v", err)
+		}
+		want := `# Tools for this machine
+version: 1
+
+binaries:
+
+  - id: fzf
+    name: fzf
+    provider: github
+    path: junegunn/fzf
+    format: ".tar.gz"
+`
+		if got := string(doc.Bytes()); got != want {
+			t.Errorf("Unexpected document:\n%s", got)
+		}
+	})
+
+	t.Run("only entry", func(t *testing.T) {
+		doc := loadNamedDocument(t, "config.yaml", "version: 1\nbinaries:\n  - id: gh\n    name: gh\nlogLevel: info\n")
+		if err := doc.RemoveBinary("gh"); err != nil {
+			t.Fatalf("RemoveBinary failed: %v", err)
+		}
+		if got := string(doc.Bytes()); got != "version: 1\nbinaries: []\nlogLevel: info\n" {
+			t.Errorf("Unexpected document:\n%s", got)
+		}
+	})
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+
+	"cturner8/binmate/internal/database"
+	"cturner8/binmate/internal/database/repository"
+)
+
+// ExportOptions controls which state is exported with manual binaries
+type ExportOptions struct {
+	PinActive bool // Pin binaries without a pin to their active version
+}
+
+// ExportBinaries returns config entries for the binaries added manually (with binmate add,
+// import or the TUI), which only exist in the database. Pins set on a binary are exported
+// with it.
+func ExportBinaries(dbService *repository.Service, opts ExportOptions) ([]Binary, error) {
+	binaries, err := dbService.Binaries.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list binaries: %w", err)
+	}
+
+	exported := []Binary{}
+	for _, binary := range binaries {
+		if binary.Source != "manual" {
+			continue
+		}
+
+		entry := fromDatabaseBinary(binary)
+
+		pin, err := dbService.Pins.Get(binary.ID)
+		if err != nil && err != database.ErrNotFound {
+			return nil, fmt.Errorf("failed to get pin for %s: %w", binary.UserID, err)
+		}
+		if err == nil {
+			entry.Pin = pin.Version
+		}
+
+		if entry.Pin == "" && opts.PinActive {
+			_, installation, err := dbService.Versions.GetWithInstallation(binary.ID)
+			if err != nil && err != database.ErrNotFound {
+				return nil, fmt.Errorf("failed to get active version for %s: %w", binary.UserID, err)
+			}
+			if err == nil {
+				entry.Pin = installation.Version
+			}
+		}
+
+		exported = append(exported, entry)
+	}
+
+	sort.Slice(exported, func(i, j int) bool { return exported[i].Id < exported[j].Id })
+	return exported, nil
+}
+
+// fromDatabaseBinary converts a database binary to its config entry
+func fromDatabaseBinary(binary *database.Binary) Binary {
+	entry := Binary{
+		Id:            binary.UserID,
+		Name:          binary.Name,
+		Alias:         derefString(binary.Alias),
+		Provider:      binary.Provider,
+		Path:          binary.ProviderPath,
+		InstallPath:   derefString(binary.InstallPath),
+		Format:        binary.Format,
+		AssetRegex:    derefString(binary.AssetRegex),
+		ReleaseRegex:  derefString(binary.ReleaseRegex),
+		Authenticated: binary.Authenticated,
+		Version:       derefString(binary.VersionConstraint),
+		Channel:       derefString(binary.Channel),
+		NightlyRegex:  derefString(binary.NightlyRegex),
+		Shim:          binary.Shim,
+		BinPath:       derefString(binary.BinPath),
+	}
+	if binary.KeepVersions != nil {
+		entry.KeepVersions = *binary.KeepVersions
+	}
+	return entry
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"cturner8/binmate/internal/database"
+	"cturner8/binmate/internal/database/repository"
+)
+
+func setupExportDB(t *testing.T) *repository.Service {
+	t.Helper()
+	db, err := database.Initialize(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to initialize database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	dbService := repository.NewService(db)
+
+	// A config binary, which is not exported
+	if err := SyncToDatabase(Config{Version: 1, Binaries: []Binary{
+		{Id: "gh", Name: "gh", Provider: "github", Path: "cli/cli", Format: ".tar.gz"},
+	}}, dbService); err != nil {
+		t.Fatalf("Failed to sync config: %v", err)
+	}
+
+	alias, installPath, assetRegex := "rg", "/opt/bin", "musl"
+	manual := []*database.Binary{
+		{UserID: "ripgrep", Name: "ripgrep", Alias: &alias, Provider: "github", ProviderPath: "BurntSushi/ripgrep",
+			Format: ".tar.gz", InstallPath: &installPath, AssetRegex: &assetRegex, Authenticated: true},
+		{UserID: "fzf", Name: "fzf", Provider: "github", ProviderPath: "junegunn/fzf", Format: ".zip"},
+		{UserID: "jq", Name: "jq", Provider: "github", ProviderPath: "jqlang/jq", Format: ".tar.gz"},
+	}
+	for _, binary := range manual {
+		if err := dbService.Binaries.Create(binary); err != nil {
+			t.Fatalf("Failed to create binary: %v", err)
+		}
+		installation := &database.Installation{BinaryID: binary.ID, Version: "v1.0.0", InstalledPath: "/tmp/" + binary.UserID}
+		if err := dbService.Installations.Create(installation); err != nil {
+			t.Fatalf("Failed to create installation: %v", err)
+		}
+		if binary.UserID != "jq" {
+			if err := dbService.Versions.Set(binary.ID, installation.ID, "/tmp/bin/"+binary.UserID); err != nil {
+				t.Fatalf("Failed to set active version: %v", err)
+			}
+		}
+	}
+	if err := dbService.Pins.Set(manual[1].ID, "v0.9.0", "manual"); err != nil {
+		t.Fatalf("Failed to pin: %v", err)
+	}
+
+	return dbService
+}
+
+func TestExportBinaries(t *testing.T) {
+	dbService := setupExportDB(t)
+
+	binaries, err := ExportBinaries(dbService, ExportOptions{})
+	if err != nil {
+		t.Fatalf("ExportBinaries failed: %v", err)
+	}
+
+	want := []Binary{
+		{Id: "fzf", Name: "fzf", Provider: "github", Path: "junegunn/fzf", Format: ".zip", Pin: "v0.9.0"},
+		{Id: "jq", Name: "jq", Provider: "github", Path: "jqlang/jq", Format: ".tar.gz"},
+		{Id: "ripgrep", Name: "ripgrep", Alias: "rg", Provider: "github", Path: "BurntSushi/ripgrep", Format: ".tar.gz",
+			InstallPath: "/opt/bin", AssetRegex: "musl", Authenticated: true},
+	}
+	if len(binaries) != len(want) {
+		t.Fatalf("Expected %d binaries, got %d: %+v", len(want), len(binaries), binaries)
+	}
+	for i := range want {
+		if !reflect.DeepEqual(binaries[i], want[i]) {
+			t.Errorf("Binary %d: expected %+v, got %+v", i, want[i], binaries[i])
+		}
+	}
+}
+
+func TestExportBinaries_PinActive(t *testing.T) {
+	dbService := setupExportDB(t)
+
+	binaries, err := ExportBinaries(dbService, ExportOptions{PinActive: true})
+	if err != nil {
+		t.Fatalf("ExportBinaries failed: %v", err)
+	}
+
+	pins := map[string]string{}
+	for _, binary := range binaries {
+		pins[binary.Id] = binary.Pin
+	}
+
+	// Existing pins are kept, binaries without an active version stay unpinned
+	want := map[string]string{"fzf": "v0.9.0", "jq": "", "ripgrep": "v1.0.0"}
+	for id, pin := range want {
+		if pins[id] != pin {
+			t.Errorf("Expected %s pinned to %q, got %q", id, pin, pins[id])
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// Config file formats
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+	FormatTOML = "toml"
+)
+
+// Formats lists the supported config file formats
+var Formats = []string{FormatJSON, FormatYAML, FormatTOML}
+
+// FormatOf returns the config format of a file from its extension, or an empty string if
+// the extension is not a supported format
+func FormatOf(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FormatJSON
+	case ".yaml", ".yml":
+		return FormatYAML
+	case ".toml":
+		return FormatTOML
+	default:
+		return ""
+	}
+}
+
+// parseNodes parses a config document of a format into a node tree
+func parseNodes(format string, data []byte) (*node, error) {
+	switch format {
+	case FormatJSON:
+		return parseJSONNodes(data)
+	case FormatYAML:
+		return parseYAMLNodes(data)
+	case FormatTOML:
+		return parseTOMLNodes(data)
+	default:
+		return nil, fmt.Errorf("unsupported config format %q", format)
+	}
+}
+
+// lineIndex converts between byte offsets and 1-based line and column positions
+type lineIndex struct {
+	data   []byte
+	starts []int // Byte offset of the start of each line
+}
+
+func newLineIndex(data []byte) *lineIndex {
+	starts := []int{0}
+	for i, c := range data {
+		if c == '\n' {
+			starts = append(starts, i+1)
+		}
+	}
+	return &lineIndex{data: data, starts: starts}
+}
+
+// offset returns the byte offset of a line and column, counting columns in characters
+func (l *lineIndex) offset(line, column int) int {
+	if line < 1 {
+		return 0
+	}
+	if line > len(l.starts) {
+		return len(l.data)
+	}
+	offset := l.starts[line-1]
+	for i := 1; i < column && offset < len(l.data) && l.data[offset] != '\n'; i++ {
+		_, size := utf8.DecodeRune(l.data[offset:])
+		offset += size
+	}
+	return offset
+}
+
+// position returns the line and column of a byte offset
+func (l *lineIndex) position(offset int) (int, int) {
+	line := 1
+	for line < len(l.starts) && l.starts[line] <= offset {
+		line++
+	}
+	return line, utf8.RuneCount(l.data[l.starts[line-1]:offset]) + 1
+}
+
+// lineStart returns the offset of the start of the line containing an offset
+func lineStart(data []byte, offset int) int {
+	return bytes.LastIndexByte(data[:offset], '\n') + 1
+}
+
+// lineEnd returns the offset just past the newline ending the line containing an offset
+func lineEnd(data []byte, offset int) int {
+	if i := bytes.IndexByte(data[offset:], '\n'); i >= 0 {
+		return offset + i + 1
+	}
+	return len(data)
+}
+
+// commentStart returns the start of the comment lines directly above the line starting at
+// an offset, so comments describing an entry are removed with it
+func commentStart(data []byte, offset int) int {
+	for offset > 0 {
+		start := lineStart(data, offset-1)
+		if !bytes.HasPrefix(bytes.TrimSpace(data[start:offset]), []byte("#")) {
+			break
+		}
+		offset = start
+	}
+	return offset
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"cturner8/binmate/internal/providers/github"
+)
+
+// GitHubClientSettings converts global configuration into GitHub provider client settings.
+// Environment variables and {{.OS}}/{{.Arch}} templates in mirror and proxy URLs are
+// interpolated.
+func GitHubClientSettings(global GlobalConfig) (github.ClientSettings, error) {
+	ctx := TemplateContext{OS: runtime.GOOS, Arch: runtime.GOARCH}
+
+	mirrors := make([]github.Mirror, 0, len(global.Mirrors))
+	for i, m := range global.Mirrors {
+		mirror := github.Mirror{Origin: m.Origin, URL: m.URL, ForwardAuth: m.ForwardAuth}
+		fields := []struct {
+			key   string
+			value *string
+		}{{"origin", &mirror.Origin}, {"url", &mirror.URL}}
+		for _, field := range fields {
+			interpolated, err := Interpolate(*field.value, ctx)
+			if err != nil {
+				return github.ClientSettings{}, fmt.Errorf("invalid global.mirrors[%d].%s: %w", i, field.key, err)
+			}
+			*field.value = interpolated
+		}
+		mirrors = append(mirrors, mirror)
+	}
+
+	proxy, err := Interpolate(global.HTTP.Proxy, ctx)
+	if err != nil {
+		return github.ClientSettings{}, fmt.Errorf("invalid global.http.proxy: %w", err)
+	}
+
+	settings := github.ClientSettings{
+		Mirrors: mirrors,
+		Proxy:   proxy,
+		NoProxy: global.HTTP.NoProxy,
+	}
+
+	for _, file := range global.HTTP.CACertFiles {
+		settings.CACertFiles = append(settings.CACertFiles, expandHome(file))
+	}
+
+	durations := []struct {
+		field  string
+		value  string
+		target *time.Duration
+	}{
+		{"timeout", global.HTTP.Timeout, &settings.Timeout},
+		{"connectTimeout", global.HTTP.ConnectTimeout, &settings.ConnectTimeout},
+		{"responseHeaderTimeout", global.HTTP.ResponseHeaderTimeout, &settings.ResponseHeaderTimeout},
+	}
+	for _, d := range durations {
+		if d.value == "" {
+			continue
+		}
+		parsed, err := time.ParseDuration(d.value)
+		if err != nil || parsed < 0 {
+			return github.ClientSettings{}, fmt.Errorf("invalid global.http.%s '%s': expected a duration such as \"30s\"", d.field, d.value)
+		}
+		*d.target = parsed
+	}
+
+	return settings, nil
+}
+
+// expandHome replaces a leading "~/" with the user's home directory
+func expandHome(path string) string {
+	if !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[2:])
+}
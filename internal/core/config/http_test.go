@@ -0,0 +1,90 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGitHubClientSettings(t *testing.T) {
+	tests := []struct {
+		name        string
+		global      GlobalConfig
+		wantTimeout time.Duration
+		wantConnect time.Duration
+		wantMirrors int
+		wantErr     bool
+	}{
+		{
+			name:   "empty config",
+			global: GlobalConfig{},
+		},
+		{
+			name: "mirrors and timeouts",
+			global: GlobalConfig{
+				Mirrors: []Mirror{{URL: "https://mirror.example.com"}},
+				HTTP: HTTPConfig{
+					Timeout:        "5m",
+					ConnectTimeout: "10s",
+				},
+			},
+			wantTimeout: 5 * time.Minute,
+			wantConnect: 10 * time.Second,
+			wantMirrors: 1,
+		},
+		{
+			name:    "unset variable in mirror url",
+			global:  GlobalConfig{Mirrors: []Mirror{{URL: "https://${BINMATE_TEST_UNSET_MIRROR}/github"}}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid timeout",
+			global:  GlobalConfig{HTTP: HTTPConfig{Timeout: "soon"}},
+			wantErr: true,
+		},
+		{
+			name:    "negative timeout",
+			global:  GlobalConfig{HTTP: HTTPConfig{ResponseHeaderTimeout: "-1s"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GitHubClientSettings(tt.global)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GitHubClientSettings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Timeout != tt.wantTimeout {
+				t.Errorf("Timeout = %v, want %v", got.Timeout, tt.wantTimeout)
+			}
+			if got.ConnectTimeout != tt.wantConnect {
+				t.Errorf("ConnectTimeout = %v, want %v", got.ConnectTimeout, tt.wantConnect)
+			}
+			if len(got.Mirrors) != tt.wantMirrors {
+				t.Errorf("Mirrors = %d, want %d", len(got.Mirrors), tt.wantMirrors)
+			}
+		})
+	}
+}
+
+func TestGitHubClientSettings_Interpolation(t *testing.T) {
+	t.Setenv("BINMATE_TEST_MIRROR", "artifactory.example.com")
+	t.Setenv("BINMATE_TEST_PROXY", "http://proxy.example.com:3128")
+
+	got, err := GitHubClientSettings(GlobalConfig{
+		Mirrors: []Mirror{{URL: "https://${BINMATE_TEST_MIRROR}/github"}},
+		HTTP:    HTTPConfig{Proxy: "${BINMATE_TEST_PROXY}"},
+	})
+	if err != nil {
+		t.Fatalf("GitHubClientSettings() error = %v", err)
+	}
+	if got.Mirrors[0].URL != "https://artifactory.example.com/github" {
+		t.Errorf("Mirror URL = %q, want interpolated", got.Mirrors[0].URL)
+	}
+	if got.Proxy != "http://proxy.example.com:3128" {
+		t.Errorf("Proxy = %q, want interpolated", got.Proxy)
+	}
+}
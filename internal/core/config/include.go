@@ -0,0 +1,318 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"cturner8/binmate/internal/core/crypto"
+	"cturner8/binmate/internal/providers/github"
+)
+
+// includeClient builds the client fetching included configs from the proxy, CA certificate
+// and connection settings of global.http. Mirrors only apply to provider requests.
+var includeClient = func(global GlobalConfig) (*http.Client, error) {
+	settings, err := GitHubClientSettings(global)
+	if err != nil {
+		return nil, err
+	}
+	settings.Mirrors = nil
+	transport, err := github.NewTransport(settings)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{Transport: transport, Timeout: 30 * time.Second}, nil
+}
+
+// localIncludeClient builds the client fetching the configs included by files from the
+// settings of the files themselves, so included configs cannot change how they are fetched.
+// The project config is skipped, as its global settings are ignored.
+func localIncludeClient(files []string, configs []Config, projectFile string) (*http.Client, error) {
+	var local Config
+	for i, file := range files {
+		if file != projectFile {
+			local = mergeValues(local, configs[i], file, nil)
+		}
+	}
+	client, err := includeClient(local.Global)
+	if err != nil {
+		return nil, fmt.Errorf("invalid global.http settings: %w", err)
+	}
+	return client, nil
+}
+
+// Include is a config included by a config file
+type Include struct {
+	Source string // https:// URL or path as written, without its digest
+	Digest string // Digest the included config must match, e.g. "sha256:...", empty if not verified
+	File   string // Local file read: the cached copy of a URL, or the config file of a path
+	From   string // Config file including it
+
+	client *http.Client // Client fetching a URL
+}
+
+// Remote reports whether the included config is fetched from a URL
+func (i Include) Remote() bool {
+	return strings.HasPrefix(i.Source, "https://")
+}
+
+// parseInclude parses an include entry of a config file: an https:// URL or a path to a
+// config file or a directory such as a git checkout containing one, relative to the
+// including file, optionally followed by "#sha256:<checksum>"
+func parseInclude(entry, from string) (Include, error) {
+	include := Include{Source: entry, From: from}
+	if i := strings.LastIndex(entry, "#"); i >= 0 && strings.Contains(entry[i+1:], ":") {
+		include.Source, include.Digest = entry[:i], entry[i+1:]
+	}
+
+	source, err := Interpolate(include.Source, TemplateContext{OS: runtime.GOOS, Arch: runtime.GOARCH})
+	if err != nil {
+		return Include{}, err
+	}
+	include.Source = source
+
+	switch {
+	case include.Source == "":
+		return Include{}, fmt.Errorf("include is empty")
+	case strings.HasPrefix(include.Source, "http://"):
+		return Include{}, fmt.Errorf("only https:// URLs can be included")
+	case include.Remote():
+		u, err := url.Parse(include.Source)
+		if err != nil {
+			return Include{}, fmt.Errorf("invalid URL: %w", err)
+		}
+		if FormatOf(u.Path) == "" {
+			return Include{}, fmt.Errorf("URL must end in .json, .yaml, .yml or .toml to tell its format")
+		}
+		include.File, err = includeCachePath(include.Source, path.Ext(u.Path))
+		if err != nil {
+			return Include{}, err
+		}
+	default:
+		source := expandHome(include.Source)
+		if !filepath.IsAbs(source) {
+			source = filepath.Join(filepath.Dir(from), source)
+		}
+		include.File, err = includeFile(source)
+		if err != nil {
+			return Include{}, err
+		}
+	}
+	return include, nil
+}
+
+// includeFile returns the config file of an included path: the file itself, or the project
+// config file in a directory
+func includeFile(source string) (string, error) {
+	info, err := os.Stat(source)
+	if err != nil {
+		return "", err
+	}
+	if !info.IsDir() {
+		return source, nil
+	}
+	for _, name := range ProjectConfigFiles {
+		file := filepath.Join(source, name)
+		if info, err := os.Stat(file); err == nil && !info.IsDir() {
+			return file, nil
+		}
+	}
+	return "", fmt.Errorf("no %s found in %s", strings.Join(ProjectConfigFiles, ", "), source)
+}
+
+// includeCachePath returns the file a URL is cached in, named by a hash of the URL
+func includeCachePath(source, ext string) (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to locate cache directory: %w", err)
+	}
+	sum := sha256.Sum256([]byte(source))
+	return filepath.Join(cacheDir, "binmate", "includes", hex.EncodeToString(sum[:8])+ext), nil
+}
+
+// load makes the included config available in File, fetching a URL if it is not cached
+// or refresh is set, and verifies its digest. A URL that cannot be fetched falls back to
+// its cached copy, but a fetched config not matching its digest is an error.
+func (i Include) load(refresh bool) error {
+	if i.Remote() {
+		_, statErr := os.Stat(i.File)
+		if refresh || statErr != nil {
+			fetched, err := i.fetch()
+			switch {
+			case err == nil:
+				defer os.Remove(fetched)
+				if i.Digest != "" {
+					if err := crypto.VerifyDigest(fetched, i.Digest); err != nil {
+						return err
+					}
+				}
+				if err := os.Rename(fetched, i.File); err != nil {
+					return fmt.Errorf("unable to cache config: %w", err)
+				}
+			case statErr != nil:
+				return err
+			default:
+				log.Printf("Warning: using cached copy of %s: %v", i.Source, err)
+			}
+		}
+	}
+
+	if i.Digest != "" {
+		if err := crypto.VerifyDigest(i.File, i.Digest); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fetch downloads an included URL into a temporary file next to its cache file
+func (i Include) fetch() (string, error) {
+	response, err := i.client.Get(i.Source)
+	if err != nil {
+		return "", fmt.Errorf("fetch failed: %w", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("fetch failed: unexpected status %s", response.Status)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(i.File), 0o755); err != nil {
+		return "", fmt.Errorf("unable to create cache directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(i.File), filepath.Base(i.File)+".*")
+	if err != nil {
+		return "", fmt.Errorf("unable to create cache file: %w", err)
+	}
+	_, err = io.Copy(tmp, response.Body)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return "", fmt.Errorf("fetch failed: %w", err)
+	}
+	return tmp.Name(), nil
+}
+
+// readIncludes reads the configs included by config files, in the order they are listed.
+// Configs included by more than one file are read once. Includes that cannot be read are
+// skipped with a warning and returned as errors.
+func readIncludes(files []string, configs []Config, projectFile string) ([]Include, []Config, []error) {
+	var (
+		includes []Include
+		included []Config
+		errs     []error
+		seen     = map[string]bool{}
+	)
+	client, clientErr := localIncludeClient(files, configs, projectFile)
+	for i, file := range files {
+		// Project configs cannot include configs, see restrictProject
+		if file == projectFile {
+			continue
+		}
+		for _, entry := range configs[i].Include {
+			include, err := parseInclude(entry, file)
+			if err == nil && include.Remote() {
+				include.client, err = client, clientErr
+			}
+			if err == nil {
+				err = include.load(false)
+			}
+			if err != nil {
+				errs = append(errs, fmt.Errorf("unable to include %s in %s: %w", entry, file, err))
+				continue
+			}
+			if seen[include.File] {
+				continue
+			}
+			seen[include.File] = true
+
+			config, err := readConfigFile(include.File)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("unable to read %s included in %s: %w", include.Source, file, err))
+				continue
+			}
+			if len(config.Include) > 0 {
+				errs = append(errs, fmt.Errorf("%s included in %s includes other configs, which is not supported", include.Source, file))
+				continue
+			}
+			includes = append(includes, include)
+			included = append(included, config)
+		}
+	}
+	for _, err := range errs {
+		log.Printf("Warning: skipping include: %v", err)
+	}
+	return includes, included, errs
+}
+
+// layerConfigs merges configs read from files, given in increasing order of precedence,
+// over the configs they include, so any local file overrides shared config. Includes that
+// cannot be read are skipped and recorded in sources. projectFile, if one of files, is
+// restricted to the values restrictProject allows.
+func layerConfigs(files []string, configs []Config, projectFile string, sources *Sources) Config {
+	includes, included, errs := readIncludes(files, configs, projectFile)
+	if sources != nil {
+		sources.IncludeErrors = errs
+		if slices.Contains(files, projectFile) {
+			sources.ProjectFile = projectFile
+		}
+	}
+
+	config := Config{}
+	for i, include := range includes {
+		config = mergeValues(config, included[i], include.Source, sources)
+		if sources != nil {
+			sources.Includes = append(sources.Includes, include)
+		}
+	}
+	for i, file := range files {
+		fileConfig := configs[i]
+		if file != projectFile {
+			config = MergeConfig(config, fileConfig, file, sources)
+			continue
+		}
+
+		var ignored []string
+		if fileConfig, ignored = restrictProject(config, fileConfig); len(ignored) > 0 {
+			log.Printf("Warning: ignoring %s in project config %s, as project configs can only choose binaries and their versions", strings.Join(ignored, ", "), file)
+		}
+		// Binaries only the project declares are synced as project binaries, kept while
+		// binmate runs outside the project
+		declared := len(config.Binaries)
+		config = MergeConfig(config, fileConfig, file, sources)
+		for j := declared; j < len(config.Binaries); j++ {
+			config.Binaries[j].projectFile = file
+		}
+		config.projectFile = file
+	}
+	return config
+}
+
+// RefreshIncludes fetches the latest copy of every URL included by the config files read,
+// so changes to shared configs are picked up
+func RefreshIncludes(sources *Sources) error {
+	if sources == nil {
+		return nil
+	}
+	for _, include := range sources.Includes {
+		if !include.Remote() {
+			continue
+		}
+		if err := include.load(true); err != nil {
+			return fmt.Errorf("unable to refresh %s: %w", include.Source, err)
+		}
+	}
+	return nil
+}
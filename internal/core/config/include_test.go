@@ -0,0 +1,269 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// sharedConfigServer serves a config over HTTPS, with includes fetched through its client
+// and cached in a temporary directory
+type sharedConfigServer struct {
+	*httptest.Server
+	mu      sync.Mutex
+	content string
+}
+
+func newSharedConfigServer(t *testing.T, content string) *sharedConfigServer {
+	t.Helper()
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	s := &sharedConfigServer{content: content}
+	s.Server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		w.Write([]byte(s.content))
+	}))
+	t.Cleanup(s.Close)
+
+	client := includeClient
+	includeClient = func(GlobalConfig) (*http.Client, error) { return s.Client(), nil }
+	t.Cleanup(func() { includeClient = client })
+	return s
+}
+
+func (s *sharedConfigServer) set(content string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.content = content
+}
+
+const sharedConfig = `{
+  "version": 1,
+  "binaries": [
+    {"id": "gh", "name": "gh", "provider": "github", "path": "cli/cli", "format": ".tar.gz"},
+    {"id": "jq", "name": "jq", "provider": "github", "path": "jqlang/jq", "format": ".tar.gz"}
+  ]
+}`
+
+func TestReadConfigFiles_Includes(t *testing.T) {
+	server := newSharedConfigServer(t, sharedConfig)
+	dir := t.TempDir()
+
+	// A git checkout of a team config, included by its directory
+	checkout := filepath.Join(dir, "team-tools")
+	if err := os.MkdirAll(checkout, 0o755); err != nil {
+		t.Fatalf("Failed to create checkout: %v", err)
+	}
+	writeConfig(t, checkout, "binmate.yaml", `version: 1
+binaries:
+  - id: fzf
+    name: fzf
+    provider: github
+    path: junegunn/fzf
+    format: .tar.gz
+`)
+
+	path := writeConfig(t, dir, "config.json", `{
+  "version": 1,
+  "include": ["`+server.URL+`/tools.json", "./team-tools"],
+  "binaries": [
+    {"id": "gh", "pin": "v2.40.0"}
+  ]
+}`)
+
+	cfg, sources, err := ReadConfigFiles([]string{path}, "", "")
+	if err != nil {
+		t.Fatalf("ReadConfigFiles failed: %v", err)
+	}
+
+	if len(cfg.Binaries) != 3 {
+		t.Fatalf("Expected 3 binaries, got %+v", cfg.Binaries)
+	}
+	gh, _ := GetBinary("gh", cfg.Binaries)
+	if gh.Pin != "v2.40.0" || gh.Path != "cli/cli" {
+		t.Errorf("gh = %+v, expected local pin over included entry", gh)
+	}
+	if _, err := GetBinary("fzf", cfg.Binaries); err != nil {
+		t.Errorf("fzf from checkout missing: %v", err)
+	}
+
+	if len(sources.Files) != 1 || sources.Files[0] != path {
+		t.Errorf("Files = %v, expected only the local file", sources.Files)
+	}
+	if len(sources.Includes) != 2 || sources.Includes[0].Source != server.URL+"/tools.json" {
+		t.Fatalf("Includes = %+v, expected the URL and checkout", sources.Includes)
+	}
+	if got := sources.Includes[1].File; got != filepath.Join(checkout, "binmate.yaml") {
+		t.Errorf("checkout include file = %s, expected its binmate.yaml", got)
+	}
+	if got := sources.Values["binaries.jq.path"].File; got != server.URL+"/tools.json" {
+		t.Errorf("source of binaries.jq.path = %q, expected the URL", got)
+	}
+
+	// The cached copy is used while the URL is unreachable
+	server.Close()
+	if _, _, err := ReadConfigFiles([]string{path}, "", ""); err != nil {
+		t.Errorf("Expected cached include to be used, got %v", err)
+	}
+}
+
+func TestRefreshIncludes(t *testing.T) {
+	server := newSharedConfigServer(t, sharedConfig)
+	path := writeConfig(t, t.TempDir(), "config.json", `{"version": 1, "include": ["`+server.URL+`/tools.json"]}`)
+
+	_, sources, err := ReadConfigFiles([]string{path}, "", "")
+	if err != nil {
+		t.Fatalf("ReadConfigFiles failed: %v", err)
+	}
+
+	server.set(strings.Replace(sharedConfig, "cli/cli", "cli/gh", 1))
+
+	// Cached copies are read until refreshed
+	cfg, _, _ := ReadConfigFiles([]string{path}, "", "")
+	if gh, _ := GetBinary("gh", cfg.Binaries); gh.Path != "cli/cli" {
+		t.Errorf("gh.Path = %q, expected the cached config", gh.Path)
+	}
+
+	if err := RefreshIncludes(&sources); err != nil {
+		t.Fatalf("RefreshIncludes failed: %v", err)
+	}
+	cfg, _, _ = ReadConfigFiles([]string{path}, "", "")
+	if gh, _ := GetBinary("gh", cfg.Binaries); gh.Path != "cli/gh" {
+		t.Errorf("gh.Path = %q, expected the refreshed config", gh.Path)
+	}
+}
+
+func TestReadConfigFiles_IncludeHTTPSettings(t *testing.T) {
+	client := includeClient
+	server := newSharedConfigServer(t, sharedConfig)
+	includeClient = client
+
+	certFile := filepath.Join(t.TempDir(), "ca.pem")
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(certFile, cert, 0o644); err != nil {
+		t.Fatalf("Failed to write certificate: %v", err)
+	}
+	dir := t.TempDir()
+
+	// The server's certificate is only trusted through global.http.caCertFiles
+	path := writeConfig(t, dir, "config.json", `{"version": 1, "include": ["`+server.URL+`/tools.json"]}`)
+	if _, sources, _ := ReadConfigFiles([]string{path}, "", ""); len(sources.IncludeErrors) != 1 || !strings.Contains(sources.IncludeErrors[0].Error(), "certificate") {
+		t.Errorf("Expected an untrusted certificate, got %v", sources.IncludeErrors)
+	}
+
+	path = writeConfig(t, dir, "config.json", `{"version": 1, "global": {"http": {"caCertFiles": ["`+certFile+`"]}}, "include": ["`+server.URL+`/tools.json"]}`)
+	cfg, sources, err := ReadConfigFiles([]string{path}, "", "")
+	if err != nil {
+		t.Fatalf("ReadConfigFiles failed: %v", err)
+	}
+	if len(sources.IncludeErrors) > 0 || len(cfg.Binaries) != 2 {
+		t.Errorf("Expected the include to be fetched, got binaries %+v and errors %v", cfg.Binaries, sources.IncludeErrors)
+	}
+}
+
+func TestReadConfigFiles_IncludeDigest(t *testing.T) {
+	server := newSharedConfigServer(t, sharedConfig)
+	dir := t.TempDir()
+	sum := sha256.Sum256([]byte(sharedConfig))
+	digest := "sha256:" + hex.EncodeToString(sum[:])
+
+	path := writeConfig(t, dir, "config.json", `{"version": 1, "include": ["`+server.URL+`/tools.json#`+digest+`"]}`)
+	cfg, sources, err := ReadConfigFiles([]string{path}, "", "")
+	if err != nil {
+		t.Fatalf("ReadConfigFiles failed: %v", err)
+	}
+	if len(cfg.Binaries) != 2 || sources.Includes[0].Digest != digest {
+		t.Errorf("Expected verified include, got %+v", sources.Includes)
+	}
+
+	// A changed config is rejected and the verified copy kept
+	server.set(strings.Replace(sharedConfig, "cli/cli", "evil/cli", 1))
+	if err := RefreshIncludes(&sources); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("Expected checksum mismatch, got %v", err)
+	}
+	cfg, _, _ = ReadConfigFiles([]string{path}, "", "")
+	if gh, _ := GetBinary("gh", cfg.Binaries); gh.Path != "cli/cli" {
+		t.Errorf("gh.Path = %q, expected the verified config", gh.Path)
+	}
+
+	other := writeConfig(t, dir, "other.json", `{"version": 1, "include": ["`+server.URL+`/other.json#sha256:0000"]}`)
+	if _, sources, _ := ReadConfigFiles([]string{other}, "", ""); len(sources.IncludeErrors) != 1 || !strings.Contains(sources.IncludeErrors[0].Error(), "checksum mismatch") {
+		t.Errorf("Expected checksum mismatch, got %v", sources.IncludeErrors)
+	}
+}
+
+func TestReadConfigFiles_IncludeErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeConfig(t, dir, "nested.json", `{"version": 1, "include": ["other.json"]}`)
+
+	tests := []struct {
+		name    string
+		include string
+		message string
+	}{
+		{"plain http", "http://example.com/tools.json", "only https:// URLs can be included"},
+		{"unknown format", "https://example.com/tools", "URL must end in .json, .yaml, .yml or .toml"},
+		{"missing path", "./missing.json", "no such file or directory"},
+		{"directory without config", ".", "no binmate.json"},
+		{"nested include", "nested.json", "includes other configs, which is not supported"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Includes that cannot be read are skipped, leaving the rest of the config
+			path := writeConfig(t, dir, "config.json", `{"version": 1, "include": ["`+tt.include+`"], "binaries": [{"id": "gh", "name": "gh", "provider": "github", "path": "cli/cli", "format": ".tar.gz"}]}`)
+			cfg, sources, err := ReadConfigFiles([]string{path}, "", "")
+			if err != nil {
+				t.Fatalf("ReadConfigFiles failed: %v", err)
+			}
+			if len(cfg.Binaries) != 1 {
+				t.Errorf("Expected the local binary to be read, got %+v", cfg.Binaries)
+			}
+			if len(sources.IncludeErrors) != 1 || !strings.Contains(sources.IncludeErrors[0].Error(), tt.message) {
+				t.Errorf("Expected include error containing %q, got %v", tt.message, sources.IncludeErrors)
+			}
+		})
+	}
+}
+
+func TestValidateFiles_Includes(t *testing.T) {
+	loadSchema(t)
+	server := newSharedConfigServer(t, `{
+  "version": 1,
+  "binaries": [
+    {"id": "gh", "name": "gh", "provider": "github", "path": "cli/cli", "format": ".rar"}
+  ]
+}`)
+	dir := t.TempDir()
+
+	path := writeConfig(t, dir, "config.json", `{
+  "version": 1,
+  "include": [
+    "`+server.URL+`/tools.json",
+    "./missing"
+  ],
+  "binaries": [
+    {"id": "gh", "pin": "v2.40.0"}
+  ]
+}`)
+
+	errs := ValidateFiles([]string{path}, "")
+	if len(errs) != 2 {
+		t.Fatalf("Expected 2 errors, got %d:\n%v", len(errs), errs)
+	}
+	if errs[0].File != path || errs[0].Line != 5 || errs[0].Path != "include[1]" {
+		t.Errorf("Expected unreadable include reported at its entry, got %v", errs[0])
+	}
+	if errs[1].File != server.URL+"/tools.json" || errs[1].Line != 4 || errs[1].Path != "binaries[0].format" {
+		t.Errorf("Expected included config error reported against its URL, got %v", errs[1])
+	}
+}
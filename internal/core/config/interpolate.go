@@ -0,0 +1,116 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"runtime"
+	"strings"
+	"text/template"
+
+	"cturner8/binmate/internal/providers/github"
+)
+
+// envVariable matches ${NAME} environment variable references. The braces are required so
+// the "$" end anchor of regexes is left alone.
+var envVariable = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// TemplateContext is the data available to {{.Field}} templates in config values
+type TemplateContext struct {
+	OS      string // Operating system binmate is running on, e.g. "linux"
+	Arch    string // Architecture binmate is running on, e.g. "amd64"
+	Version string // Pinned version without its leading "v", e.g. "2.40.0"
+	Name    string // Binary name
+}
+
+// binaryContext returns the template context of a binary, with its pinned version if any.
+// assetRegex and binPath resolve {{.Version}} at install time instead, see interpolateBinary.
+func binaryContext(binary Binary) TemplateContext {
+	ctx := TemplateContext{OS: runtime.GOOS, Arch: runtime.GOARCH, Name: binary.Name}
+	if binary.Pin != "" {
+		ctx.Version = strings.TrimPrefix(binary.Pin, "v")
+	}
+	return ctx
+}
+
+// Interpolate expands ${NAME} environment variables and {{.OS}}, {{.Arch}}, {{.Version}}
+// and {{.Name}} templates in a config value. Referencing an unset variable is an error.
+func Interpolate(value string, ctx TemplateContext) (string, error) {
+	// Templates are expanded first so variable values are never parsed as templates
+	if strings.Contains(value, "{{") {
+		tmpl, err := template.New("value").Parse(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid template: %w", err)
+		}
+		var out strings.Builder
+		if err := tmpl.Execute(&out, ctx); err != nil {
+			return "", fmt.Errorf("invalid template: %w", err)
+		}
+		value = out.String()
+	}
+
+	var missing []string
+	value = envVariable.ReplaceAllStringFunc(value, func(ref string) string {
+		name := envVariable.FindStringSubmatch(ref)[1]
+		env, ok := os.LookupEnv(name)
+		if !ok {
+			missing = append(missing, name)
+		}
+		return env
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("environment variable %s is not set", strings.Join(missing, ", "))
+	}
+	return value, nil
+}
+
+// interpolationError is an error interpolating a field of a binary
+type interpolationError struct {
+	field string
+	err   error
+}
+
+func (e *interpolationError) Error() string {
+	return fmt.Sprintf("%s: %v", e.field, e.err)
+}
+
+// interpolateBinary interpolates the installPath, assetRegex, releaseRegex and binPath of a
+// binary. Values substituted into regexes are quoted to match literally.
+func interpolateBinary(binary Binary) (Binary, error) {
+	ctx := binaryContext(binary)
+	regexCtx := TemplateContext{
+		OS:      regexp.QuoteMeta(ctx.OS),
+		Arch:    regexp.QuoteMeta(ctx.Arch),
+		Version: regexp.QuoteMeta(ctx.Version),
+		Name:    regexp.QuoteMeta(ctx.Name),
+	}
+
+	// Fields with installed set keep the version placeholder, resolved to the version being
+	// installed, so they still match releases after the pin changes
+	fields := []struct {
+		key       string
+		value     *string
+		ctx       TemplateContext
+		installed bool
+	}{
+		{"installPath", &binary.InstallPath, ctx, false},
+		{"assetRegex", &binary.AssetRegex, regexCtx, true},
+		{"releaseRegex", &binary.ReleaseRegex, regexCtx, false},
+		{"binPath", &binary.BinPath, ctx, true},
+	}
+	for _, field := range fields {
+		ctx := field.ctx
+		if field.installed {
+			ctx.Version = github.VersionPlaceholder
+		} else if ctx.Version == "" && strings.Contains(*field.value, ".Version") {
+			return binary, &interpolationError{field.key, fmt.Errorf("{{.Version}} needs a pinned version, as only assetRegex and binPath are resolved when installing")}
+		}
+
+		value, err := Interpolate(*field.value, ctx)
+		if err != nil {
+			return binary, &interpolationError{field.key, err}
+		}
+		*field.value = value
+	}
+	return binary, nil
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestInterpolate(t *testing.T) {
+	t.Setenv("BINMATE_TEST_TOOLS", "/home/tester/tools")
+	ctx := TemplateContext{OS: "linux", Arch: "arm64", Version: "1.7.1", Name: "jq"}
+
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr string
+	}{
+		{name: "plain value", value: "/usr/local/bin", want: "/usr/local/bin"},
+		{name: "environment variable", value: "${BINMATE_TEST_TOOLS}/bin", want: "/home/tester/tools/bin"},
+		{name: "template", value: "{{.Name}}-{{.OS}}-{{.Arch}}-{{.Version}}", want: "jq-linux-arm64-1.7.1"},
+		{name: "regex anchors are kept", value: "linux$|^$HOME", want: "linux$|^$HOME"},
+		{name: "unset variable", value: "${BINMATE_TEST_UNSET}/bin", wantErr: "environment variable BINMATE_TEST_UNSET is not set"},
+		{name: "unknown template field", value: "{{.Platform}}", wantErr: "invalid template"},
+		{name: "malformed template", value: "{{.OS", wantErr: "invalid template"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Interpolate(tt.value, ctx)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Interpolate failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Interpolate(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInterpolateBinary_VersionNeedsPin(t *testing.T) {
+	_, err := interpolateBinary(Binary{Id: "gh", Name: "gh", InstallPath: "/opt/gh/{{.Version}}"})
+	if err == nil || !strings.Contains(err.Error(), "installPath: {{.Version}} needs a pinned version") {
+		t.Errorf("Expected pinned version error, got %v", err)
+	}
+
+	binary, err := interpolateBinary(Binary{Id: "gh", Name: "gh", Pin: "v2.40.0", InstallPath: "/opt/gh/{{.Version}}"})
+	if err != nil || binary.InstallPath != "/opt/gh/2.40.0" {
+		t.Errorf("Expected pinned version in install path, got %q (%v)", binary.InstallPath, err)
+	}
+}
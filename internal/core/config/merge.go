@@ -1,8 +1,24 @@
 package config
 
+import "fmt"
+
 // MergeBinaryWithGlobal merges global configuration with binary-specific configuration.
-// Binary-specific settings always take precedence over global settings.
-func MergeBinaryWithGlobal(binary Binary, global GlobalConfig) Binary {
+// Binary-specific settings always take precedence over global settings, and the binary's
+// overrides for the platform binmate is running on take precedence over both. Environment
+// variables and templates in the merged installPath, assetRegex, releaseRegex and binPath
+// are then interpolated. If a value fails to interpolate, the merged binary is returned with
+// its values as written alongside the error.
+func MergeBinaryWithGlobal(binary Binary, global GlobalConfig) (Binary, error) {
+	merged := applyPlatform(applyGlobal(binary, global), Platform())
+	interpolated, err := interpolateBinary(merged)
+	if err != nil {
+		return merged, fmt.Errorf("invalid binary %s: %w", binary.Id, err)
+	}
+	return interpolated, nil
+}
+
+// applyGlobal fills in the settings of a binary left unset from the global defaults
+func applyGlobal(binary Binary, global GlobalConfig) Binary {
 	merged := binary
 
 	// Apply global install path if binary doesn't have one
@@ -20,5 +36,10 @@ func MergeBinaryWithGlobal(binary Binary, global GlobalConfig) Binary {
 		}
 	}
 
+	// Shims enabled globally apply to every binary
+	if global.Shims.Enabled {
+		merged.Shim = true
+	}
+
 	return merged
 }
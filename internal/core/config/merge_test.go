@@ -1,7 +1,11 @@
 package config
 
 import (
+	"runtime"
+	"strings"
 	"testing"
+
+	"cturner8/binmate/internal/providers/github"
 )
 
 func TestMergeBinaryWithGlobal(t *testing.T) {
@@ -159,11 +163,35 @@ func TestMergeBinaryWithGlobal(t *testing.T) {
 				Authenticated: true,
 			},
 		},
+		{
+			name: "global shims apply to binary",
+			binary: Binary{
+				Id:       "test",
+				Name:     "test",
+				Provider: "github",
+				Path:     "owner/repo",
+				Format:   ".tar.gz",
+			},
+			global: GlobalConfig{
+				Shims: ShimConfig{Enabled: true},
+			},
+			expected: Binary{
+				Id:       "test",
+				Name:     "test",
+				Provider: "github",
+				Path:     "owner/repo",
+				Format:   ".tar.gz",
+				Shim:     true,
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := MergeBinaryWithGlobal(tt.binary, tt.global)
+			result, err := MergeBinaryWithGlobal(tt.binary, tt.global)
+			if err != nil {
+				t.Fatalf("MergeBinaryWithGlobal failed: %v", err)
+			}
 
 			if result.InstallPath != tt.expected.InstallPath {
 				t.Errorf("InstallPath = %v, expected %v", result.InstallPath, tt.expected.InstallPath)
@@ -171,6 +199,95 @@ func TestMergeBinaryWithGlobal(t *testing.T) {
 			if result.Authenticated != tt.expected.Authenticated {
 				t.Errorf("Authenticated = %v, expected %v", result.Authenticated, tt.expected.Authenticated)
 			}
+			if result.Shim != tt.expected.Shim {
+				t.Errorf("Shim = %v, expected %v", result.Shim, tt.expected.Shim)
+			}
 		})
 	}
 }
+
+func TestMergeBinaryWithGlobal_Interpolation(t *testing.T) {
+	t.Setenv("BINMATE_TEST_HOME", "/home/tester")
+
+	binary := Binary{
+		Id:          "gh",
+		Name:        "gh",
+		Provider:    "github",
+		Path:        "cli/cli",
+		Format:      ".tar.gz",
+		AssetRegex:  "gh_{{.Version}}_{{.OS}}_{{.Arch}}",
+		InstallPath: "${BINMATE_TEST_HOME}/tools/{{.Name}}",
+	}
+
+	result, err := MergeBinaryWithGlobal(binary, GlobalConfig{})
+	if err != nil {
+		t.Fatalf("MergeBinaryWithGlobal failed: %v", err)
+	}
+	if result.InstallPath != "/home/tester/tools/gh" {
+		t.Errorf("InstallPath = %q, expected interpolated path", result.InstallPath)
+	}
+	want := "gh_" + github.VersionPlaceholder + "_" + runtime.GOOS + "_" + runtime.GOARCH
+	if result.AssetRegex != want {
+		t.Errorf("AssetRegex = %q, expected %q", result.AssetRegex, want)
+	}
+
+	// Pinned binaries keep the placeholder too, so the regex still matches after the pin changes
+	binary.Pin = "v2.40.0"
+	result, _ = MergeBinaryWithGlobal(binary, GlobalConfig{InstallPath: "/opt/bin"})
+	if result.AssetRegex != want {
+		t.Errorf("AssetRegex = %q, expected %q", result.AssetRegex, want)
+	}
+
+	// Values that cannot be interpolated are an error, and left as written
+	binary.InstallPath = "${BINMATE_TEST_UNSET_HOME}/bin"
+	result, err = MergeBinaryWithGlobal(binary, GlobalConfig{})
+	if err == nil || !strings.Contains(err.Error(), "BINMATE_TEST_UNSET_HOME is not set") {
+		t.Errorf("Expected an interpolation error, got %v", err)
+	}
+	if result.InstallPath != binary.InstallPath {
+		t.Errorf("InstallPath = %q, expected uninterpolated value", result.InstallPath)
+	}
+}
+
+func TestMergeBinaryWithGlobal_Platforms(t *testing.T) {
+	binary := Binary{
+		Id:         "tool",
+		Name:       "tool",
+		Provider:   "github",
+		Path:       "owner/tool",
+		Format:     ".tar.gz",
+		AssetRegex: "linux-musl",
+		Platforms: map[string]PlatformOverride{
+			Platform(): {
+				Format:     ".zip",
+				AssetRegex: "{{.OS}}-universal",
+				BinPath:    "tool_{{.Version}}/bin/tool",
+			},
+			"plan9/386": {Name: "other"},
+		},
+	}
+
+	result, err := MergeBinaryWithGlobal(binary, GlobalConfig{})
+	if err != nil {
+		t.Fatalf("MergeBinaryWithGlobal failed: %v", err)
+	}
+	if result.Format != ".zip" {
+		t.Errorf("Format = %q, expected platform override", result.Format)
+	}
+	if want := runtime.GOOS + "-universal"; result.AssetRegex != want {
+		t.Errorf("AssetRegex = %q, expected interpolated override %q", result.AssetRegex, want)
+	}
+	if want := "tool_" + github.VersionPlaceholder + "/bin/tool"; result.BinPath != want {
+		t.Errorf("BinPath = %q, expected %q", result.BinPath, want)
+	}
+	if result.Name != "tool" {
+		t.Errorf("Name = %q, expected overrides for other platforms to be ignored", result.Name)
+	}
+
+	// Binaries without an override for the platform are unchanged
+	delete(binary.Platforms, Platform())
+	result, _ = MergeBinaryWithGlobal(binary, GlobalConfig{})
+	if result.Format != ".tar.gz" || result.AssetRegex != "linux-musl" || result.BinPath != "" {
+		t.Errorf("result = %+v, expected binary settings", result)
+	}
+}
@@ -0,0 +1,304 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// node is a parsed config value with its position in the source file, used to report
+// validation errors against the line they occur on
+type node struct {
+	kind   string // "object", "array", "string", "number", "boolean" or "null"
+	value  any    // string, float64 or bool for scalars
+	raw    string // Source text of numbers, to tell integers from decimals
+	line   int
+	column int
+	start  int              // Byte offset of the value
+	end    int              // Byte offset just past the value
+	key    int              // Byte offset of the key of object fields, or the value of array items
+	keys   []string         // Object keys in file order
+	fields map[string]*node // Object values, positioned at their key
+	items  []*node          // Array items
+	flow   bool             // Object or array written inline, e.g. a YAML flow sequence or TOML inline table
+}
+
+// field returns the value of an object field, or nil if absent
+func (n *node) field(name string) *node {
+	if n == nil || n.kind != "object" {
+		return nil
+	}
+	return n.fields[name]
+}
+
+// item returns an array item, or nil if out of range
+func (n *node) item(i int) *node {
+	if n == nil || n.kind != "array" || i < 0 || i >= len(n.items) {
+		return nil
+	}
+	return n.items[i]
+}
+
+// list returns the items of an array node, or nil for other nodes
+func (n *node) list() []*node {
+	if n == nil || n.kind != "array" {
+		return nil
+	}
+	return n.items
+}
+
+// str returns the value of a string node, or an empty string for other nodes
+func (n *node) str() string {
+	if n == nil {
+		return ""
+	}
+	s, _ := n.value.(string)
+	return s
+}
+
+// positionError is a syntax error at a position in the source file
+type positionError struct {
+	line, column int
+	msg          string
+}
+
+func (e *positionError) Error() string {
+	return fmt.Sprintf("%d:%d: %s", e.line, e.column, e.msg)
+}
+
+// jsonParser parses JSON into nodes, tracking line and column positions
+type jsonParser struct {
+	data   []byte
+	pos    int
+	line   int
+	column int
+}
+
+// parseJSONNodes parses a JSON document into a node tree
+func parseJSONNodes(data []byte) (*node, error) {
+	p := &jsonParser{data: data, line: 1, column: 1}
+	p.skipSpace()
+	root, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.data) {
+		return nil, p.errorf("unexpected %q after top-level value", p.data[p.pos])
+	}
+	return root, nil
+}
+
+func (p *jsonParser) errorf(format string, args ...any) error {
+	return &positionError{line: p.line, column: p.column, msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *jsonParser) advance() {
+	if p.data[p.pos] == '\n' {
+		p.line++
+		p.column = 1
+	} else {
+		p.column++
+	}
+	p.pos++
+}
+
+func (p *jsonParser) skipSpace() {
+	for p.pos < len(p.data) {
+		switch p.data[p.pos] {
+		case ' ', '\t', '\r', '\n':
+			p.advance()
+		default:
+			return
+		}
+	}
+}
+
+func (p *jsonParser) expect(c byte) error {
+	p.skipSpace()
+	if p.pos >= len(p.data) {
+		return p.errorf("unexpected end of file, expected %q", c)
+	}
+	if p.data[p.pos] != c {
+		return p.errorf("unexpected %q, expected %q", p.data[p.pos], c)
+	}
+	p.advance()
+	return nil
+}
+
+func (p *jsonParser) parseValue() (*node, error) {
+	if p.pos >= len(p.data) {
+		return nil, p.errorf("unexpected end of file")
+	}
+
+	n := &node{line: p.line, column: p.column, start: p.pos, key: p.pos}
+	var err error
+	switch c := p.data[p.pos]; {
+	case c == '{':
+		err = p.parseObject(n)
+	case c == '[':
+		err = p.parseArray(n)
+	case c == '"':
+		n.kind = "string"
+		n.value, err = p.parseString()
+	case c == 't' || c == 'f' || c == 'n':
+		err = p.parseLiteral(n)
+	case c == '-' || (c >= '0' && c <= '9'):
+		err = p.parseNumber(n)
+	default:
+		return nil, p.errorf("unexpected %q", c)
+	}
+	n.end = p.pos
+	return n, err
+}
+
+func (p *jsonParser) parseObject(n *node) error {
+	n.kind = "object"
+	n.fields = map[string]*node{}
+	p.advance()
+
+	p.skipSpace()
+	if p.pos < len(p.data) && p.data[p.pos] == '}' {
+		p.advance()
+		return nil
+	}
+
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.data) || p.data[p.pos] != '"' {
+			return p.errorf("expected object key")
+		}
+		keyLine, keyColumn, keyStart := p.line, p.column, p.pos
+		key, err := p.parseString()
+		if err != nil {
+			return err
+		}
+		if _, exists := n.fields[key]; exists {
+			return &positionError{line: keyLine, column: keyColumn, msg: fmt.Sprintf("duplicate key %q", key)}
+		}
+
+		if err := p.expect(':'); err != nil {
+			return err
+		}
+		p.skipSpace()
+		value, err := p.parseValue()
+		if err != nil {
+			return err
+		}
+		value.line, value.column, value.key = keyLine, keyColumn, keyStart
+		n.keys = append(n.keys, key)
+		n.fields[key] = value
+
+		p.skipSpace()
+		if p.pos >= len(p.data) {
+			return p.errorf("unexpected end of file, expected ',' or '}'")
+		}
+		switch p.data[p.pos] {
+		case ',':
+			p.advance()
+		case '}':
+			p.advance()
+			return nil
+		default:
+			return p.errorf("unexpected %q, expected ',' or '}'", p.data[p.pos])
+		}
+	}
+}
+
+func (p *jsonParser) parseArray(n *node) error {
+	n.kind = "array"
+	p.advance()
+
+	p.skipSpace()
+	if p.pos < len(p.data) && p.data[p.pos] == ']' {
+		p.advance()
+		return nil
+	}
+
+	for {
+		p.skipSpace()
+		item, err := p.parseValue()
+		if err != nil {
+			return err
+		}
+		n.items = append(n.items, item)
+
+		p.skipSpace()
+		if p.pos >= len(p.data) {
+			return p.errorf("unexpected end of file, expected ',' or ']'")
+		}
+		switch p.data[p.pos] {
+		case ',':
+			p.advance()
+		case ']':
+			p.advance()
+			return nil
+		default:
+			return p.errorf("unexpected %q, expected ',' or ']'", p.data[p.pos])
+		}
+	}
+}
+
+func (p *jsonParser) parseString() (string, error) {
+	start := p.pos
+	p.advance()
+	for p.pos < len(p.data) {
+		switch p.data[p.pos] {
+		case '\\':
+			p.advance()
+			if p.pos < len(p.data) {
+				p.advance()
+			}
+		case '"':
+			p.advance()
+			var s string
+			if err := json.Unmarshal(p.data[start:p.pos], &s); err != nil {
+				return "", p.errorf("invalid string: %v", err)
+			}
+			return s, nil
+		case '\n':
+			return "", p.errorf("unterminated string")
+		default:
+			p.advance()
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+func (p *jsonParser) parseLiteral(n *node) error {
+	for _, literal := range []struct {
+		text  string
+		kind  string
+		value any
+	}{{"true", "boolean", true}, {"false", "boolean", false}, {"null", "null", nil}} {
+		end := p.pos + len(literal.text)
+		if end <= len(p.data) && string(p.data[p.pos:end]) == literal.text {
+			n.kind, n.value = literal.kind, literal.value
+			for p.pos < end {
+				p.advance()
+			}
+			return nil
+		}
+	}
+	return p.errorf("invalid literal")
+}
+
+func (p *jsonParser) parseNumber(n *node) error {
+	start := p.pos
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+		if (c >= '0' && c <= '9') || c == '-' || c == '+' || c == '.' || c == 'e' || c == 'E' {
+			p.advance()
+			continue
+		}
+		break
+	}
+
+	raw := string(p.data[start:p.pos])
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return p.errorf("invalid number %q", raw)
+	}
+	n.kind, n.value, n.raw = "number", value, raw
+	return nil
+}
@@ -0,0 +1,232 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2/unstable"
+)
+
+// tomlNodes builds a node tree from the expressions of a TOML document
+type tomlNodes struct {
+	parser *unstable.Parser
+	lines  *lineIndex
+	root   *node
+	table  *node // Table the following key/values belong to
+	array  *node // Array of tables the current table is an entry of, if any
+}
+
+// parseTOMLNodes parses a TOML document into a node tree. Tables are positioned at their
+// header and span to the comments above the next header, so they can be removed whole.
+func parseTOMLNodes(data []byte) (*node, error) {
+	t := &tomlNodes{
+		parser: &unstable.Parser{},
+		lines:  newLineIndex(data),
+		root:   &node{kind: "object", line: 1, column: 1, end: len(data), fields: map[string]*node{}},
+	}
+	t.table = t.root
+	t.parser.Reset(data)
+
+	for t.parser.NextExpression() {
+		expr := t.parser.Expression()
+		var err error
+		switch expr.Kind {
+		case unstable.Table, unstable.ArrayTable:
+			err = t.openTable(expr)
+		case unstable.KeyValue:
+			err = t.keyValue(t.table, expr)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := t.parser.Error(); err != nil {
+		var parserErr *unstable.ParserError
+		if errors.As(err, &parserErr) && parserErr.Highlight != nil {
+			shape := t.parser.Shape(t.parser.Range(parserErr.Highlight))
+			return nil, &positionError{line: shape.Start.Line, column: shape.Start.Column, msg: parserErr.Message}
+		}
+		return nil, err
+	}
+
+	t.closeTable(len(data))
+	return t.root, nil
+}
+
+// keyOffset returns the offset of the first part of a key
+func (t *tomlNodes) keyOffset(expr *unstable.Node) int {
+	keys := expr.Key()
+	keys.Next()
+	return int(keys.Node().Raw.Offset)
+}
+
+// closeTable ends the current table at the comments above the header starting at an offset
+func (t *tomlNodes) closeTable(offset int) {
+	if t.table != t.root {
+		t.table.end = commentStart(t.lines.data, offset)
+	}
+	if t.array != nil {
+		t.array.end = t.table.end
+	}
+}
+
+// openTable starts the table of a [table] or [[array.table]] header
+func (t *tomlNodes) openTable(expr *unstable.Node) error {
+	header := lineStart(t.lines.data, t.keyOffset(expr))
+	t.closeTable(header)
+
+	line, column := t.lines.position(header)
+	parts := []string{}
+	keys := expr.Key()
+	for keys.Next() {
+		parts = append(parts, string(keys.Node().Data))
+	}
+
+	parent, err := t.walk(t.root, parts[:len(parts)-1], header)
+	if err != nil {
+		return err
+	}
+	name := parts[len(parts)-1]
+	existing := parent.fields[name]
+	table := &node{kind: "object", line: line, column: column, start: header, key: header, fields: map[string]*node{}}
+
+	if expr.Kind == unstable.ArrayTable {
+		if existing == nil {
+			existing = &node{kind: "array", line: line, column: column, start: header, key: header}
+			parent.keys = append(parent.keys, name)
+			parent.fields[name] = existing
+		} else if existing.kind != "array" || existing.flow {
+			return &positionError{line: line, column: column, msg: fmt.Sprintf("key %q is already defined", strings.Join(parts, "."))}
+		}
+		existing.items = append(existing.items, table)
+		t.array = existing
+	} else {
+		t.array = nil
+		switch {
+		case existing == nil:
+			parent.keys = append(parent.keys, name)
+			parent.fields[name] = table
+		case existing.kind == "object" && !existing.flow && len(existing.keys) == 0:
+			table = existing
+		default:
+			return &positionError{line: line, column: column, msg: fmt.Sprintf("key %q is already defined", strings.Join(parts, "."))}
+		}
+	}
+
+	t.table = table
+	return nil
+}
+
+// walk returns the table at a dotted key below a table, creating implicit tables and
+// descending into the last entry of arrays of tables
+func (t *tomlNodes) walk(table *node, parts []string, offset int) (*node, error) {
+	for _, part := range parts {
+		next := table.fields[part]
+		if next == nil {
+			line, column := t.lines.position(offset)
+			next = &node{kind: "object", line: line, column: column, start: offset, key: offset, fields: map[string]*node{}}
+			table.keys = append(table.keys, part)
+			table.fields[part] = next
+		}
+		if next.kind == "array" && !next.flow && len(next.items) > 0 {
+			next = next.items[len(next.items)-1]
+		}
+		if next.kind != "object" {
+			line, column := t.lines.position(offset)
+			return nil, &positionError{line: line, column: column, msg: fmt.Sprintf("key %q is not a table", part)}
+		}
+		table = next
+	}
+	return table, nil
+}
+
+// keyValue adds a key/value expression to a table
+func (t *tomlNodes) keyValue(table *node, expr *unstable.Node) error {
+	keyStart := t.keyOffset(expr)
+	line, column := t.lines.position(keyStart)
+
+	parts := []string{}
+	keys := expr.Key()
+	for keys.Next() {
+		parts = append(parts, string(keys.Node().Data))
+	}
+	parent, err := t.walk(table, parts[:len(parts)-1], keyStart)
+	if err != nil {
+		return err
+	}
+	name := parts[len(parts)-1]
+	if _, exists := parent.fields[name]; exists {
+		return &positionError{line: line, column: column, msg: fmt.Sprintf("duplicate key %q", name)}
+	}
+
+	value, err := t.value(expr.Value(), keyStart)
+	if err != nil {
+		return err
+	}
+	value.line, value.column, value.key = line, column, keyStart
+	parent.keys = append(parent.keys, name)
+	parent.fields[name] = value
+	return nil
+}
+
+// value converts a TOML value. Containers have no source range of their own, so they are
+// positioned at fallback, the offset of their key.
+func (t *tomlNodes) value(v *unstable.Node, fallback int) (*node, error) {
+	n := &node{start: fallback, end: fallback}
+	if v.Raw.Length > 0 {
+		n.start, n.end = int(v.Raw.Offset), int(v.Raw.Offset+v.Raw.Length)
+	} else if len(v.Data) > 0 && v.Kind != unstable.String {
+		r := t.parser.Range(v.Data)
+		n.start, n.end = int(r.Offset), int(r.Offset+r.Length)
+	}
+	n.key = n.start
+	n.line, n.column = t.lines.position(n.start)
+
+	text := string(v.Data)
+	switch v.Kind {
+	case unstable.String:
+		n.kind, n.value = "string", text
+	case unstable.Bool:
+		n.kind, n.value = "boolean", text == "true"
+	case unstable.Integer:
+		value, err := strconv.ParseInt(strings.ReplaceAll(text, "_", ""), 0, 64)
+		if err != nil {
+			return nil, &positionError{line: n.line, column: n.column, msg: fmt.Sprintf("invalid integer %s", text)}
+		}
+		n.kind, n.value, n.raw = "number", float64(value), strconv.FormatInt(value, 10)
+	case unstable.Float:
+		value, err := strconv.ParseFloat(strings.ReplaceAll(text, "_", ""), 64)
+		if err != nil {
+			return nil, &positionError{line: n.line, column: n.column, msg: fmt.Sprintf("invalid float %s", text)}
+		}
+		n.kind, n.value, n.raw = "number", value, text
+	case unstable.Array:
+		n.kind, n.flow = "array", true
+		children := v.Children()
+		for children.Next() {
+			item, err := t.value(children.Node(), n.start)
+			if err != nil {
+				return nil, err
+			}
+			n.items = append(n.items, item)
+		}
+	case unstable.InlineTable:
+		n.kind, n.flow, n.fields = "object", true, map[string]*node{}
+		children := v.Children()
+		for children.Next() {
+			if err := t.keyValue(n, children.Node()); err != nil {
+				return nil, err
+			}
+		}
+		if len(n.keys) > 0 {
+			first := n.fields[n.keys[0]]
+			n.line, n.column = first.line, first.column
+		}
+	default:
+		// Dates and times are validated as strings
+		n.kind, n.value = "string", text
+	}
+	return n, nil
+}
@@ -0,0 +1,195 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// yamlErrorLine matches the line reported in yaml syntax errors, e.g. "yaml: line 3: ..."
+var yamlErrorLine = regexp.MustCompile(`^yaml: line (\d+): `)
+
+// yamlNodes converts a yaml node tree into nodes with byte offsets
+type yamlNodes struct {
+	data  []byte
+	lines *lineIndex
+}
+
+// parseYAMLNodes parses a YAML document into a node tree. An empty document is an empty object.
+func parseYAMLNodes(data []byte) (*node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		if match := yamlErrorLine.FindStringSubmatch(err.Error()); match != nil {
+			line, _ := strconv.Atoi(match[1])
+			return nil, &positionError{line: line, column: 1, msg: strings.TrimPrefix(err.Error(), match[0])}
+		}
+		return nil, errors.New(strings.TrimPrefix(err.Error(), "yaml: "))
+	}
+	if len(doc.Content) == 0 {
+		return &node{kind: "object", line: 1, column: 1, fields: map[string]*node{}}, nil
+	}
+
+	y := &yamlNodes{data: data, lines: newLineIndex(data)}
+	return y.convert(doc.Content[0], false)
+}
+
+// convert converts a yaml node, inFlow being set for the contents of flow collections
+func (y *yamlNodes) convert(n *yaml.Node, inFlow bool) (*node, error) {
+	start := y.lines.offset(n.Line, n.Column)
+	out := &node{line: n.Line, column: n.Column, start: start, end: start, key: start}
+
+	if n.Kind == yaml.AliasNode {
+		alias, err := y.convert(n.Alias, false)
+		if err != nil {
+			return nil, err
+		}
+		alias.line, alias.column, alias.start, alias.end, alias.key = out.line, out.column, out.start, out.end, out.key
+		return alias, nil
+	}
+
+	out.flow = n.Style&yaml.FlowStyle != 0
+	switch n.Kind {
+	case yaml.MappingNode:
+		out.kind = "object"
+		out.fields = map[string]*node{}
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key, value := n.Content[i], n.Content[i+1]
+			if _, exists := out.fields[key.Value]; exists {
+				return nil, &positionError{line: key.Line, column: key.Column, msg: fmt.Sprintf("duplicate key %q", key.Value)}
+			}
+			child, err := y.convert(value, inFlow || out.flow)
+			if err != nil {
+				return nil, err
+			}
+			out.end = child.end
+			child.line, child.column, child.key = key.Line, key.Column, y.lines.offset(key.Line, key.Column)
+			out.keys = append(out.keys, key.Value)
+			out.fields[key.Value] = child
+		}
+
+	case yaml.SequenceNode:
+		out.kind = "array"
+		for _, item := range n.Content {
+			child, err := y.convert(item, inFlow || out.flow)
+			if err != nil {
+				return nil, err
+			}
+			out.end = child.end
+			out.items = append(out.items, child)
+		}
+
+	case yaml.ScalarNode:
+		if err := y.scalar(n, out); err != nil {
+			return nil, err
+		}
+		out.end = y.scalarEnd(n, start, inFlow)
+	}
+
+	if out.flow {
+		out.end = y.flowEnd(start)
+	}
+	return out, nil
+}
+
+// scalar sets the kind and value of a scalar node from its resolved tag
+func (y *yamlNodes) scalar(n *yaml.Node, out *node) error {
+	out.raw = n.Value
+	switch n.ShortTag() {
+	case "!!int":
+		var value int64
+		if err := n.Decode(&value); err != nil {
+			return &positionError{line: n.Line, column: n.Column, msg: err.Error()}
+		}
+		out.kind, out.value, out.raw = "number", float64(value), strconv.FormatInt(value, 10)
+	case "!!float":
+		var value float64
+		if err := n.Decode(&value); err != nil {
+			return &positionError{line: n.Line, column: n.Column, msg: err.Error()}
+		}
+		out.kind, out.value = "number", value
+	case "!!bool":
+		var value bool
+		if err := n.Decode(&value); err != nil {
+			return &positionError{line: n.Line, column: n.Column, msg: err.Error()}
+		}
+		out.kind, out.value = "boolean", value
+	case "!!null":
+		out.kind = "null"
+	default:
+		out.kind, out.value = "string", n.Value
+	}
+	return nil
+}
+
+// scalarEnd returns the offset just past the source text of a scalar
+func (y *yamlNodes) scalarEnd(n *yaml.Node, start int, inFlow bool) int {
+	data := y.data
+	switch {
+	case n.Style&yaml.DoubleQuotedStyle != 0:
+		for i := start + 1; i < len(data); i++ {
+			switch data[i] {
+			case '\\':
+				i++
+			case '"':
+				return i + 1
+			}
+		}
+	case n.Style&yaml.SingleQuotedStyle != 0:
+		for i := start + 1; i < len(data); i++ {
+			if data[i] == '\'' {
+				if i+1 < len(data) && data[i+1] == '\'' {
+					i++
+					continue
+				}
+				return i + 1
+			}
+		}
+	case n.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0:
+		// Block scalars span the indicator line and one line per line of the value
+		end := lineEnd(data, start)
+		for i := 0; i <= strings.Count(strings.TrimSuffix(n.Value, "\n"), "\n"); i++ {
+			end = lineEnd(data, end)
+		}
+		return len(strings.TrimRight(string(data[:end]), "\r\n"))
+	}
+
+	// Plain scalars end at a comment or the end of the line, or the next flow indicator
+	end := lineEnd(data, start)
+	text := string(data[start:end])
+	if i := strings.Index(text, " #"); i >= 0 {
+		text = text[:i]
+	}
+	if i := strings.IndexAny(text, ",]}"); inFlow && i >= 0 {
+		text = text[:i]
+	}
+	return start + len(strings.TrimRight(text, " \t\r\n"))
+}
+
+// flowEnd returns the offset just past the closing bracket of a flow collection
+func (y *yamlNodes) flowEnd(start int) int {
+	depth := 0
+	var quote byte
+	for i := start; i < len(y.data); i++ {
+		c := y.data[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[' || c == '{':
+			depth++
+		case c == ']' || c == '}':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return len(y.data)
+}
@@ -0,0 +1,30 @@
+package config
+
+import "runtime"
+
+// Platform returns the platforms key of the platform binmate is running on, e.g. "linux/amd64"
+func Platform() string {
+	return runtime.GOOS + "/" + runtime.GOARCH
+}
+
+// applyPlatform replaces the settings of a binary that are overridden for a platform
+func applyPlatform(binary Binary, platform string) Binary {
+	override, exists := binary.Platforms[platform]
+	if !exists {
+		return binary
+	}
+
+	if override.Name != "" {
+		binary.Name = override.Name
+	}
+	if override.Format != "" {
+		binary.Format = override.Format
+	}
+	if override.AssetRegex != "" {
+		binary.AssetRegex = override.AssetRegex
+	}
+	if override.BinPath != "" {
+		binary.BinPath = override.BinPath
+	}
+	return binary
+}
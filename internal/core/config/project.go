@@ -0,0 +1,242 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+)
+
+// ProjectConfigFile is the name of the project-local config file, found by walking up from the working directory
+const ProjectConfigFile = "binmate.json"
+
+// ProjectConfigFiles are the names a project config file may have, in order of preference
+// when a directory has more than one
+var ProjectConfigFiles = []string{ProjectConfigFile, "binmate.yaml", "binmate.yml", "binmate.toml"}
+
+// projectKeys are the top-level keys a project config can set. Global settings and includes
+// decide where requests and credentials are sent and where files are written, so they are
+// left to the user's own config rather than any repository binmate runs in.
+var projectKeys = []string{"version", "binaries"}
+
+// projectBinaryKeys are the keys a project config can set on binaries from the user's config,
+// choosing the version of a tool the project uses
+var projectBinaryKeys = []string{"id", "version", "pin", "channel", "shim", "keepVersions"}
+
+// projectNewBinaryKeys are the further keys a project config can set on binaries it adds.
+// installPath and authenticated are left to the user's config.
+var projectNewBinaryKeys = []string{"name", "alias", "provider", "path", "format", "assetRegex", "releaseRegex", "nightlyRegex", "binPath", "platforms"}
+
+// Source is the config file a value was read from
+type Source struct {
+	File  string // Config file setting the value, or the URL or path of an included config
+	Value string // Value as set in the file
+}
+
+// Sources records where each config value was read from
+type Sources struct {
+	Files         []string          // Config files read, in increasing order of precedence
+	Includes      []Include         // Configs included by Files, layered beneath them in order
+	IncludeErrors []error           // Includes that could not be read and were skipped
+	ProjectFile   string            // Project config file found from the working directory, empty if none was read
+	Values        map[string]Source // Sources by dotted value path, e.g. "global.installPath" or "binaries.gh.pin"
+}
+
+// Paths returns the recorded value paths in sorted order
+func (s Sources) Paths() []string {
+	paths := make([]string, 0, len(s.Values))
+	for path := range s.Values {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// FindProjectConfig returns the path of the nearest project config file in dir or one of its parents
+func FindProjectConfig(dir string) (string, bool) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", false
+	}
+
+	for {
+		for _, name := range ProjectConfigFiles {
+			path := filepath.Join(dir, name)
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return path, true
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// projectBinaryKey reports whether a project config can set a binary key, on a binary from
+// the user's config if defined is set or on a binary the project adds otherwise
+func projectBinaryKey(key string, defined bool) bool {
+	return slices.Contains(projectBinaryKeys, key) || (!defined && slices.Contains(projectNewBinaryKeys, key))
+}
+
+// restrictProject clears the values a project config cannot set, returning their paths.
+// Binaries already in base are those defined by the user's config and includes.
+func restrictProject(base, project Config) (Config, []string) {
+	var ignored []string
+	value := reflect.ValueOf(&project).Elem()
+	ignored = append(ignored, clearFields(value, "", func(key string) bool { return slices.Contains(projectKeys, key) })...)
+
+	project.Binaries = append([]Binary(nil), project.Binaries...)
+	for i := range project.Binaries {
+		_, err := GetBinary(project.Binaries[i].Id, base.Binaries)
+		defined := err == nil
+		binary := reflect.ValueOf(&project.Binaries[i]).Elem()
+		ignored = append(ignored, clearFields(binary, joinPath("binaries", project.Binaries[i].Id), func(key string) bool {
+			return projectBinaryKey(key, defined)
+		})...)
+	}
+	return project, ignored
+}
+
+// RestrictProjectBinary clears the values a project config cannot set on a binary it adds,
+// such as installPath and authenticated, returning their keys
+func RestrictProjectBinary(binary Binary) (Binary, []string) {
+	value := reflect.ValueOf(&binary).Elem()
+	cleared := clearFields(value, "", func(key string) bool { return projectBinaryKey(key, false) })
+	return binary, cleared
+}
+
+// clearFields clears the set fields of a struct whose keys are not allowed, returning their paths
+func clearFields(value reflect.Value, path string, allowed func(key string) bool) []string {
+	var cleared []string
+	for i := 0; i < value.NumField(); i++ {
+		key := value.Type().Field(i).Tag.Get("mapstructure")
+		if key == "" || key == "-" || allowed(key) || value.Field(i).IsZero() {
+			continue
+		}
+		value.Field(i).SetZero()
+		cleared = append(cleared, joinPath(path, key))
+	}
+	return cleared
+}
+
+// MergeConfig layers an overriding config (e.g. a project config) over a base config.
+// Binaries are matched by id: new binaries are added and the fields set on existing ones
+// override the base entry. Other values follow MergeBinaryWithGlobal semantics, so only
+// values set in the override replace base values and unset (zero) values are inherited.
+// Each value taken from the override is recorded in sources against overrideFile.
+func MergeConfig(base, override Config, overrideFile string, sources *Sources) Config {
+	if sources != nil {
+		sources.Files = append(sources.Files, overrideFile)
+	}
+	return mergeValues(base, override, overrideFile, sources)
+}
+
+// mergeValues layers an overriding config over a base config like MergeConfig, without
+// recording the override as a config file read
+func mergeValues(base, override Config, overrideFile string, sources *Sources) Config {
+	merged := base
+	merged.Binaries = append([]Binary(nil), base.Binaries...)
+	merged.Global.Providers = make(map[string]ProviderDefaults, len(base.Global.Providers))
+	for name, defaults := range base.Global.Providers {
+		merged.Global.Providers[name] = defaults
+	}
+
+	if sources != nil && sources.Values == nil {
+		sources.Values = map[string]Source{}
+	}
+
+	overlay(reflect.ValueOf(&merged).Elem(), reflect.ValueOf(override), "", overrideFile, sources)
+	return merged
+}
+
+// overlay copies the set values of src into dst, recording their source
+func overlay(dst, src reflect.Value, path, file string, sources *Sources) {
+	switch src.Kind() {
+	case reflect.Struct:
+		for i := 0; i < src.NumField(); i++ {
+			name := src.Type().Field(i).Tag.Get("mapstructure")
+			if name == "" || name == "-" {
+				continue
+			}
+			overlay(dst.Field(i), src.Field(i), joinPath(path, name), file, sources)
+		}
+
+	case reflect.Map:
+		if src.Len() == 0 {
+			return
+		}
+		// Copy the map so merging never modifies the base config's map
+		merged := reflect.MakeMap(dst.Type())
+		iter := dst.MapRange()
+		for iter.Next() {
+			merged.SetMapIndex(iter.Key(), iter.Value())
+		}
+		dst.Set(merged)
+		for _, key := range src.MapKeys() {
+			// Map values are not addressable, so merge into a copy and store it back
+			entry := reflect.New(dst.Type().Elem()).Elem()
+			if existing := dst.MapIndex(key); existing.IsValid() {
+				entry.Set(existing)
+			}
+			overlay(entry, src.MapIndex(key), joinPath(path, key.String()), file, sources)
+			dst.SetMapIndex(key, entry)
+		}
+
+	case reflect.Slice:
+		if src.Len() == 0 {
+			return
+		}
+		if binaries, ok := src.Interface().([]Binary); ok {
+			overlayBinaries(dst, binaries, path, file, sources)
+			return
+		}
+		// Other lists (mirrors, noProxy, caCertFiles) replace the base list as a whole
+		dst.Set(src)
+		record(sources, path, file, src)
+
+	default:
+		if src.IsZero() {
+			return
+		}
+		dst.Set(src)
+		record(sources, path, file, src)
+	}
+}
+
+// overlayBinaries merges binaries by id, appending binaries missing from dst
+func overlayBinaries(dst reflect.Value, binaries []Binary, path, file string, sources *Sources) {
+	existing := dst.Interface().([]Binary)
+	for _, binary := range binaries {
+		index := -1
+		for i := range existing {
+			if existing[i].Id == binary.Id {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			existing = append(existing, Binary{})
+			index = len(existing) - 1
+		}
+		overlay(reflect.ValueOf(&existing[index]).Elem(), reflect.ValueOf(binary), joinPath(path, binary.Id), file, sources)
+	}
+	dst.Set(reflect.ValueOf(existing))
+}
+
+func record(sources *Sources, path, file string, value reflect.Value) {
+	if sources != nil {
+		sources.Values[path] = Source{File: file, Value: fmt.Sprintf("%v", value.Interface())}
+	}
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,287 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFindProjectConfig(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "a", "b")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatalf("failed to create directories: %v", err)
+	}
+
+	if _, ok := FindProjectConfig(nested); ok {
+		t.Fatal("FindProjectConfig() found a config, want none")
+	}
+
+	projectFile := filepath.Join(root, "a", ProjectConfigFile)
+	if err := os.WriteFile(projectFile, []byte(`{"version": 1}`), 0o644); err != nil {
+		t.Fatalf("failed to write project config: %v", err)
+	}
+
+	got, ok := FindProjectConfig(nested)
+	if !ok || got != projectFile {
+		t.Errorf("FindProjectConfig() = %q, %v, want %q", got, ok, projectFile)
+	}
+}
+
+func TestFindProjectConfig_Formats(t *testing.T) {
+	root := t.TempDir()
+	tomlFile := filepath.Join(root, "binmate.toml")
+	if err := os.WriteFile(tomlFile, []byte("version = 1\n"), 0o644); err != nil {
+		t.Fatalf("failed to write project config: %v", err)
+	}
+
+	got, ok := FindProjectConfig(root)
+	if !ok || got != tomlFile {
+		t.Errorf("FindProjectConfig() = %q, %v, want %q", got, ok, tomlFile)
+	}
+
+	// binmate.json is preferred when a directory has more than one
+	jsonFile := filepath.Join(root, ProjectConfigFile)
+	if err := os.WriteFile(jsonFile, []byte(`{"version": 1}`), 0o644); err != nil {
+		t.Fatalf("failed to write project config: %v", err)
+	}
+	if got, _ := FindProjectConfig(root); got != jsonFile {
+		t.Errorf("FindProjectConfig() = %q, want %q", got, jsonFile)
+	}
+}
+
+func TestMergeConfig(t *testing.T) {
+	base := Config{
+		Version: 1,
+		Global: GlobalConfig{
+			InstallPath: "/usr/local/bin",
+			Providers:   map[string]ProviderDefaults{"github": {Authenticated: true}},
+			Concurrency: 4,
+		},
+		Binaries: []Binary{
+			{Id: "gh", Name: "gh", Provider: "github", Path: "cli/cli", Format: ".tar.gz",
+				Platforms: map[string]PlatformOverride{"darwin/arm64": {Format: ".zip"}}},
+			{Id: "fzf", Name: "fzf", Provider: "github", Path: "junegunn/fzf", Format: ".tar.gz"},
+		},
+	}
+	project := Config{
+		Global: GlobalConfig{
+			InstallPath: "/repo/.bin",
+		},
+		Binaries: []Binary{
+			{Id: "gh", Pin: "v2.40.0", Platforms: map[string]PlatformOverride{
+				"darwin/arm64": {BinPath: "gh/bin/gh"},
+				"linux/arm64":  {AssetRegex: "musl"},
+			}},
+			{Id: "terraform", Name: "terraform", Provider: "github", Path: "hashicorp/terraform", Format: ".zip"},
+		},
+	}
+
+	sources := &Sources{}
+	merged := MergeConfig(Config{}, base, "/home/user/config.json", sources)
+	merged = MergeConfig(merged, project, "/repo/binmate.json", sources)
+
+	if merged.Global.InstallPath != "/repo/.bin" {
+		t.Errorf("Global.InstallPath = %q, want /repo/.bin", merged.Global.InstallPath)
+	}
+	if merged.Global.Concurrency != 4 || !merged.Global.Providers["github"].Authenticated {
+		t.Errorf("Global = %+v, want inherited concurrency and provider defaults", merged.Global)
+	}
+
+	if len(merged.Binaries) != 3 {
+		t.Fatalf("len(Binaries) = %d, want 3", len(merged.Binaries))
+	}
+	gh, _ := GetBinary("gh", merged.Binaries)
+	if gh.Pin != "v2.40.0" || gh.Path != "cli/cli" {
+		t.Errorf("gh = %+v, want project pin over user entry", gh)
+	}
+	wantPlatforms := map[string]PlatformOverride{
+		"darwin/arm64": {Format: ".zip", BinPath: "gh/bin/gh"},
+		"linux/arm64":  {AssetRegex: "musl"},
+	}
+	if !reflect.DeepEqual(gh.Platforms, wantPlatforms) {
+		t.Errorf("gh.Platforms = %+v, want %+v", gh.Platforms, wantPlatforms)
+	}
+	if _, err := GetBinary("terraform", merged.Binaries); err != nil {
+		t.Errorf("terraform missing from merged binaries: %v", err)
+	}
+
+	// The base config is left unchanged
+	if base.Binaries[0].Pin != "" || len(base.Binaries) != 2 || len(base.Binaries[0].Platforms) != 1 {
+		t.Errorf("base config was modified: %+v", base.Binaries)
+	}
+
+	wantSources := map[string]string{
+		"global.installPath":                         "/repo/binmate.json",
+		"global.concurrency":                         "/home/user/config.json",
+		"global.providers.github.authenticated":      "/home/user/config.json",
+		"binaries.gh.pin":                            "/repo/binmate.json",
+		"binaries.gh.path":                           "/home/user/config.json",
+		"binaries.terraform.path":                    "/repo/binmate.json",
+		"binaries.gh.platforms.darwin/arm64.format":  "/home/user/config.json",
+		"binaries.gh.platforms.darwin/arm64.binPath": "/repo/binmate.json",
+	}
+	for path, want := range wantSources {
+		if got := sources.Values[path].File; got != want {
+			t.Errorf("source of %s = %q, want %q", path, got, want)
+		}
+	}
+	if sources.Values["binaries.gh.pin"].Value != "v2.40.0" {
+		t.Errorf("value of binaries.gh.pin = %q, want v2.40.0", sources.Values["binaries.gh.pin"].Value)
+	}
+	if len(sources.Files) != 2 {
+		t.Errorf("Files = %v, want both config files", sources.Files)
+	}
+}
+
+func TestReadConfigWithSources_Project(t *testing.T) {
+	dir := t.TempDir()
+	userFile := filepath.Join(dir, "config.json")
+	userConfig := `{
+  "version": 1,
+  "global": {"installPath": "/usr/local/bin"},
+  "binaries": [{"id": "gh", "name": "gh", "provider": "github", "path": "cli/cli", "format": ".tar.gz"}]
+}`
+	if err := os.WriteFile(userFile, []byte(userConfig), 0o644); err != nil {
+		t.Fatalf("failed to write user config: %v", err)
+	}
+
+	project := filepath.Join(dir, "project")
+	if err := os.MkdirAll(filepath.Join(project, "src"), 0o755); err != nil {
+		t.Fatalf("failed to create project: %v", err)
+	}
+	projectFile := filepath.Join(project, ProjectConfigFile)
+	projectConfig := `{"binaries": [{"id": "gh", "version": "~2.40"}]}`
+	if err := os.WriteFile(projectFile, []byte(projectConfig), 0o644); err != nil {
+		t.Fatalf("failed to write project config: %v", err)
+	}
+
+	t.Setenv("BINMATE_CONFIG_PATH", userFile)
+	t.Chdir(filepath.Join(project, "src"))
+
+	config, sources := ReadConfigWithSources(ConfigFlags{})
+
+	if len(config.Binaries) != 1 || config.Binaries[0].Version != "~2.40" || config.Binaries[0].Path != "cli/cli" {
+		t.Errorf("Binaries = %+v, want gh with project version constraint", config.Binaries)
+	}
+	if config.Global.InstallPath != "/usr/local/bin" {
+		t.Errorf("Global.InstallPath = %q, want /usr/local/bin", config.Global.InstallPath)
+	}
+	if config.LogLevel != "silent" {
+		t.Errorf("LogLevel = %q, want default silent", config.LogLevel)
+	}
+	if got := sources.Values["binaries.gh.version"].File; got != projectFile {
+		t.Errorf("source of binaries.gh.version = %q, want %q", got, projectFile)
+	}
+	if _, ok := sources.Values["logLevel"]; ok {
+		t.Error("default logLevel should not be attributed to a config file")
+	}
+}
+
+func TestReadConfigWithSources_UserConfigNamedLikeProject(t *testing.T) {
+	dir := t.TempDir()
+	writeConfig(t, dir, "shared.json", `{"binaries": [{"id": "jq", "name": "jq", "provider": "github", "path": "jqlang/jq", "format": ".tar.gz"}]}`)
+	userFile := writeConfig(t, dir, ProjectConfigFile, `{
+  "version": 1,
+  "include": ["shared.json"],
+  "global": {"installPath": "/usr/local/bin"},
+  "binaries": [{"id": "gh", "name": "gh", "provider": "github", "path": "cli/cli", "format": ".tar.gz", "installPath": "/opt/bin"}]
+}`)
+
+	// A user config named like a project config is not restricted, wherever binmate runs
+	t.Setenv("BINMATE_CONFIG_PATH", userFile)
+	for _, cwd := range []string{dir, t.TempDir()} {
+		t.Chdir(cwd)
+		config, sources := ReadConfigWithSources(ConfigFlags{})
+
+		if config.Global.InstallPath != "/usr/local/bin" {
+			t.Errorf("Global.InstallPath = %q, want /usr/local/bin", config.Global.InstallPath)
+		}
+		if gh, _ := GetBinary("gh", config.Binaries); gh.InstallPath != "/opt/bin" {
+			t.Errorf("gh.InstallPath = %q, want /opt/bin", gh.InstallPath)
+		}
+		if _, err := GetBinary("jq", config.Binaries); err != nil {
+			t.Errorf("Expected the included jq: %v", err)
+		}
+		if sources.ProjectFile != "" {
+			t.Errorf("ProjectFile = %q, want none", sources.ProjectFile)
+		}
+	}
+}
+
+func TestReadConfigFiles_ProjectRestricted(t *testing.T) {
+	dir := t.TempDir()
+	userFile := writeConfig(t, dir, "config.json", `{
+  "version": 1,
+  "global": {"installPath": "/usr/local/bin"},
+  "binaries": [{"id": "gh", "name": "gh", "provider": "github", "path": "cli/cli", "format": ".tar.gz"}]
+}`)
+	projectFile := writeConfig(t, dir, ProjectConfigFile, `{
+  "version": 1,
+  "include": ["https://example.com/tools.json"],
+  "global": {
+    "installPath": "/tmp/bin",
+    "mirrors": [{"url": "https://mirror.example.com", "forwardAuth": true}],
+    "http": {"proxy": "http://proxy.example.com"}
+  },
+  "binaries": [
+    {"id": "gh", "pin": "v2.40.0", "path": "evil/cli", "authenticated": true},
+    {"id": "terraform", "name": "terraform", "provider": "github", "path": "hashicorp/terraform", "format": ".zip", "installPath": "/etc"}
+  ]
+}`)
+
+	config, _, err := ReadConfigFiles([]string{userFile, projectFile}, projectFile, "")
+	if err != nil {
+		t.Fatalf("ReadConfigFiles failed: %v", err)
+	}
+
+	if config.Global.InstallPath != "/usr/local/bin" || len(config.Global.Mirrors) != 0 || config.Global.HTTP.Proxy != "" {
+		t.Errorf("Global = %+v, want the user's global settings only", config.Global)
+	}
+	gh, _ := GetBinary("gh", config.Binaries)
+	if gh.Pin != "v2.40.0" || gh.Path != "cli/cli" || gh.Authenticated {
+		t.Errorf("gh = %+v, want only the project pin applied", gh)
+	}
+	terraform, err := GetBinary("terraform", config.Binaries)
+	if err != nil || terraform.Path != "hashicorp/terraform" || terraform.InstallPath != "" {
+		t.Errorf("terraform = %+v, %v, want the project binary without its installPath", terraform, err)
+	}
+}
+
+func TestValidateFiles_ProjectRestricted(t *testing.T) {
+	loadSchema(t)
+	dir := t.TempDir()
+	userFile := writeConfig(t, dir, "config.json", `{
+  "version": 1,
+  "binaries": [{"id": "gh", "name": "gh", "provider": "github", "path": "cli/cli", "format": ".tar.gz"}]
+}`)
+	projectFile := writeConfig(t, dir, ProjectConfigFile, `{
+  "version": 1,
+  "global": {"mirrors": [{"url": "https://mirror.example.com", "forwardAuth": true}]},
+  "binaries": [
+    {"id": "gh", "pin": "v2.40.0", "path": "evil/cli"},
+    {"id": "terraform", "name": "terraform", "provider": "github", "path": "hashicorp/terraform", "format": ".zip", "authenticated": true}
+  ]
+}`)
+
+	errs := ValidateFiles([]string{userFile, projectFile}, projectFile)
+	want := []struct {
+		path    string
+		line    int
+		message string
+	}{
+		{"global", 3, "global cannot be set in a project config"},
+		{"binaries[0].path", 5, "path of a binary from your own config cannot be changed"},
+		{"binaries[1].authenticated", 6, "authenticated cannot be set in a project config"},
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("Expected %d errors, got %d:\n%v", len(want), len(errs), errs)
+	}
+	for i, w := range want {
+		if errs[i].File != projectFile || errs[i].Path != w.path || errs[i].Line != w.line || !strings.Contains(errs[i].Message, w.message) {
+			t.Errorf("error %d = %v, want %s on line %d: %s", i, errs[i], w.path, w.line, w.message)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
 
 	"github.com/spf13/viper"
 )
@@ -14,6 +15,15 @@ type ConfigFlags struct {
 }
 
 func ReadConfig(flags ConfigFlags) Config {
+	config, _ := ReadConfigWithSources(flags)
+	return config
+}
+
+// ReadConfigWithSources reads the user's config file and layers the nearest project
+// config file (binmate.json, .yaml, .yml or .toml in the working directory or a parent) over it,
+// recording which file each value came from. Configs included by either file are layered
+// beneath both; includes that cannot be read are skipped and recorded in the sources.
+func ReadConfigWithSources(flags ConfigFlags) (Config, Sources) {
 	v := viper.New()
 
 	v.SetConfigName("config")
@@ -25,15 +35,6 @@ func ReadConfig(flags ConfigFlags) Config {
 		logLevel = "silent"
 	}
 
-	// set defaults
-	v.SetDefault("version", 1)
-	v.SetDefault("binaries", []Binary{})
-	v.SetDefault("logLevel", logLevel)
-	v.SetDefault("global", GlobalConfig{
-		InstallPath: "",
-		Providers:   map[string]ProviderDefaults{},
-	})
-
 	homeDir, _ := os.UserConfigDir()
 
 	if flags.ConfigPath != "" {
@@ -46,15 +47,117 @@ func ReadConfig(flags ConfigFlags) Config {
 	}
 
 	// Find and read the config file
-	v.ReadInConfig()
+	readErr := v.ReadInConfig()
 
-	var config Config
+	var userConfig Config
 
 	// extract config
-	err := v.Unmarshal(&config)
+	err := v.Unmarshal(&userConfig)
 	if err != nil {
 		log.Fatalf("unable to decode into struct, %v", err)
 	}
 
-	return config
+	var (
+		files       []string
+		configs     []Config
+		projectFile string
+	)
+	if readErr == nil {
+		files, configs = append(files, v.ConfigFileUsed()), append(configs, userConfig)
+	}
+
+	// Layer the project config over the user's config
+	if cwd, err := os.Getwd(); err == nil {
+		if found, ok := FindProjectConfig(cwd); ok && !SameFile(found, v.ConfigFileUsed()) {
+			projectConfig, err := readConfigFile(found)
+			if err != nil {
+				log.Fatalf("unable to read project config %s: %v", found, err)
+			}
+			projectFile = found
+			files, configs = append(files, projectFile), append(configs, projectConfig)
+		}
+	}
+
+	var sources Sources
+	config := layerConfigs(files, configs, projectFile, &sources)
+
+	setDefaults(&config, logLevel)
+
+	return config, sources
+}
+
+// ReadConfigFiles reads and layers config files given in increasing order of precedence
+// over the configs they include, e.g. to reload the files read by ReadConfigWithSources
+// after editing one of them. projectFile is the file among them read as the project config,
+// empty if none is. Includes that cannot be read are skipped and recorded in the sources.
+func ReadConfigFiles(files []string, projectFile string, logLevel string) (Config, Sources, error) {
+	configs := make([]Config, len(files))
+	for i, file := range files {
+		fileConfig, err := readConfigFile(file)
+		if err != nil {
+			return Config{}, Sources{}, fmt.Errorf("unable to read %s: %w", file, err)
+		}
+		configs[i] = fileConfig
+	}
+
+	var sources Sources
+	config := layerConfigs(files, configs, projectFile, &sources)
+
+	setDefaults(&config, logLevel)
+
+	return config, sources, nil
+}
+
+// setDefaults fills in the values of settings missing from every config file
+func setDefaults(config *Config, logLevel string) {
+	if config.Version == 0 {
+		config.Version = 1
+	}
+	if config.Binaries == nil {
+		config.Binaries = []Binary{}
+	}
+	if config.LogLevel == "" {
+		config.LogLevel = logLevel
+	}
+	if config.Global.Providers == nil {
+		config.Global.Providers = map[string]ProviderDefaults{}
+	}
+}
+
+// DefaultConfigPath returns the path of the user's config file used when none exists yet:
+// $BINMATE_CONFIG_PATH if set, otherwise config.json in the .binmate user config directory
+func DefaultConfigPath() (string, error) {
+	if path, ok := os.LookupEnv("BINMATE_CONFIG_PATH"); ok && path != "" {
+		return path, nil
+	}
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to find user config directory: %w", err)
+	}
+	return filepath.Join(configDir, ".binmate", "config.json"), nil
+}
+
+// readConfigFile reads a single config file
+func readConfigFile(path string) (Config, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return Config{}, err
+	}
+
+	var config Config
+	if err := v.Unmarshal(&config); err != nil {
+		return Config{}, fmt.Errorf("unable to decode into struct: %w", err)
+	}
+	return config, nil
+}
+
+// SameFile reports whether two paths refer to the same existing file
+func SameFile(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	infoA, errA := os.Stat(a)
+	infoB, errB := os.Stat(b)
+	return errA == nil && errB == nil && os.SameFile(infoA, infoB)
 }
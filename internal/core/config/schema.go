@@ -0,0 +1,216 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// schemaData is the JSON schema config files are validated against, set from the
+// schema.json embedded in the binary
+var schemaData []byte
+
+// SetSchema sets the JSON schema used to validate config files
+func SetSchema(data []byte) {
+	schemaData = data
+}
+
+// schemaValidator checks parsed config nodes against the subset of JSON schema
+// (draft-07) keywords used by schema.json
+type schemaValidator struct {
+	root map[string]any
+	// skipRequired reports whether the required properties of the object at path can be
+	// omitted, so project configs only need to set the values they override
+	skipRequired func(path string, n *node) bool
+	errors       []ValidationError
+}
+
+func newSchemaValidator(data []byte) (*schemaValidator, error) {
+	var root map[string]any
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+	return &schemaValidator{root: root}, nil
+}
+
+func (v *schemaValidator) validate(n *node) []ValidationError {
+	v.errors = nil
+	v.check(n, v.root, "")
+	return v.errors
+}
+
+func (v *schemaValidator) errorf(n *node, path, format string, args ...any) {
+	v.errors = append(v.errors, ValidationError{
+		Line:    n.line,
+		Column:  n.column,
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// resolve follows a local "#/definitions/name" reference
+func (v *schemaValidator) resolve(schema map[string]any) map[string]any {
+	ref, ok := schema["$ref"].(string)
+	if !ok {
+		return schema
+	}
+	current := any(v.root)
+	for _, part := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		object, ok := current.(map[string]any)
+		if !ok {
+			return map[string]any{}
+		}
+		current = object[part]
+	}
+	resolved, _ := current.(map[string]any)
+	return resolved
+}
+
+func (v *schemaValidator) check(n *node, schema map[string]any, path string) {
+	schema = v.resolve(schema)
+
+	if expected, ok := schema["type"].(string); ok && !matchesType(n, expected) {
+		v.errorf(n, path, "expected %s, got %s", expected, n.kind)
+		return
+	}
+
+	if values, ok := schema["enum"].([]any); ok {
+		allowed := make([]string, 0, len(values))
+		matched := false
+		for _, value := range values {
+			if value == n.value {
+				matched = true
+			}
+			allowed = append(allowed, fmt.Sprintf("%q", value))
+		}
+		if !matched {
+			v.errorf(n, path, "must be one of %s", strings.Join(allowed, ", "))
+		}
+	}
+
+	switch n.kind {
+	case "object":
+		v.checkObject(n, schema, path)
+	case "array":
+		if minItems, ok := schema["minItems"].(float64); ok && len(n.items) < int(minItems) {
+			v.errorf(n, path, "must contain at least %d item(s)", int(minItems))
+		}
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, item := range n.items {
+				v.check(item, items, fmt.Sprintf("%s[%d]", path, i))
+			}
+		}
+	case "string":
+		s := n.str()
+		if minLength, ok := schema["minLength"].(float64); ok && utf8.RuneCountInString(s) < int(minLength) {
+			v.errorf(n, path, "must be at least %d character(s) long", int(minLength))
+		}
+		if pattern, ok := schema["pattern"].(string); ok {
+			if re, err := regexp.Compile(pattern); err == nil && !re.MatchString(s) {
+				v.errorf(n, path, "%q does not match pattern %s", s, pattern)
+			}
+		}
+	case "number":
+		if minimum, ok := schema["minimum"].(float64); ok && n.value.(float64) < minimum {
+			v.errorf(n, path, "must be at least %v", minimum)
+		}
+	}
+}
+
+func (v *schemaValidator) checkObject(n *node, schema map[string]any, path string) {
+	properties, _ := schema["properties"].(map[string]any)
+
+	if required, ok := schema["required"].([]any); ok && (v.skipRequired == nil || !v.skipRequired(path, n)) {
+		for _, name := range required {
+			if n.field(name.(string)) == nil {
+				v.errorf(n, path, "missing required property %q", name)
+			}
+		}
+	}
+
+	var namePattern *regexp.Regexp
+	if names, ok := schema["propertyNames"].(map[string]any); ok {
+		if pattern, ok := names["pattern"].(string); ok {
+			namePattern, _ = regexp.Compile(pattern)
+		}
+	}
+
+	for _, key := range n.keys {
+		value := n.fields[key]
+		if namePattern != nil && !namePattern.MatchString(key) {
+			v.errorf(value, joinPath(path, key), "property name %q does not match pattern %s", key, namePattern)
+			continue
+		}
+		if propertySchema, ok := properties[key].(map[string]any); ok {
+			v.check(value, propertySchema, joinPath(path, key))
+			continue
+		}
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				v.errorf(value, joinPath(path, key), "unknown property %q%s", key, suggestProperty(key, properties))
+			}
+		case map[string]any:
+			v.check(value, additional, joinPath(path, key))
+		}
+	}
+}
+
+// matchesType reports whether a node is of a JSON schema type
+func matchesType(n *node, expected string) bool {
+	switch expected {
+	case "integer":
+		if n.kind != "number" {
+			return false
+		}
+		value := n.value.(float64)
+		return value == math.Trunc(value) && !strings.ContainsAny(n.raw, ".eE")
+	default:
+		return n.kind == expected
+	}
+}
+
+// suggestProperty returns a hint naming the closest known property to a misspelt key
+func suggestProperty(key string, properties map[string]any) string {
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	best, bestDistance := "", 3
+	for _, name := range names {
+		if distance := editDistance(strings.ToLower(key), strings.ToLower(name)); distance < bestDistance {
+			best, bestDistance = name, distance
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (did you mean %q?)", best)
+}
+
+// editDistance returns the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(b)]
+}
@@ -1,41 +1,132 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 
+	"cturner8/binmate/internal/database"
 	"cturner8/binmate/internal/database/repository"
 )
 
-// SyncToDatabase syncs config binaries to the database
-func SyncToDatabase(config Config, dbService *repository.Service) error {
-	// Convert config binaries to repository format
-	configBinaries := make([]repository.ConfigBinary, len(config.Binaries))
-	for i, b := range config.Binaries {
-		// Merge global config with binary-specific config
-		merged := MergeBinaryWithGlobal(b, config.Global)
-
-		configBinaries[i] = repository.ConfigBinary{
-			ID:            merged.Id,
-			Name:          merged.Name,
-			Alias:         merged.Alias,
-			Provider:      merged.Provider,
-			Path:          merged.Path,
-			InstallPath:   merged.InstallPath,
-			Format:        merged.Format,
-			AssetRegex:    merged.AssetRegex,
-			ReleaseRegex:  merged.ReleaseRegex,
-			Authenticated: merged.Authenticated,
+// SyncOptions controls how a config is synced to the database
+type SyncOptions struct {
+	KeepRemoved bool // Keep config binaries no longer in config instead of deleting them
+}
+
+// SyncPlan lists the changes syncing a config would make to the database
+type SyncPlan struct {
+	*repository.SyncPlan
+	Pins     map[string]PinChange                // Pins set or removed, by binary ID
+	Orphaned map[string][]*database.Installation // Installations of removed binaries, by binary ID
+
+	config Config
+}
+
+// PinChange is a pin set or removed by a sync. Versions are empty when unpinned.
+type PinChange struct {
+	Old string
+	New string
+}
+
+// Empty reports whether syncing would leave the database unchanged
+func (p *SyncPlan) Empty() bool {
+	return p.SyncPlan.Empty() && len(p.Pins) == 0
+}
+
+// PrepareSync fetches the latest copy of the configs included by sources, re-reads config
+// over them and validates it. Syncing without an included config would remove its binaries,
+// so includes that still cannot be read are an error.
+func PrepareSync(config *Config, sources *Sources) error {
+	if sources != nil && (len(sources.Includes) > 0 || len(sources.IncludeErrors) > 0) {
+		if err := RefreshIncludes(sources); err != nil {
+			return err
+		}
+		reloaded, reloadedSources, err := ReadConfigFiles(sources.Files, sources.ProjectFile, config.LogLevel)
+		if err != nil {
+			return err
+		}
+		*config, *sources = reloaded, reloadedSources
+		if len(sources.IncludeErrors) > 0 {
+			return errors.Join(sources.IncludeErrors...)
+		}
+	}
+
+	if err := ValidateSources(sources); err != nil {
+		return fmt.Errorf("invalid config:\n%w", err)
+	}
+	return nil
+}
+
+// PlanSync compares config binaries with the database without changing it
+func PlanSync(config Config, dbService *repository.Service) (*SyncPlan, error) {
+	configBinaries, err := toConfigBinaries(config)
+	if err != nil {
+		return nil, err
+	}
+	binaryPlan, err := dbService.Binaries.PlanSync(configBinaries, config.projectFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to plan sync: %w", err)
+	}
+
+	plan := &SyncPlan{
+		SyncPlan: binaryPlan,
+		Pins:     map[string]PinChange{},
+		Orphaned: map[string][]*database.Installation{},
+		config:   config,
+	}
+	for _, b := range config.Binaries {
+		change, changed, err := planPin(b, dbService)
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			plan.Pins[b.Id] = change
 		}
 	}
 
-	// Sync to database
-	if err := dbService.Binaries.SyncFromConfig(configBinaries, config.Version); err != nil {
+	// Removing a binary deletes its installation records but leaves their files behind
+	for _, binary := range binaryPlan.Remove {
+		installations, err := dbService.Installations.ListByBinary(binary.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list installations of %s: %w", binary.UserID, err)
+		}
+		if len(installations) > 0 {
+			plan.Orphaned[binary.UserID] = installations
+		}
+	}
+
+	return plan, nil
+}
+
+// ApplySync makes the changes of a sync plan
+func ApplySync(plan *SyncPlan, dbService *repository.Service, options SyncOptions) error {
+	if err := dbService.Binaries.ApplySync(plan.SyncPlan, plan.config.Version, options.KeepRemoved); err != nil {
 		return fmt.Errorf("failed to sync config to database: %w", err)
 	}
 
+	for _, b := range plan.config.Binaries {
+		if err := syncPin(b, dbService); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// SyncToDatabase syncs config binaries to the database
+func SyncToDatabase(config Config, dbService *repository.Service) error {
+	return SyncToDatabaseWithOptions(config, dbService, SyncOptions{})
+}
+
+// SyncToDatabaseWithOptions syncs config binaries to the database with options
+func SyncToDatabaseWithOptions(config Config, dbService *repository.Service, options SyncOptions) error {
+	plan, err := PlanSync(config, dbService)
+	if err != nil {
+		return err
+	}
+	return ApplySync(plan, dbService, options)
+}
+
 // SyncBinary syncs a specific binary from config to database
 func SyncBinary(binaryID string, config Config, dbService *repository.Service) error {
 	// Find the binary in config
@@ -45,27 +136,23 @@ func SyncBinary(binaryID string, config Config, dbService *repository.Service) e
 	}
 
 	// Merge global config with binary-specific config
-	merged := MergeBinaryWithGlobal(binary, config.Global)
+	merged, err := MergeBinaryWithGlobal(binary, config.Global)
+	if err != nil {
+		return err
+	}
 
 	// Convert to repository format
-	configBinary := repository.ConfigBinary{
-		ID:            merged.Id,
-		Name:          merged.Name,
-		Alias:         merged.Alias,
-		Provider:      merged.Provider,
-		Path:          merged.Path,
-		InstallPath:   merged.InstallPath,
-		Format:        merged.Format,
-		AssetRegex:    merged.AssetRegex,
-		ReleaseRegex:  merged.ReleaseRegex,
-		Authenticated: merged.Authenticated,
-	}
+	configBinary := toConfigBinary(merged)
 
 	// Sync single binary to database
 	if err := dbService.Binaries.SyncBinary(configBinary, config.Version); err != nil {
 		return fmt.Errorf("failed to sync binary to database: %w", err)
 	}
 
+	if err := syncPin(merged, dbService); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -77,3 +164,103 @@ func ReadAndSync(config Config, dbService *repository.Service) (Config, error) {
 
 	return config, nil
 }
+
+// toConfigBinaries converts the binaries of a config, merged with its global config, to the
+// repository sync format
+func toConfigBinaries(config Config) ([]repository.ConfigBinary, error) {
+	configBinaries := make([]repository.ConfigBinary, len(config.Binaries))
+	for i, b := range config.Binaries {
+		merged, err := MergeBinaryWithGlobal(b, config.Global)
+		if err != nil {
+			return nil, err
+		}
+		configBinaries[i] = toConfigBinary(merged)
+	}
+	return configBinaries, nil
+}
+
+// toConfigBinary converts a merged config binary to the repository sync format
+func toConfigBinary(binary Binary) repository.ConfigBinary {
+	return repository.ConfigBinary{
+		ID:            binary.Id,
+		Name:          binary.Name,
+		Alias:         binary.Alias,
+		Provider:      binary.Provider,
+		Path:          binary.Path,
+		InstallPath:   binary.InstallPath,
+		Format:        binary.Format,
+		AssetRegex:    binary.AssetRegex,
+		ReleaseRegex:  binary.ReleaseRegex,
+		Authenticated: binary.Authenticated,
+		Version:       binary.Version,
+		Channel:       binary.Channel,
+		NightlyRegex:  binary.NightlyRegex,
+		KeepVersions:  binary.KeepVersions,
+		Shim:          binary.Shim,
+		BinPath:       binary.BinPath,
+		ProjectFile:   binary.projectFile,
+	}
+}
+
+// planPin returns the change syncPin would make to the pin of a binary
+func planPin(binary Binary, dbService *repository.Service) (PinChange, bool, error) {
+	dbBinary, err := dbService.Binaries.GetByUserID(binary.Id)
+	if err == database.ErrNotFound {
+		return PinChange{New: binary.Pin}, binary.Pin != "", nil
+	}
+	if err != nil {
+		return PinChange{}, false, fmt.Errorf("failed to get binary %s: %w", binary.Id, err)
+	}
+
+	pin, err := dbService.Pins.Get(dbBinary.ID)
+	if err != nil && err != database.ErrNotFound {
+		return PinChange{}, false, fmt.Errorf("failed to get pin for %s: %w", binary.Id, err)
+	}
+	current := ""
+	if err == nil {
+		current = pin.Version
+	}
+
+	switch {
+	case binary.Pin != "":
+		if err == nil && pin.Source == "config" && pin.Version == binary.Pin {
+			return PinChange{}, false, nil
+		}
+		return PinChange{Old: current, New: binary.Pin}, current != binary.Pin, nil
+	case err == nil && pin.Source == "config":
+		return PinChange{Old: current}, true, nil
+	}
+	return PinChange{}, false, nil
+}
+
+// syncPin applies the pin declared in config for a binary.
+// Config pins replace manual ones, and are removed once dropped from config;
+// manual pins on binaries without a config pin are left untouched.
+func syncPin(binary Binary, dbService *repository.Service) error {
+	dbBinary, err := dbService.Binaries.GetByUserID(binary.Id)
+	if err != nil {
+		return fmt.Errorf("failed to get binary %s: %w", binary.Id, err)
+	}
+
+	pin, err := dbService.Pins.Get(dbBinary.ID)
+	if err != nil && err != database.ErrNotFound {
+		return fmt.Errorf("failed to get pin for %s: %w", binary.Id, err)
+	}
+	hasPin := err == nil
+
+	switch {
+	case binary.Pin != "":
+		if hasPin && pin.Source == "config" && pin.Version == binary.Pin {
+			return nil
+		}
+		if err := dbService.Pins.Set(dbBinary.ID, binary.Pin, "config"); err != nil {
+			return fmt.Errorf("failed to pin %s: %w", binary.Id, err)
+		}
+	case hasPin && pin.Source == "config":
+		if err := dbService.Pins.Delete(dbBinary.ID); err != nil {
+			return fmt.Errorf("failed to unpin %s: %w", binary.Id, err)
+		}
+	}
+
+	return nil
+}
@@ -2,10 +2,13 @@ package config
 
 import (
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	"cturner8/binmate/internal/database"
 	"cturner8/binmate/internal/database/repository"
+	"cturner8/binmate/internal/providers/github"
 )
 
 func TestSyncToDatabase(t *testing.T) {
@@ -233,3 +236,382 @@ func TestSyncToDatabase_SourceFieldSetCorrectly(t *testing.T) {
 		t.Errorf("Expected source='config' for config-managed binary, got '%s'", binaries[0].Source)
 	}
 }
+
+func TestSyncToDatabase_VersionConstraint(t *testing.T) {
+	tmpDir := t.TempDir()
+	db, err := database.Initialize(filepath.Join(tmpDir, "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to initialize database: %v", err)
+	}
+	defer db.Close()
+
+	dbService := repository.NewService(db)
+
+	config := Config{
+		Version: 1,
+		Binaries: []Binary{
+			{Id: "gh", Name: "gh", Provider: "github", Path: "cli/cli", Format: ".tar.gz", Version: "~2.40", Channel: "prerelease"},
+		},
+	}
+
+	for _, constraint := range []string{"~2.40", ">=2.40 <3", ""} {
+		config.Binaries[0].Version = constraint
+		if err := SyncToDatabase(config, dbService); err != nil {
+			t.Fatalf("Failed to sync to database: %v", err)
+		}
+
+		binary, err := dbService.Binaries.GetByUserID("gh")
+		if err != nil {
+			t.Fatalf("Failed to get binary: %v", err)
+		}
+
+		got := ""
+		if binary.VersionConstraint != nil {
+			got = *binary.VersionConstraint
+		}
+		if got != constraint {
+			t.Errorf("VersionConstraint = %q, want %q", got, constraint)
+		}
+		if binary.Channel == nil || *binary.Channel != "prerelease" {
+			t.Errorf("Channel = %v, want prerelease", binary.Channel)
+		}
+	}
+}
+
+func TestSyncToDatabase_KeepVersions(t *testing.T) {
+	tmpDir := t.TempDir()
+	db, err := database.Initialize(filepath.Join(tmpDir, "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to initialize database: %v", err)
+	}
+	defer db.Close()
+
+	dbService := repository.NewService(db)
+
+	config := Config{
+		Version: 1,
+		Binaries: []Binary{
+			{Id: "gh", Name: "gh", Provider: "github", Path: "cli/cli", Format: ".tar.gz", KeepVersions: 3},
+		},
+	}
+
+	for _, keep := range []int{3, 0} {
+		config.Binaries[0].KeepVersions = keep
+		if err := SyncToDatabase(config, dbService); err != nil {
+			t.Fatalf("Failed to sync to database: %v", err)
+		}
+
+		binary, err := dbService.Binaries.GetByUserID("gh")
+		if err != nil {
+			t.Fatalf("Failed to get binary: %v", err)
+		}
+
+		got := 0
+		if binary.KeepVersions != nil {
+			got = *binary.KeepVersions
+		}
+		if got != keep {
+			t.Errorf("KeepVersions = %d, want %d", got, keep)
+		}
+	}
+}
+
+func TestSyncToDatabase_PlatformOverrides(t *testing.T) {
+	tmpDir := t.TempDir()
+	db, err := database.Initialize(filepath.Join(tmpDir, "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to initialize database: %v", err)
+	}
+	defer db.Close()
+
+	dbService := repository.NewService(db)
+
+	config := Config{
+		Version: 1,
+		Binaries: []Binary{
+			{Id: "gh", Name: "gh", Provider: "github", Path: "cli/cli", Format: ".tar.gz",
+				Platforms: map[string]PlatformOverride{
+					Platform(): {Format: ".zip", BinPath: "gh/bin/gh"},
+				}},
+		},
+	}
+	if err := SyncToDatabase(config, dbService); err != nil {
+		t.Fatalf("Failed to sync to database: %v", err)
+	}
+
+	binary, err := dbService.Binaries.GetByUserID("gh")
+	if err != nil {
+		t.Fatalf("Failed to get binary: %v", err)
+	}
+	if binary.Format != ".zip" {
+		t.Errorf("Format = %q, want platform override", binary.Format)
+	}
+	if binary.BinPath == nil || *binary.BinPath != "gh/bin/gh" {
+		t.Errorf("BinPath = %v, want gh/bin/gh", binary.BinPath)
+	}
+}
+
+func TestSyncToDatabase_InterpolationErrors(t *testing.T) {
+	tmpDir := t.TempDir()
+	db, err := database.Initialize(filepath.Join(tmpDir, "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to initialize database: %v", err)
+	}
+	defer db.Close()
+
+	dbService := repository.NewService(db)
+
+	config := Config{
+		Version: 1,
+		Binaries: []Binary{
+			{Id: "gh", Name: "gh", Provider: "github", Path: "cli/cli", Format: ".tar.gz",
+				InstallPath: "${BINMATE_TEST_UNSET_HOME}/bin"},
+		},
+	}
+	if err := SyncToDatabase(config, dbService); err == nil || !strings.Contains(err.Error(), "invalid binary gh") {
+		t.Errorf("Expected an interpolation error, got %v", err)
+	}
+	if err := SyncBinary("gh", config, dbService); err == nil || !strings.Contains(err.Error(), "invalid binary gh") {
+		t.Errorf("Expected an interpolation error, got %v", err)
+	}
+	if _, err := dbService.Binaries.GetByUserID("gh"); err != database.ErrNotFound {
+		t.Errorf("Expected gh not to be synced, got err = %v", err)
+	}
+}
+
+func TestSyncToDatabase_PinnedVersionPlaceholder(t *testing.T) {
+	tmpDir := t.TempDir()
+	db, err := database.Initialize(filepath.Join(tmpDir, "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to initialize database: %v", err)
+	}
+	defer db.Close()
+
+	dbService := repository.NewService(db)
+
+	config := Config{
+		Version: 1,
+		Binaries: []Binary{
+			{Id: "gh", Name: "gh", Provider: "github", Path: "cli/cli", Format: ".tar.gz", Pin: "v2.40.0",
+				AssetRegex: "gh_{{.Version}}_linux_amd64", BinPath: "gh_{{.Version}}/bin/gh"},
+		},
+	}
+	if err := SyncToDatabase(config, dbService); err != nil {
+		t.Fatalf("Failed to sync to database: %v", err)
+	}
+
+	// The placeholder is resolved when installing, so the stored values survive pin changes
+	binary, err := dbService.Binaries.GetByUserID("gh")
+	if err != nil {
+		t.Fatalf("Failed to get binary: %v", err)
+	}
+	if binary.AssetRegex == nil || !strings.Contains(*binary.AssetRegex, github.VersionPlaceholder) {
+		t.Errorf("AssetRegex = %v, want the version placeholder", binary.AssetRegex)
+	}
+	if binary.BinPath == nil || !strings.Contains(*binary.BinPath, github.VersionPlaceholder) {
+		t.Errorf("BinPath = %v, want the version placeholder", binary.BinPath)
+	}
+}
+
+func TestSyncToDatabase_Pins(t *testing.T) {
+	tmpDir := t.TempDir()
+	db, err := database.Initialize(filepath.Join(tmpDir, "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to initialize database: %v", err)
+	}
+	defer db.Close()
+
+	dbService := repository.NewService(db)
+
+	config := Config{
+		Version: 1,
+		Binaries: []Binary{
+			{Id: "gh", Name: "gh", Provider: "github", Path: "cli/cli", Format: ".tar.gz", Pin: "v2.40.0"},
+			{Id: "fzf", Name: "fzf", Provider: "github", Path: "junegunn/fzf", Format: ".tar.gz"},
+		},
+	}
+	if err := SyncToDatabase(config, dbService); err != nil {
+		t.Fatalf("Failed to sync to database: %v", err)
+	}
+
+	gh, _ := dbService.Binaries.GetByUserID("gh")
+	pin, err := dbService.Pins.Get(gh.ID)
+	if err != nil {
+		t.Fatalf("Expected config pin for gh: %v", err)
+	}
+	if pin.Version != "v2.40.0" || pin.Source != "config" {
+		t.Errorf("pin = %+v, want config pin at v2.40.0", pin)
+	}
+
+	// Manual pins survive syncs that do not declare a pin
+	fzf, _ := dbService.Binaries.GetByUserID("fzf")
+	if err := dbService.Pins.Set(fzf.ID, "0.50.0", "manual"); err != nil {
+		t.Fatalf("Failed to pin fzf: %v", err)
+	}
+
+	config.Binaries[0].Pin = ""
+	if err := SyncToDatabase(config, dbService); err != nil {
+		t.Fatalf("Failed to sync to database: %v", err)
+	}
+
+	if _, err := dbService.Pins.Get(gh.ID); err != database.ErrNotFound {
+		t.Errorf("Expected config pin to be removed, got err = %v", err)
+	}
+	if _, err := dbService.Pins.Get(fzf.ID); err != nil {
+		t.Errorf("Expected manual pin to be kept: %v", err)
+	}
+}
+
+func TestPlanSync(t *testing.T) {
+	tmpDir := t.TempDir()
+	db, err := database.Initialize(filepath.Join(tmpDir, "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to initialize database: %v", err)
+	}
+	defer db.Close()
+
+	dbService := repository.NewService(db)
+
+	config := Config{
+		Version: 1,
+		Binaries: []Binary{
+			{Id: "gh", Name: "gh", Provider: "github", Path: "cli/cli", Format: ".tar.gz"},
+			{Id: "jq", Name: "jq", Provider: "github", Path: "jqlang/jq", Format: ".tar.gz"},
+			{Id: "fzf", Name: "fzf", Provider: "github", Path: "junegunn/fzf", Format: ".tar.gz"},
+		},
+	}
+	if err := SyncToDatabase(config, dbService); err != nil {
+		t.Fatalf("Failed to sync to database: %v", err)
+	}
+
+	jq, _ := dbService.Binaries.GetByUserID("jq")
+	installation := &database.Installation{BinaryID: jq.ID, Version: "jq-1.7.1", InstalledPath: "/tmp/jq", SourceURL: "https://example.com/jq"}
+	if err := dbService.Installations.Create(installation); err != nil {
+		t.Fatalf("Failed to create installation: %v", err)
+	}
+
+	config.Binaries = []Binary{
+		{Id: "gh", Name: "gh", Provider: "github", Path: "cli/cli", Format: ".zip", Pin: "v2.40.0"},
+		{Id: "fzf", Name: "fzf", Provider: "github", Path: "junegunn/fzf", Format: ".tar.gz"},
+		{Id: "rg", Name: "rg", Provider: "github", Path: "BurntSushi/ripgrep", Format: ".tar.gz"},
+	}
+
+	plan, err := PlanSync(config, dbService)
+	if err != nil {
+		t.Fatalf("PlanSync failed: %v", err)
+	}
+
+	if len(plan.Create) != 1 || plan.Create[0].ID != "rg" {
+		t.Errorf("Create = %+v, expected rg", plan.Create)
+	}
+	if len(plan.Update) != 1 || plan.Update[0].Config.ID != "gh" {
+		t.Fatalf("Update = %+v, expected gh", plan.Update)
+	}
+	want := []repository.FieldChange{{Field: "format", Old: `".tar.gz"`, New: `".zip"`}}
+	if !reflect.DeepEqual(plan.Update[0].Changes, want) {
+		t.Errorf("gh changes = %+v, expected %+v", plan.Update[0].Changes, want)
+	}
+	if pin := plan.Pins["gh"]; pin != (PinChange{New: "v2.40.0"}) {
+		t.Errorf("gh pin = %+v, expected v2.40.0", pin)
+	}
+	if len(plan.Remove) != 1 || plan.Remove[0].UserID != "jq" {
+		t.Errorf("Remove = %+v, expected jq", plan.Remove)
+	}
+	if orphaned := plan.Orphaned["jq"]; len(orphaned) != 1 || orphaned[0].Version != "jq-1.7.1" {
+		t.Errorf("Orphaned = %+v, expected the jq installation", plan.Orphaned)
+	}
+	if len(plan.Unchanged) != 1 || plan.Unchanged[0] != "fzf" {
+		t.Errorf("Unchanged = %v, expected fzf", plan.Unchanged)
+	}
+
+	// Planning leaves the database alone
+	if binaries, _ := dbService.Binaries.List(); len(binaries) != 3 {
+		t.Errorf("Expected 3 binaries after planning, got %d", len(binaries))
+	}
+
+	// Removed binaries are kept when pruning is off
+	if err := ApplySync(plan, dbService, SyncOptions{KeepRemoved: true}); err != nil {
+		t.Fatalf("ApplySync failed: %v", err)
+	}
+	if _, err := dbService.Binaries.GetByUserID("jq"); err != nil {
+		t.Errorf("Expected jq to be kept: %v", err)
+	}
+	gh, _ := dbService.Binaries.GetByUserID("gh")
+	if gh.Format != ".zip" {
+		t.Errorf("gh format = %q, expected the planned update", gh.Format)
+	}
+	if pin, err := dbService.Pins.Get(gh.ID); err != nil || pin.Version != "v2.40.0" {
+		t.Errorf("Expected gh pinned to v2.40.0, got %+v, %v", pin, err)
+	}
+
+	plan, err = PlanSync(config, dbService)
+	if err != nil {
+		t.Fatalf("PlanSync failed: %v", err)
+	}
+	if len(plan.Create) != 0 || len(plan.Update) != 0 || len(plan.Pins) != 0 || len(plan.Remove) != 1 {
+		t.Errorf("Expected only the kept jq to remain in the plan, got %+v", plan)
+	}
+
+	if err := ApplySync(plan, dbService, SyncOptions{}); err != nil {
+		t.Fatalf("ApplySync failed: %v", err)
+	}
+	if _, err := dbService.Binaries.GetByUserID("jq"); err != database.ErrNotFound {
+		t.Errorf("Expected jq to be removed, got err = %v", err)
+	}
+}
+
+func TestSyncToDatabase_ProjectBinaries(t *testing.T) {
+	db, err := database.Initialize(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to initialize database: %v", err)
+	}
+	defer db.Close()
+
+	dbService := repository.NewService(db)
+
+	dir := t.TempDir()
+	userFile := writeConfig(t, dir, "config.json", `{
+  "version": 1,
+  "binaries": [{"id": "gh", "name": "gh", "provider": "github", "path": "cli/cli", "format": ".tar.gz"}]
+}`)
+	projectFile := writeConfig(t, dir, ProjectConfigFile, `{
+  "binaries": [
+    {"id": "gh", "pin": "v2.40.0"},
+    {"id": "terraform", "name": "terraform", "provider": "github", "path": "hashicorp/terraform", "format": ".zip"}
+  ]
+}`)
+
+	sync := func(files ...string) {
+		t.Helper()
+		config, _, err := ReadConfigFiles(files, projectFile, "")
+		if err != nil {
+			t.Fatalf("ReadConfigFiles failed: %v", err)
+		}
+		if err := SyncToDatabase(config, dbService); err != nil {
+			t.Fatalf("Failed to sync to database: %v", err)
+		}
+	}
+
+	sync(userFile, projectFile)
+	gh, _ := dbService.Binaries.GetByUserID("gh")
+	terraform, err := dbService.Binaries.GetByUserID("terraform")
+	if err != nil {
+		t.Fatalf("Expected terraform to be synced: %v", err)
+	}
+	if gh.Source != "config" || terraform.Source != "project" || terraform.ConfigFile == nil || *terraform.ConfigFile != projectFile {
+		t.Errorf("gh source = %s, terraform source = %s (%v), want config and project", gh.Source, terraform.Source, terraform.ConfigFile)
+	}
+
+	// Syncing outside the project keeps its binaries
+	sync(userFile)
+	if _, err := dbService.Binaries.GetByUserID("terraform"); err != nil {
+		t.Errorf("Expected terraform to be kept outside the project: %v", err)
+	}
+
+	// Removing a binary from the project config removes it
+	writeConfig(t, dir, ProjectConfigFile, `{"binaries": [{"id": "gh", "pin": "v2.40.0"}]}`)
+	sync(userFile, projectFile)
+	if _, err := dbService.Binaries.GetByUserID("terraform"); err != database.ErrNotFound {
+		t.Errorf("Expected terraform to be removed, got err = %v", err)
+	}
+}
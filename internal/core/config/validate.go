@@ -0,0 +1,503 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"cturner8/binmate/internal/core/semver"
+	"cturner8/binmate/internal/providers/github"
+)
+
+// ValidationError is a problem found in a config file
+type ValidationError struct {
+	File    string
+	Line    int    // 1-based line of the offending value, 0 if unknown
+	Column  int    // 1-based column of the offending value, 0 if unknown
+	Path    string // Dotted path of the offending value, e.g. "binaries[0].assetRegex"
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	location := e.File
+	if e.Line > 0 {
+		location = fmt.Sprintf("%s:%d:%d", location, e.Line, e.Column)
+	}
+	if e.Path != "" {
+		return fmt.Sprintf("%s: %s: %s", location, e.Path, e.Message)
+	}
+	return fmt.Sprintf("%s: %s", location, e.Message)
+}
+
+// ValidationErrors lists every problem found in a set of config files
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	lines := make([]string, len(e))
+	for i, err := range e {
+		lines[i] = err.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// parsedFile is a config file parsed for validation
+type parsedFile struct {
+	path string
+	root *node
+}
+
+// binary returns the node of the binary with an id in the file, or nil if it is not defined there
+func (f parsedFile) binary(id string) *node {
+	if f.root == nil {
+		return nil
+	}
+	for _, binary := range f.root.field("binaries").list() {
+		if binary.field("id").str() == id {
+			return binary
+		}
+	}
+	return nil
+}
+
+// ValidateSources validates the config files read into sources, returning ValidationErrors
+// if any problems are found
+func ValidateSources(sources *Sources) error {
+	if sources == nil {
+		return nil
+	}
+	if errs := ValidateFiles(sources.Files, sources.ProjectFile); len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// layer is a config file validated in increasing order of precedence
+type layer struct {
+	file     string // File read
+	name     string // Name problems are reported against: the file, or the source it is included from
+	included bool
+}
+
+// ValidateFiles validates config files layered in increasing order of precedence over
+// the configs they include. Each file is checked against the JSON schema and for invalid
+// regexes, version constraints and duplicate binary ids. Files after the first may omit
+// the required properties of values they override. The merged config is then checked for
+// binaries installing commands with the same name into the same install path. projectFile,
+// if one of files, is also checked for values a project config cannot set.
+func ValidateFiles(files []string, projectFile string) ValidationErrors {
+	var (
+		parsed []parsedFile
+		merged Config
+		known  = map[string]bool{}
+	)
+	layers, errs := includeLayers(files, projectFile)
+
+	var validator *schemaValidator
+	if len(schemaData) > 0 {
+		var err error
+		if validator, err = newSchemaValidator(schemaData); err != nil {
+			return ValidationErrors{{Message: err.Error()}}
+		}
+	}
+
+	for i, layer := range layers {
+		file := layer.name
+		root, err := parseConfigNodes(layer.file)
+		if err != nil {
+			var posErr *positionError
+			if errors.As(err, &posErr) {
+				errs = append(errs, ValidationError{File: file, Line: posErr.line, Column: posErr.column, Message: posErr.msg})
+			} else {
+				errs = append(errs, ValidationError{File: file, Message: err.Error()})
+			}
+			continue
+		}
+		parsed = append(parsed, parsedFile{path: file, root: root})
+
+		var fileErrs []ValidationError
+		if root != nil {
+			if validator != nil {
+				validator.skipRequired = nil
+				if i > 0 {
+					validator.skipRequired = func(path string, n *node) bool {
+						return path == "" || (isBinaryPath(path) && known[n.field("id").str()])
+					}
+				}
+				fileErrs = append(fileErrs, validator.validate(root)...)
+			}
+			fileErrs = append(fileErrs, checkBinaryNodes(root)...)
+			if !layer.included && layer.file == projectFile {
+				fileErrs = append(fileErrs, checkProjectNodes(root, known)...)
+			}
+			if include := root.field("include"); include != nil && layer.included {
+				fileErrs = append(fileErrs, ValidationError{
+					Line:    include.line,
+					Column:  include.column,
+					Path:    "include",
+					Message: "included configs cannot include other configs",
+				})
+			}
+		}
+		for _, err := range fileErrs {
+			err.File = file
+			errs = append(errs, err)
+		}
+
+		config, err := readConfigFile(layer.file)
+		if err != nil {
+			if root != nil && validator != nil {
+				// Type errors are already reported against their line by the schema
+				continue
+			}
+			errs = append(errs, ValidationError{File: file, Message: err.Error()})
+			continue
+		}
+		if !layer.included && layer.file == projectFile {
+			config, _ = restrictProject(merged, config)
+		}
+		merged = MergeConfig(merged, config, file, nil)
+		for _, binary := range config.Binaries {
+			known[binary.Id] = true
+		}
+	}
+
+	errs = append(errs, checkInterpolation(merged, parsed)...)
+	return append(errs, checkCommandCollisions(merged, parsed)...)
+}
+
+// includeLayers returns the configs included by files followed by the files themselves,
+// reporting includes that cannot be read at their entry in the including file. Includes of
+// the project config are reported by checkProjectNodes and not read.
+func includeLayers(files []string, projectFile string) ([]layer, ValidationErrors) {
+	var (
+		errs     ValidationErrors
+		included []layer
+		seen     = map[string]bool{}
+		configs  = make([]Config, len(files))
+		readable = make([]bool, len(files))
+	)
+	for i, file := range files {
+		// Files that cannot be read are reported when they are validated
+		config, err := readConfigFile(file)
+		configs[i], readable[i] = config, err == nil
+	}
+	client, clientErr := localIncludeClient(files, configs, projectFile)
+
+	for j, file := range files {
+		if !readable[j] || file == projectFile {
+			continue
+		}
+		root, _ := parseConfigNodes(file)
+
+		for i, entry := range configs[j].Include {
+			include, err := parseInclude(entry, file)
+			if err == nil && include.Remote() {
+				include.client, err = client, clientErr
+			}
+			if err == nil {
+				err = include.load(false)
+			}
+			if err != nil {
+				verr := ValidationError{File: file, Path: fmt.Sprintf("include[%d]", i), Message: fmt.Sprintf("unable to include %s: %v", entry, err)}
+				if item := root.field("include").item(i); item != nil {
+					verr.Line, verr.Column = item.line, item.column
+				}
+				errs = append(errs, verr)
+				continue
+			}
+			if !seen[include.File] {
+				seen[include.File] = true
+				included = append(included, layer{file: include.File, name: include.Source, included: true})
+			}
+		}
+	}
+
+	layers := included
+	for _, file := range files {
+		layers = append(layers, layer{file: file, name: file})
+	}
+	return layers, errs
+}
+
+// parseConfigNodes parses a config file into nodes. Files of an unknown format return a
+// nil node and are validated through their decoded values.
+func parseConfigNodes(file string) (*node, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	format := FormatOf(file)
+	if format == "" {
+		return nil, nil
+	}
+	return parseNodes(format, data)
+}
+
+// isBinaryPath reports whether a path is an entry of the binaries list, e.g. "binaries[2]"
+func isBinaryPath(path string) bool {
+	return strings.HasPrefix(path, "binaries[") && strings.HasSuffix(path, "]") && !strings.Contains(path, ".")
+}
+
+// checkProjectNodes reports the values a project config cannot set, which are ignored when
+// it is read. Binaries in known are defined by the user's config and includes.
+func checkProjectNodes(root *node, known map[string]bool) []ValidationError {
+	var errs []ValidationError
+	configKeys, binaryKeys := fieldKeys(reflect.TypeOf(Config{})), fieldKeys(reflect.TypeOf(Binary{}))
+
+	for _, key := range root.keys {
+		if slices.Contains(projectKeys, key) || !slices.Contains(configKeys, key) {
+			continue
+		}
+		value := root.fields[key]
+		errs = append(errs, ValidationError{
+			Line:    value.line,
+			Column:  value.column,
+			Path:    key,
+			Message: fmt.Sprintf("%s cannot be set in a project config, only in your own config", key),
+		})
+	}
+
+	for i, binary := range root.field("binaries").list() {
+		defined := known[binary.field("id").str()]
+		for _, key := range binary.keys {
+			if projectBinaryKey(key, defined) || !slices.Contains(binaryKeys, key) {
+				continue
+			}
+			message := fmt.Sprintf("%s cannot be set in a project config, only in your own config", key)
+			if defined && slices.Contains(projectNewBinaryKeys, key) {
+				message = fmt.Sprintf("%s of a binary from your own config cannot be changed by a project config", key)
+			}
+			value := binary.fields[key]
+			errs = append(errs, ValidationError{
+				Line:    value.line,
+				Column:  value.column,
+				Path:    fmt.Sprintf("binaries[%d].%s", i, key),
+				Message: message,
+			})
+		}
+	}
+	return errs
+}
+
+// fieldKeys returns the config keys of a struct type
+func fieldKeys(t reflect.Type) []string {
+	keys := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		if key := t.Field(i).Tag.Get("mapstructure"); t.Field(i).IsExported() && key != "" && key != "-" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// checkBinaryNodes checks the binaries of a parsed file for invalid regexes and
+// version constraints and for duplicate ids
+func checkBinaryNodes(root *node) []ValidationError {
+	var errs []ValidationError
+	ids := map[string]*node{}
+
+	for i, binary := range root.field("binaries").list() {
+		path := fmt.Sprintf("binaries[%d]", i)
+
+		if id := binary.field("id"); id != nil && id.kind == "string" {
+			if first, exists := ids[id.str()]; exists {
+				errs = append(errs, ValidationError{
+					Line:    id.line,
+					Column:  id.column,
+					Path:    path + ".id",
+					Message: fmt.Sprintf("duplicate binary id %q, first defined on line %d", id.str(), first.line),
+				})
+			} else {
+				ids[id.str()] = id
+			}
+		}
+
+		type regex struct {
+			path  string
+			value *node
+		}
+		var regexes []regex
+		for _, field := range []string{"assetRegex", "releaseRegex", "nightlyRegex"} {
+			regexes = append(regexes, regex{path + "." + field, binary.field(field)})
+		}
+		if platforms := binary.field("platforms"); platforms != nil && platforms.kind == "object" {
+			for _, platform := range platforms.keys {
+				regexes = append(regexes, regex{path + ".platforms." + platform + ".assetRegex", platforms.fields[platform].field("assetRegex")})
+			}
+		}
+		for _, regex := range regexes {
+			value := regex.value
+			if value == nil || value.kind != "string" {
+				continue
+			}
+			if _, err := regexp.Compile(value.str()); err != nil {
+				errs = append(errs, ValidationError{
+					Line:    value.line,
+					Column:  value.column,
+					Path:    regex.path,
+					Message: fmt.Sprintf("invalid regex: %v", err),
+				})
+			}
+		}
+
+		if value := binary.field("version"); value != nil && value.kind == "string" && value.str() != "" {
+			if _, err := semver.ParseConstraint(value.str()); err != nil {
+				errs = append(errs, ValidationError{
+					Line:    value.line,
+					Column:  value.column,
+					Path:    path + ".version",
+					Message: err.Error(),
+				})
+			}
+		}
+	}
+
+	return errs
+}
+
+// checkInterpolation reports config values with environment variables or templates that
+// cannot be interpolated, and regexes that are only invalid once interpolated
+func checkInterpolation(merged Config, parsed []parsedFile) []ValidationError {
+	var errs []ValidationError
+
+	for _, binary := range merged.Binaries {
+		withGlobal := applyPlatform(applyGlobal(binary, merged.Global), Platform())
+		interpolated, err := interpolateBinary(withGlobal)
+		var interpErr *interpolationError
+		if errors.As(err, &interpErr) {
+			verr := ValidationError{Message: interpErr.err.Error()}
+			locateField(&verr, binary.Id, interpErr.field, parsed)
+			errs = append(errs, verr)
+			continue
+		}
+
+		regexes := []struct{ key, raw, value string }{
+			{"assetRegex", withGlobal.AssetRegex, interpolated.AssetRegex},
+			{"releaseRegex", withGlobal.ReleaseRegex, interpolated.ReleaseRegex},
+		}
+		for _, regex := range regexes {
+			if regex.value == regex.raw {
+				// Regexes without templates are already checked against their file
+				continue
+			}
+			if _, err := regexp.Compile(github.ExpandVersion(regex.value, "0")); err != nil {
+				verr := ValidationError{Message: fmt.Sprintf("invalid regex once interpolated: %v", err)}
+				locateField(&verr, binary.Id, regex.key, parsed)
+				errs = append(errs, verr)
+			}
+		}
+	}
+
+	if _, err := GitHubClientSettings(merged.Global); err != nil {
+		verr := ValidationError{Message: err.Error()}
+		for i := len(parsed) - 1; i >= 0; i-- {
+			if global := parsed[i].root.field("global"); global != nil {
+				verr.File, verr.Line, verr.Column, verr.Path = parsed[i].path, global.line, global.column, "global"
+				break
+			}
+		}
+		errs = append(errs, verr)
+	}
+	return errs
+}
+
+// checkCommandCollisions reports binaries installing a command with the same name
+// (alias, or name if unset) into the same install path, where one would overwrite the other
+func checkCommandCollisions(merged Config, parsed []parsedFile) []ValidationError {
+	var errs []ValidationError
+	installed := map[string]Binary{}
+
+	for _, binary := range merged.Binaries {
+		// Values that fail to interpolate are reported by checkInterpolation
+		effective, _ := MergeBinaryWithGlobal(binary, merged.Global)
+		command := effective.Name
+		if effective.Alias != "" {
+			command = effective.Alias
+		}
+		if command == "" {
+			continue
+		}
+
+		key := installDir(effective.InstallPath) + "\x00" + command
+		other, exists := installed[key]
+		if !exists {
+			installed[key] = binary
+			continue
+		}
+
+		installPath := effective.InstallPath
+		if installPath == "" {
+			installPath = "the default install path"
+		}
+		err := ValidationError{
+			Message: fmt.Sprintf("binaries %q and %q both install %q into %s; set an alias or installPath on one of them",
+				other.Id, binary.Id, command, installPath),
+		}
+		locateBinary(&err, binary.Id, parsed)
+		errs = append(errs, err)
+	}
+
+	return errs
+}
+
+// installDir returns the directory an install path refers to, so spellings of one directory
+// such as "~/.local/bin" and "${HOME}/.local/bin/" compare equal. Environment variables are
+// already interpolated by MergeBinaryWithGlobal.
+func installDir(path string) string {
+	if path == "" {
+		return ""
+	}
+	path = expandHome(path)
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return filepath.Clean(path)
+}
+
+// locateField points an error at the last file setting a field of a binary, preferring the
+// override for the current platform, or setting the global default of the field
+func locateField(err *ValidationError, id, field string, parsed []parsedFile) {
+	for _, prefix := range []string{"platforms." + Platform() + ".", ""} {
+		for i := len(parsed) - 1; i >= 0; i-- {
+			for j, binary := range parsed[i].root.field("binaries").list() {
+				value := binary.field(field)
+				if prefix != "" {
+					value = binary.field("platforms").field(Platform()).field(field)
+				}
+				if value != nil && binary.field("id").str() == id {
+					err.File, err.Line, err.Column = parsed[i].path, value.line, value.column
+					err.Path = fmt.Sprintf("binaries[%d].%s%s", j, prefix, field)
+					return
+				}
+			}
+		}
+	}
+	for i := len(parsed) - 1; i >= 0; i-- {
+		if value := parsed[i].root.field("global").field(field); value != nil {
+			err.File, err.Line, err.Column = parsed[i].path, value.line, value.column
+			err.Path = "global." + field
+			err.Message = fmt.Sprintf("%s (for binary %q)", err.Message, id)
+			return
+		}
+	}
+	locateBinary(err, id, parsed)
+}
+
+// locateBinary points an error at the last file defining a binary
+func locateBinary(err *ValidationError, id string, parsed []parsedFile) {
+	for i := len(parsed) - 1; i >= 0; i-- {
+		if binary := parsed[i].binary(id); binary != nil {
+			err.File = parsed[i].path
+			err.Line, err.Column = binary.line, binary.column
+			return
+		}
+	}
+	if len(parsed) > 0 {
+		err.File = parsed[len(parsed)-1].path
+	}
+}
@@ -0,0 +1,572 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func loadSchema(t *testing.T) {
+	t.Helper()
+	data, err := os.ReadFile("../../../schema.json")
+	if err != nil {
+		t.Fatalf("Failed to read schema: %v", err)
+	}
+	SetSchema(data)
+	t.Cleanup(func() { SetSchema(nil) })
+}
+
+func writeConfig(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	return path
+}
+
+func TestValidateFiles_Valid(t *testing.T) {
+	loadSchema(t)
+	path := writeConfig(t, t.TempDir(), "config.json", `{
+  "$schema": "https://example.com/schema.json",
+  "version": 1,
+  "dateFormat": "02/01/2006",
+  "global": {"installPath": "/usr/local/bin", "providers": {"github": {"authenticated": true}}},
+  "binaries": [
+    {"id": "gh", "name": "gh", "provider": "github", "path": "cli/cli", "format": ".tar.gz", "assetRegex": "linux_amd64", "version": "^2"},
+    {"id": "fzf", "name": "fzf", "alias": "finder", "provider": "github", "path": "junegunn/fzf", "format": ".zip"}
+  ]
+}`)
+
+	if errs := ValidateFiles([]string{path}, ""); len(errs) > 0 {
+		t.Errorf("Expected no errors, got:\n%v", errs)
+	}
+}
+
+func TestValidateFiles_Errors(t *testing.T) {
+	loadSchema(t)
+
+	tests := []struct {
+		name    string
+		content string
+		line    int
+		path    string
+		message string
+	}{
+		{
+			name: "unknown property",
+			content: `{
+  "version": 1,
+  "binaries": [
+    {"id": "gh", "name": "gh", "provider": "github", "path": "cli/cli", "format": ".tar.gz",
+     "asetRegex": "linux"}
+  ]
+}`,
+			line:    5,
+			path:    "binaries[0].asetRegex",
+			message: `did you mean "assetRegex"?`,
+		},
+		{
+			name: "wrong type",
+			content: `{
+  "version": "1",
+  "binaries": []
+}`,
+			line:    2,
+			path:    "version",
+			message: "expected integer, got string",
+		},
+		{
+			name: "invalid enum value",
+			content: `{
+  "version": 1,
+  "binaries": [
+    {"id": "gh", "name": "gh", "provider": "gitlab", "path": "cli/cli", "format": ".tar.gz"}
+  ]
+}`,
+			line:    4,
+			path:    "binaries[0].provider",
+			message: `must be one of "github"`,
+		},
+		{
+			name: "missing required property",
+			content: `{
+  "version": 1,
+  "binaries": [
+    {"id": "gh", "name": "gh", "provider": "github", "path": "cli/cli"}
+  ]
+}`,
+			line:    4,
+			path:    "binaries[0]",
+			message: `missing required property "format"`,
+		},
+		{
+			name: "invalid regex",
+			content: `{
+  "version": 1,
+  "binaries": [
+    {
+      "id": "gh", "name": "gh", "provider": "github", "path": "cli/cli", "format": ".tar.gz",
+      "releaseRegex": "^v(["
+    }
+  ]
+}`,
+			line:    6,
+			path:    "binaries[0].releaseRegex",
+			message: "invalid regex",
+		},
+		{
+			name: "invalid platform key",
+			content: `{
+  "version": 1,
+  "binaries": [
+    {"id": "gh", "name": "gh", "provider": "github", "path": "cli/cli", "format": ".tar.gz",
+     "platforms": {"macos": {"format": ".zip"}}}
+  ]
+}`,
+			line:    5,
+			path:    "binaries[0].platforms.macos",
+			message: `property name "macos" does not match pattern`,
+		},
+		{
+			name: "invalid platform override",
+			content: `{
+  "version": 1,
+  "binaries": [
+    {"id": "gh", "name": "gh", "provider": "github", "path": "cli/cli", "format": ".tar.gz",
+     "platforms": {
+       "darwin/arm64": {"format": ".pkg"}
+     }}
+  ]
+}`,
+			line:    6,
+			path:    "binaries[0].platforms.darwin/arm64.format",
+			message: `must be one of ".tar.gz", ".zip"`,
+		},
+		{
+			name: "invalid platform regex",
+			content: `{
+  "version": 1,
+  "binaries": [
+    {"id": "gh", "name": "gh", "provider": "github", "path": "cli/cli", "format": ".tar.gz",
+     "platforms": {
+       "linux/riscv64": {"assetRegex": "musl("}
+     }}
+  ]
+}`,
+			line:    6,
+			path:    "binaries[0].platforms.linux/riscv64.assetRegex",
+			message: "invalid regex",
+		},
+		{
+			name: "invalid version constraint",
+			content: `{
+  "version": 1,
+  "binaries": [
+    {"id": "gh", "name": "gh", "provider": "github", "path": "cli/cli", "format": ".tar.gz", "version": ">=abc"}
+  ]
+}`,
+			line: 4,
+			path: "binaries[0].version",
+		},
+		{
+			name: "duplicate id",
+			content: `{
+  "version": 1,
+  "binaries": [
+    {"id": "gh", "name": "gh", "provider": "github", "path": "cli/cli", "format": ".tar.gz"},
+    {"id": "gh", "name": "gh2", "provider": "github", "path": "cli/cli", "format": ".tar.gz"}
+  ]
+}`,
+			line:    5,
+			path:    "binaries[1].id",
+			message: `duplicate binary id "gh", first defined on line 4`,
+		},
+		{
+			name: "command collision",
+			content: `{
+  "version": 1,
+  "binaries": [
+    {"id": "fd", "name": "fd", "provider": "github", "path": "sharkdp/fd", "format": ".tar.gz"},
+    {"id": "fd-find", "name": "fdfind", "alias": "fd", "provider": "github", "path": "sharkdp/fd", "format": ".tar.gz"}
+  ]
+}`,
+			line:    5,
+			message: `binaries "fd" and "fd-find" both install "fd"`,
+		},
+		{
+			name: "unset environment variable",
+			content: `{
+  "version": 1,
+  "binaries": [
+    {"id": "gh", "name": "gh", "provider": "github", "path": "cli/cli", "format": ".tar.gz",
+     "installPath": "${BINMATE_TEST_UNSET_DIR}/bin"}
+  ]
+}`,
+			line:    5,
+			path:    "binaries[0].installPath",
+			message: "environment variable BINMATE_TEST_UNSET_DIR is not set",
+		},
+		{
+			name: "global version template without pin",
+			content: `{
+  "version": 1,
+  "global": {
+    "installPath": "/opt/{{.Name}}/{{.Version}}"
+  },
+  "binaries": [
+    {"id": "gh", "name": "gh", "provider": "github", "path": "cli/cli", "format": ".tar.gz"}
+  ]
+}`,
+			line:    4,
+			path:    "global.installPath",
+			message: `{{.Version}} needs a pinned version, as only assetRegex and binPath are resolved when installing (for binary "gh")`,
+		},
+		{
+			name: "syntax error",
+			content: `{
+  "version": 1,
+  "binaries": [
+  ]
+  "logLevel": "info"
+}`,
+			line:    5,
+			message: "expected ',' or '}'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeConfig(t, t.TempDir(), "config.json", tt.content)
+
+			errs := ValidateFiles([]string{path}, "")
+			if len(errs) != 1 {
+				t.Fatalf("Expected 1 error, got %d:\n%v", len(errs), errs)
+			}
+
+			err := errs[0]
+			if err.File != path {
+				t.Errorf("Expected file %s, got %s", path, err.File)
+			}
+			if err.Line != tt.line {
+				t.Errorf("Expected line %d, got %d (%v)", tt.line, err.Line, err)
+			}
+			if err.Path != tt.path {
+				t.Errorf("Expected path %q, got %q", tt.path, err.Path)
+			}
+			if !strings.Contains(err.Message, tt.message) {
+				t.Errorf("Expected message containing %q, got %q", tt.message, err.Message)
+			}
+		})
+	}
+}
+
+func TestValidateFiles_CollisionInDifferentInstallPaths(t *testing.T) {
+	loadSchema(t)
+	path := writeConfig(t, t.TempDir(), "config.json", `{
+  "version": 1,
+  "binaries": [
+    {"id": "fd", "name": "fd", "provider": "github", "path": "sharkdp/fd", "format": ".tar.gz"},
+    {"id": "fd-old", "name": "fd", "provider": "github", "path": "sharkdp/fd", "format": ".tar.gz", "installPath": "/opt/bin"}
+  ]
+}`)
+
+	if errs := ValidateFiles([]string{path}, ""); len(errs) > 0 {
+		t.Errorf("Expected no errors, got:\n%v", errs)
+	}
+}
+
+func TestValidateFiles_CollisionInSameInstallPathSpelledDifferently(t *testing.T) {
+	loadSchema(t)
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	path := writeConfig(t, t.TempDir(), "config.json", `{
+  "version": 1,
+  "binaries": [
+    {"id": "fd", "name": "fd", "provider": "github", "path": "sharkdp/fd", "format": ".tar.gz", "installPath": "~/.local/bin"},
+    {"id": "fd-old", "name": "fd", "provider": "github", "path": "sharkdp/fd", "format": ".tar.gz", "installPath": "${HOME}/.local/bin/"}
+  ]
+}`)
+
+	errs := ValidateFiles([]string{path}, "")
+	if len(errs) != 1 || !strings.Contains(errs[0].Message, `both install "fd"`) {
+		t.Errorf("Expected a collision error, got:\n%v", errs)
+	}
+}
+
+func TestValidateFiles_ProjectOverlay(t *testing.T) {
+	loadSchema(t)
+	dir := t.TempDir()
+	userConfig := writeConfig(t, dir, "config.json", `{
+  "version": 1,
+  "binaries": [
+    {"id": "gh", "name": "gh", "provider": "github", "path": "cli/cli", "format": ".tar.gz"}
+  ]
+}`)
+
+	// Overriding an existing binary only needs the changed fields
+	project := writeConfig(t, dir, "binmate.json", `{
+  "binaries": [
+    {"id": "gh", "pin": "v2.40.0"}
+  ]
+}`)
+	if errs := ValidateFiles([]string{userConfig, project}, project); len(errs) > 0 {
+		t.Errorf("Expected no errors, got:\n%v", errs)
+	}
+
+	// New binaries must be complete
+	project = writeConfig(t, dir, "binmate.json", `{
+  "binaries": [
+    {"id": "fzf", "name": "fzf"}
+  ]
+}`)
+	errs := ValidateFiles([]string{userConfig, project}, project)
+	if len(errs) == 0 {
+		t.Fatal("Expected errors for incomplete project binary")
+	}
+	for _, err := range errs {
+		if err.File != project || err.Line != 3 {
+			t.Errorf("Expected error at %s:3, got %v", project, err)
+		}
+	}
+}
+
+func TestValidateFiles_CollisionAcrossFiles(t *testing.T) {
+	loadSchema(t)
+	dir := t.TempDir()
+	userConfig := writeConfig(t, dir, "config.json", `{
+  "version": 1,
+  "binaries": [
+    {"id": "gh", "name": "gh", "provider": "github", "path": "cli/cli", "format": ".tar.gz"}
+  ]
+}`)
+	project := writeConfig(t, dir, "binmate.json", `{
+  "binaries": [
+
+    {"id": "gh-fork", "name": "gh", "provider": "github", "path": "me/cli", "format": ".tar.gz"}
+  ]
+}`)
+
+	errs := ValidateFiles([]string{userConfig, project}, project)
+	if len(errs) != 1 {
+		t.Fatalf("Expected 1 error, got %d:\n%v", len(errs), errs)
+	}
+	if errs[0].File != project || errs[0].Line != 4 {
+		t.Errorf("Expected error at %s:4, got %v", project, errs[0])
+	}
+}
+
+func TestValidationError_Error(t *testing.T) {
+	err := ValidationError{File: "config.json", Line: 3, Column: 5, Path: "binaries[0].id", Message: "bad"}
+	if got := err.Error(); got != "config.json:3:5: binaries[0].id: bad" {
+		t.Errorf("Unexpected error string %q", got)
+	}
+}
+
+// Every config field must be described by the schema, or validation rejects valid configs
+func TestSchemaCoversConfigFields(t *testing.T) {
+	data, err := os.ReadFile("../../../schema.json")
+	if err != nil {
+		t.Fatalf("Failed to read schema: %v", err)
+	}
+	var schema map[string]any
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("Failed to parse schema: %v", err)
+	}
+	definitions := schema["definitions"].(map[string]any)
+	properties := func(object map[string]any) map[string]any {
+		if ref, ok := object["$ref"].(string); ok {
+			object = definitions[strings.TrimPrefix(ref, "#/definitions/")].(map[string]any)
+		}
+		return object["properties"].(map[string]any)
+	}
+
+	var check func(typ reflect.Type, object map[string]any, path string)
+	check = func(typ reflect.Type, object map[string]any, path string) {
+		props := properties(object)
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			name := field.Tag.Get("mapstructure")
+			if !field.IsExported() {
+				continue
+			}
+			prop, ok := props[name].(map[string]any)
+			if !ok {
+				t.Errorf("Schema is missing property %s%s", path, name)
+				continue
+			}
+			if field.Type.Kind() == reflect.Struct {
+				check(field.Type, prop, path+name+".")
+			}
+		}
+	}
+
+	check(reflect.TypeOf(Config{}), schema, "")
+	check(reflect.TypeOf(Binary{}), definitions["binary"].(map[string]any), "binaries[].")
+	check(reflect.TypeOf(Mirror{}), definitions["mirror"].(map[string]any), "global.mirrors[].")
+	check(reflect.TypeOf(PlatformOverride{}), definitions["platform"].(map[string]any), "binaries[].platforms.*.")
+}
+
+func TestValidateFiles_YAMLAndTOML(t *testing.T) {
+	loadSchema(t)
+
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		line    int
+		path    string
+		message string
+	}{
+		{
+			name: "valid yaml",
+			file: "config.yaml",
+			content: `# yaml-language-server: $schema=https://example.com/schema.json
+version: 1
+binaries:
+  # Pinned until the new asset names are supported
+  - id: gh
+    name: gh
+    provider: github
+    path: cli/cli
+    format: .tar.gz
+    pin: v2.40.0
+    keepVersions: 3
+`,
+		},
+		{
+			name: "yaml unknown property",
+			file: "config.yaml",
+			content: `version: 1
+binaries:
+  - id: gh
+    name: gh
+    provider: github
+    path: cli/cli
+    format: .tar.gz
+    asetRegex: linux
+`,
+			line:    8,
+			path:    "binaries[0].asetRegex",
+			message: `did you mean "assetRegex"?`,
+		},
+		{
+			name: "yaml wrong type",
+			file: "config.yml",
+			content: `version: 1
+binaries: []
+global:
+  installPath: /usr/local/bin
+  providers:
+    github:
+      authenticated: "yes"
+`,
+			line:    7,
+			path:    "global.providers.github.authenticated",
+			message: "expected boolean, got string",
+		},
+		{
+			name: "yaml syntax error",
+			file: "config.yaml",
+			content: `version: 1
+binaries: [
+`,
+			line:    2,
+			message: "did not find expected node content",
+		},
+		{
+			name: "valid toml",
+			file: "config.toml",
+			content: `#:schema https://example.com/schema.json
+version = 1
+
+[global]
+installPath = "/usr/local/bin"
+
+# Pinned until the new asset names are supported
+[[binaries]]
+id = "gh"
+name = "gh"
+provider = "github"
+path = "cli/cli"
+format = ".tar.gz"
+pin = "v2.40.0"
+keepVersions = 3
+`,
+		},
+		{
+			name: "toml invalid regex",
+			file: "config.toml",
+			content: `version = 1
+
+[[binaries]]
+id = "gh"
+name = "gh"
+provider = "github"
+path = "cli/cli"
+format = ".tar.gz"
+
+[[binaries]]
+id = "fzf"
+name = "fzf"
+provider = "github"
+path = "junegunn/fzf"
+format = ".tar.gz"
+assetRegex = "linux_(amd64"
+`,
+			line:    16,
+			path:    "binaries[1].assetRegex",
+			message: "invalid regex",
+		},
+		{
+			name: "toml wrong type",
+			file: "config.toml",
+			content: `version = 1.5
+binaries = []
+`,
+			line:    1,
+			path:    "version",
+			message: "expected integer, got number",
+		},
+		{
+			name: "toml duplicate key",
+			file: "config.toml",
+			content: `version = 1
+version = 2
+`,
+			line:    2,
+			message: `duplicate key "version"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeConfig(t, t.TempDir(), tt.file, tt.content)
+
+			errs := ValidateFiles([]string{path}, "")
+			if tt.message == "" {
+				if len(errs) > 0 {
+					t.Errorf("Expected no errors, got:\n%v", errs)
+				}
+				return
+			}
+			if len(errs) != 1 {
+				t.Fatalf("Expected 1 error, got %d:\n%v", len(errs), errs)
+			}
+
+			err := errs[0]
+			if err.Line != tt.line {
+				t.Errorf("Expected line %d, got %d (%v)", tt.line, err.Line, err)
+			}
+			if err.Path != tt.path {
+				t.Errorf("Expected path %q, got %q", tt.path, err.Path)
+			}
+			if !strings.Contains(err.Message, tt.message) {
+				t.Errorf("Expected message containing %q, got %q", tt.message, err.Message)
+			}
+		})
+	}
+}
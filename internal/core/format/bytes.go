@@ -0,0 +1,21 @@
+package format
+
+import "fmt"
+
+// FormatBytes converts bytes to human-readable format
+func FormatBytes(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+
+	// Unit prefixes: Kilo, Mega, Giga, Tera, Peta, Exa
+	const unitPrefixes = "KMGTPE"
+
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), unitPrefixes[exp])
+}
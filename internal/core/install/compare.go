@@ -0,0 +1,25 @@
+package install
+
+import (
+	"cturner8/binmate/internal/core/semver"
+	"cturner8/binmate/internal/database"
+)
+
+// CompareVersions compares two release tags of a binary, stripping any tag prefix
+// matched by its releaseRegex. It returns -1, 0 or 1 if a is older than, equal to or newer than b.
+func CompareVersions(binary *database.Binary, a, b string) int {
+	releaseRegex := ""
+	if binary.ReleaseRegex != nil {
+		releaseRegex = *binary.ReleaseRegex
+	}
+	return semver.CompareTags(a, b, releaseRegex)
+}
+
+// DisplayVersion returns a release tag without the prefix matched by the binary's releaseRegex,
+// e.g. "1.1.0" for "bun-v1.1.0". Tags that do not match are returned unchanged.
+func DisplayVersion(binary *database.Binary, tag string) string {
+	if binary == nil || binary.ReleaseRegex == nil {
+		return tag
+	}
+	return semver.StripTagPrefix(tag, *binary.ReleaseRegex)
+}
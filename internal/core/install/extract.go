@@ -1,26 +1,83 @@
 package install
 
 import (
+	"context"
 	"cturner8/binmate/internal/database"
+	"cturner8/binmate/internal/providers/github"
 	"fmt"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
 )
 
-func ExtractAsset(srcPath string, binary *database.Binary, version string) (string, error) {
+// ExtractAsset extracts the binary from a downloaded archive into the versions directory.
+// If extraction fails or ctx is cancelled, a newly created version directory is removed.
+func ExtractAsset(ctx context.Context, srcPath string, binary *database.Binary, version string) (string, error) {
 	destDir, err := getExtractPath(binary.UserID, version)
 	if err != nil {
 		return "", fmt.Errorf("unable to locate asset extract dir")
 	}
 
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	// Only clean up directories created by this extraction
+	_, statErr := os.Stat(destDir)
+	created := os.IsNotExist(statErr)
+
+	binPath := ""
+	if binary.BinPath != nil {
+		binPath = strings.ReplaceAll(*binary.BinPath, github.VersionPlaceholder, strings.TrimPrefix(DisplayVersion(binary, version), "v"))
+	}
+
+	var destPath string
 	switch binary.Format {
 	case ".zip":
-		{
-			return extractZip(srcPath, destDir, binary.Name)
-		}
+		destPath, err = extractZip(srcPath, destDir, binary.Name, binPath)
 	case ".tar.gz":
-		{
-			return extractTar(srcPath, destDir, binary.Name)
+		destPath, err = extractTar(srcPath, destDir, binary.Name, binPath)
+	default:
+		return "", fmt.Errorf("unsupported asset format: %s", binary.Format)
+	}
+
+	if err == nil {
+		err = ctx.Err()
+	}
+	if err != nil {
+		if created {
+			removePartialInstall(destDir)
+		} else if destPath != "" {
+			removePartialInstall(destPath)
 		}
+		return "", err
 	}
 
-	return "", fmt.Errorf("unsupported asset format: %s", binary.Format)
+	return destPath, nil
+}
+
+// matchesBinary reports whether an archive entry is the binary: the entry at binPath if
+// set, otherwise any entry named after the binary
+func matchesBinary(entry, binaryName, binPath string) bool {
+	if binPath != "" {
+		return path.Clean(strings.TrimPrefix(entry, "./")) == path.Clean(strings.TrimPrefix(binPath, "./"))
+	}
+	return filepath.Base(entry) == binaryName
+}
+
+// removePartialInstall removes artifacts left behind by an incomplete installation.
+// Paths inside a version directory remove the whole directory if nothing else remains.
+func removePartialInstall(path string) {
+	if err := os.RemoveAll(path); err != nil {
+		log.Printf("Warning: failed to remove partial install %s: %v", path, err)
+		return
+	}
+
+	// Remove the now empty version directory when a binary file was removed
+	dir := filepath.Dir(path)
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) == 0 {
+		os.Remove(dir)
+	}
 }
@@ -0,0 +1,148 @@
+package install
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cturner8/binmate/internal/database"
+)
+
+// writeTestTarGz creates a tar.gz archive containing the given files
+func writeTestTarGz(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("failed to create archive: %v", err)
+	}
+	defer f.Close()
+
+	gzw := gzip.NewWriter(f)
+	defer gzw.Close()
+	tw := tar.NewWriter(gzw)
+	defer tw.Close()
+
+	for name, content := range files {
+		header := &tar.Header{Name: name, Mode: 0o755, Size: int64(len(content)), Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatalf("failed to write header: %v", err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatalf("failed to write content: %v", err)
+		}
+	}
+}
+
+func TestExtractAsset(t *testing.T) {
+	tests := []struct {
+		name        string
+		files       map[string]string
+		binPath     string
+		cancelled   bool
+		wantErr     bool
+		wantDirGone bool
+	}{
+		{
+			name:  "extracts binary",
+			files: map[string]string{"bin/testbin": "binary"},
+		},
+		{
+			name:    "extracts binary at binPath",
+			files:   map[string]string{"other/testbin": "other", "testbin_1.0.0/bin/testbin": "binary"},
+			binPath: "./testbin_{{.Version}}/bin/testbin",
+		},
+		{
+			name:        "removes version directory when binPath missing",
+			files:       map[string]string{"bin/testbin": "binary"},
+			binPath:     "dist/testbin",
+			wantErr:     true,
+			wantDirGone: true,
+		},
+		{
+			name:        "removes version directory when binary missing",
+			files:       map[string]string{"README.md": "docs"},
+			wantErr:     true,
+			wantDirGone: true,
+		},
+		{
+			name:        "removes version directory when cancelled",
+			files:       map[string]string{"bin/testbin": "binary"},
+			cancelled:   true,
+			wantErr:     true,
+			wantDirGone: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dataDir := t.TempDir()
+			t.Setenv("XDG_DATA_HOME", dataDir)
+
+			archive := filepath.Join(t.TempDir(), "asset.tar.gz")
+			writeTestTarGz(t, archive, tt.files)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancelled {
+				cancel()
+			}
+
+			binary := &database.Binary{UserID: "test", Name: "testbin", Format: ".tar.gz"}
+			if tt.binPath != "" {
+				binary.BinPath = &tt.binPath
+			}
+			destPath, err := ExtractAsset(ctx, archive, binary, "v1.0.0")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExtractAsset() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			versionDir := filepath.Join(dataDir, "binmate", "versions", "test", "v1.0.0")
+			_, statErr := os.Stat(versionDir)
+			if tt.wantDirGone && !os.IsNotExist(statErr) {
+				t.Errorf("expected %s to be removed", versionDir)
+			}
+
+			if !tt.wantErr {
+				if destPath != filepath.Join(versionDir, "testbin") {
+					t.Errorf("destPath = %s, want binary inside %s", destPath, versionDir)
+				}
+				content, err := os.ReadFile(destPath)
+				if err != nil {
+					t.Errorf("extracted binary missing: %v", err)
+				} else if string(content) != "binary" {
+					t.Errorf("extracted %q, want the binary", content)
+				}
+			}
+		})
+	}
+}
+
+func TestExtractAsset_KeepsExistingVersionDirectory(t *testing.T) {
+	dataDir := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", dataDir)
+
+	// Simulate a directory from a previous install containing other files
+	versionDir := filepath.Join(dataDir, "binmate", "versions", "test", "v1.0.0")
+	if err := os.MkdirAll(versionDir, 0o755); err != nil {
+		t.Fatalf("failed to create version dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(versionDir, "other"), []byte("keep"), 0o644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	archive := filepath.Join(t.TempDir(), "asset.tar.gz")
+	writeTestTarGz(t, archive, map[string]string{"README.md": "docs"})
+
+	binary := &database.Binary{UserID: "test", Name: "testbin", Format: ".tar.gz"}
+	if _, err := ExtractAsset(context.Background(), archive, binary, "v1.0.0"); err == nil {
+		t.Fatal("expected error for missing binary")
+	}
+
+	if _, err := os.Stat(filepath.Join(versionDir, "other")); err != nil {
+		t.Errorf("existing files should not be removed: %v", err)
+	}
+}
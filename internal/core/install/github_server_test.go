@@ -0,0 +1,172 @@
+package install
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"cturner8/binmate/internal/providers/github"
+)
+
+// fakeRelease describes a release served by fakeGitHub, newest first
+type fakeRelease struct {
+	Tag        string
+	Prerelease bool
+}
+
+// fakeGitHub serves a minimal GitHub releases API for owner/repo
+type fakeGitHub struct {
+	releases  []fakeRelease
+	mu        sync.Mutex
+	downloads []string // Tags of downloaded assets
+	listed    int      // Pages of releases listed
+}
+
+// newFakeGitHub starts a fake GitHub API and routes provider requests to it
+func newFakeGitHub(t *testing.T, releases ...fakeRelease) *fakeGitHub {
+	t.Helper()
+
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("XDG_DATA_HOME", dir+"/data")
+	t.Setenv("XDG_CACHE_HOME", dir+"/cache")
+
+	fake := &fakeGitHub{releases: releases}
+	server := httptest.NewServer(http.HandlerFunc(fake.serve))
+	t.Cleanup(server.Close)
+
+	if err := github.Configure(github.ClientSettings{Mirrors: []github.Mirror{{URL: server.URL}}}); err != nil {
+		t.Fatalf("failed to configure github client: %v", err)
+	}
+	t.Cleanup(func() { github.Configure(github.ClientSettings{}) })
+
+	return fake
+}
+
+func (f *fakeGitHub) releaseJSON(i int) map[string]any {
+	r := f.releases[i]
+	return map[string]any{
+		"name":         r.Tag,
+		"tag_name":     r.Tag,
+		"prerelease":   r.Prerelease,
+		"published_at": time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).Add(-time.Duration(i) * time.Hour),
+		"assets": []map[string]any{{
+			"id":   i + 1,
+			"name": fmt.Sprintf("testbin_%s_%s.tar.gz", runtime.GOOS, runtime.GOARCH),
+			"size": 100,
+		}},
+	}
+}
+
+func (f *fakeGitHub) serve(w http.ResponseWriter, r *http.Request) {
+	const prefix = "/repos/owner/repo/releases"
+	path := r.URL.Path
+
+	switch {
+	case path == prefix:
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+		if page < 1 {
+			page = 1
+		}
+		if perPage < 1 {
+			perPage = 30
+		}
+
+		f.mu.Lock()
+		f.listed++
+		f.mu.Unlock()
+
+		start := min((page-1)*perPage, len(f.releases))
+		end := min(start+perPage, len(f.releases))
+		list := []map[string]any{}
+		for i := start; i < end; i++ {
+			list = append(list, f.releaseJSON(i))
+		}
+		if end < len(f.releases) {
+			next := *r.URL
+			query := next.Query()
+			query.Set("page", strconv.Itoa(page+1))
+			next.RawQuery = query.Encode()
+			w.Header().Set("Link", fmt.Sprintf(`<https://api.github.com%s>; rel="next"`, next.RequestURI()))
+		}
+		writeJSON(w, list)
+
+	case path == prefix+"/latest":
+		for i, release := range f.releases {
+			if !release.Prerelease {
+				writeJSON(w, f.releaseJSON(i))
+				return
+			}
+		}
+		http.NotFound(w, r)
+
+	case strings.HasPrefix(path, prefix+"/tags/"):
+		tag := strings.TrimPrefix(path, prefix+"/tags/")
+		for i, release := range f.releases {
+			if release.Tag == tag {
+				writeJSON(w, f.releaseJSON(i))
+				return
+			}
+		}
+		http.NotFound(w, r)
+
+	case strings.HasPrefix(path, prefix+"/assets/"):
+		id, err := strconv.Atoi(strings.TrimPrefix(path, prefix+"/assets/"))
+		if err != nil || id < 1 || id > len(f.releases) {
+			http.NotFound(w, r)
+			return
+		}
+		tag := f.releases[id-1].Tag
+
+		f.mu.Lock()
+		f.downloads = append(f.downloads, tag)
+		f.mu.Unlock()
+
+		w.Write(testArchive(tag))
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// downloaded returns the tags of all downloaded assets
+func (f *fakeGitHub) downloaded() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.downloads...)
+}
+
+// listedPages returns the number of release pages listed
+func (f *fakeGitHub) listedPages() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.listed
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// testArchive builds a tar.gz containing a testbin file whose content is the tag
+func testArchive(tag string) []byte {
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+	tw.WriteHeader(&tar.Header{Name: "testbin", Mode: 0o755, Size: int64(len(tag)), Typeflag: tar.TypeReg})
+	tw.Write([]byte(tag))
+	tw.Close()
+	gzw.Close()
+	return buf.Bytes()
+}
@@ -0,0 +1,106 @@
+package install
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	v "cturner8/binmate/internal/core/version"
+	"cturner8/binmate/internal/database"
+	"cturner8/binmate/internal/database/repository"
+)
+
+// Reconcile actions taken to bring a binary to its desired version
+const (
+	ReconcileInstalled = "installed"  // The desired version was downloaded and activated
+	ReconcileActivated = "activated"  // The desired version was already installed and has been activated
+	ReconcileUpToDate  = "up to date" // The desired version was already active
+)
+
+// ReconcileResult contains the results of reconciling a binary with its desired version
+type ReconcileResult struct {
+	Binary       *database.Binary
+	Installation *database.Installation
+	Version      string
+	Previous     string // Version active before reconciling, empty if none was active
+	Action       string // One of ReconcileInstalled, ReconcileActivated or ReconcileUpToDate
+}
+
+// ReconcileBinary installs and activates the desired version of a binary: its pinned version,
+// or the latest release allowed by its version constraint and channel. Versions already
+// installed are activated without downloading them again, so reconciling is idempotent.
+// The desired version is activated even if the active version is newer.
+func ReconcileBinary(ctx context.Context, binaryID string, dbService *repository.Service, opts InstallOptions) (*ReconcileResult, error) {
+	binaryConfig, err := dbService.Binaries.GetByUserID(binaryID)
+	if err != nil {
+		return nil, fmt.Errorf("binary not found: %w", err)
+	}
+
+	previous, err := activeInstallation(binaryConfig, dbService)
+	if err != nil {
+		return nil, err
+	}
+	result := &ReconcileResult{Binary: binaryConfig}
+	if previous != nil {
+		result.Previous = previous.Version
+	}
+
+	version, err := desiredVersion(ctx, binaryConfig, dbService)
+	if err != nil {
+		return nil, err
+	}
+
+	installation, err := dbService.Installations.Get(binaryConfig.ID, version)
+	if err != nil && err != database.ErrNotFound {
+		return nil, fmt.Errorf("failed to check existing installation: %w", err)
+	}
+
+	switch {
+	case err == nil && previous != nil && previous.ID == installation.ID:
+		result.Action = ReconcileUpToDate
+	case err == nil:
+		commitMu.Lock()
+		err := v.ActivateInstallation(binaryConfig, installation, dbService)
+		commitMu.Unlock()
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("Activated %s version %s", binaryID, installation.Version)
+		result.Action = ReconcileActivated
+	default:
+		installed, err := InstallBinaryWithOptions(ctx, binaryID, version, dbService, opts)
+		if err != nil {
+			return nil, err
+		}
+		installation = installed.Installation
+		result.Action = ReconcileInstalled
+		if installed.AlreadyInstalled {
+			// Pins given without their tag prefix only match the installation once resolved
+			result.Action = ReconcileActivated
+			if previous != nil && previous.ID == installation.ID {
+				result.Action = ReconcileUpToDate
+			}
+		}
+	}
+
+	result.Installation = installation
+	result.Version = installation.Version
+	return result, nil
+}
+
+// desiredVersion returns the version a binary should have active: its pin, or the tag of
+// the latest release allowed by its version constraint and channel
+func desiredVersion(ctx context.Context, binary *database.Binary, dbService *repository.Service) (string, error) {
+	pin, err := dbService.Pins.Get(binary.ID)
+	if err == nil {
+		return pin.Version, nil
+	} else if err != database.ErrNotFound {
+		return "", fmt.Errorf("failed to check pin: %w", err)
+	}
+
+	release, _, err := ResolveRelease(ctx, binary, "latest")
+	if err != nil {
+		return "", fmt.Errorf("fetch failed: %w", err)
+	}
+	return release.TagName, nil
+}
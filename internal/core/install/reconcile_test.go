@@ -0,0 +1,65 @@
+package install
+
+import (
+	"context"
+	"testing"
+)
+
+func TestReconcileBinary(t *testing.T) {
+	fake := newFakeGitHub(t, fakeRelease{Tag: "v1.1.0"}, fakeRelease{Tag: "v1.0.0"})
+	dbService, cleanup := setupTestDB(t)
+	defer cleanup()
+	binary := createTestBinary(t, dbService, "test")
+
+	steps := []struct {
+		name        string
+		setup       func()
+		wantVersion string
+		wantAction  string
+		wantPrev    string
+		downloads   int
+	}{
+		{name: "installs latest", wantVersion: "v1.1.0", wantAction: ReconcileInstalled, downloads: 1},
+		{name: "idempotent", wantVersion: "v1.1.0", wantAction: ReconcileUpToDate, wantPrev: "v1.1.0", downloads: 1},
+		{
+			name: "installs pin",
+			setup: func() {
+				if err := dbService.Pins.Set(binary.ID, "v1.0.0", "config"); err != nil {
+					t.Fatalf("failed to pin: %v", err)
+				}
+			},
+			wantVersion: "v1.0.0", wantAction: ReconcileInstalled, wantPrev: "v1.1.0", downloads: 2,
+		},
+		{
+			name: "activates installed version",
+			setup: func() {
+				if err := dbService.Pins.Delete(binary.ID); err != nil {
+					t.Fatalf("failed to unpin: %v", err)
+				}
+			},
+			wantVersion: "v1.1.0", wantAction: ReconcileActivated, wantPrev: "v1.0.0", downloads: 2,
+		},
+	}
+
+	for _, step := range steps {
+		if step.setup != nil {
+			step.setup()
+		}
+
+		result, err := ReconcileBinary(context.Background(), "test", dbService, InstallOptions{})
+		if err != nil {
+			t.Fatalf("%s: ReconcileBinary() error = %v", step.name, err)
+		}
+		if result.Version != step.wantVersion || result.Action != step.wantAction || result.Previous != step.wantPrev {
+			t.Errorf("%s: result = %s %s (was %q), want %s %s (was %q)", step.name, result.Version, result.Action, result.Previous, step.wantVersion, step.wantAction, step.wantPrev)
+		}
+		if downloads := fake.downloaded(); len(downloads) != step.downloads {
+			t.Errorf("%s: downloads = %v, want %d", step.name, downloads, step.downloads)
+		}
+
+		_, active, err := dbService.Versions.GetWithInstallation(binary.ID)
+		if err != nil || active.Version != step.wantVersion {
+			t.Errorf("%s: active version = %+v, %v, want %s", step.name, active, err, step.wantVersion)
+		}
+	}
+}
@@ -0,0 +1,252 @@
+package install
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"cturner8/binmate/internal/core/semver"
+	"cturner8/binmate/internal/database"
+	"cturner8/binmate/internal/providers/github"
+)
+
+// Release channels deciding how "latest" is resolved
+const (
+	ChannelStable     = "stable"     // Newest non-prerelease release
+	ChannelPrerelease = "prerelease" // Newest release, including prereleases
+	ChannelNightly    = "nightly"    // Most recently published release whose tag matches the nightly pattern
+)
+
+// maxReleasePages bounds the pages of releases scanned for a matching tag, as every page is
+// an API request counted against GitHub's rate limit
+const maxReleasePages = 5
+
+// defaultNightlyRegex matches nightly tags when a binary does not configure its own pattern
+const defaultNightlyRegex = `(?i)nightly`
+
+// ResolveRelease fetches the release and matching asset for a version of a binary.
+// "latest" resolves according to the binary's release channel and version constraint,
+// falling back to the repository's latest release for stable binaries without a constraint
+// or releaseRegex. Explicit versions are fetched even if they fall outside the constraint
+// or channel, and may omit the tag prefix matched by releaseRegex (e.g. "1.1.0" for "bun-v1.1.0").
+func ResolveRelease(ctx context.Context, binary *database.Binary, version string) (github.Release, github.ReleaseAsset, error) {
+	if version != "latest" {
+		tag, err := releaseTag(ctx, binary, version)
+		if err != nil {
+			return github.Release{}, github.ReleaseAsset{}, err
+		}
+		return github.FetchReleaseAsset(ctx, binary, tag)
+	}
+
+	channel := BinaryChannel(binary)
+	constraint := ""
+	if binary.VersionConstraint != nil {
+		constraint = *binary.VersionConstraint
+	}
+
+	var (
+		tag string
+		err error
+	)
+	switch channel {
+	case ChannelStable:
+		// The repository's latest release may belong to another component of a monorepo,
+		// so binaries with a releaseRegex scan the release list for their own tags
+		if constraint == "" && (binary.ReleaseRegex == nil || *binary.ReleaseRegex == "") {
+			return github.FetchReleaseAsset(ctx, binary, version)
+		}
+		tag, err = latestMatchingTag(ctx, binary, constraint, false)
+	case ChannelPrerelease:
+		tag, err = latestMatchingTag(ctx, binary, constraint, true)
+	case ChannelNightly:
+		tag, err = latestNightlyTag(ctx, binary)
+	default:
+		return github.Release{}, github.ReleaseAsset{}, fmt.Errorf("unsupported release channel '%s', expected %s, %s or %s", channel, ChannelStable, ChannelPrerelease, ChannelNightly)
+	}
+	if err != nil {
+		return github.Release{}, github.ReleaseAsset{}, err
+	}
+
+	return github.FetchReleaseAsset(ctx, binary, tag)
+}
+
+// BinaryChannel returns the release channel of a binary, defaulting to stable
+func BinaryChannel(binary *database.Binary) string {
+	if binary.Channel == nil || *binary.Channel == "" {
+		return ChannelStable
+	}
+	return *binary.Channel
+}
+
+// latestMatchingTag scans the published releases, newest first, and returns the newest tag
+// matching the binary's releaseRegex and satisfying the constraint (if any). Prereleases are
+// only considered when includePrerelease is set or the constraint names a prerelease of the
+// same version. Nightly tags are always skipped. Releases are not always listed in version
+// order (e.g. backported patches), so the scan continues past the first match until a page
+// has no newer match, and gives up after maxReleasePages.
+func latestMatchingTag(ctx context.Context, binary *database.Binary, rawConstraint string, includePrerelease bool) (string, error) {
+	nightly, err := nightlyPattern(binary)
+	if err != nil {
+		return "", err
+	}
+	pattern, err := github.TagPattern(binary)
+	if err != nil {
+		return "", err
+	}
+
+	check := func(semver.Version) bool { return true }
+	if rawConstraint != "" {
+		constraint, err := semver.ParseConstraint(rawConstraint)
+		if err != nil {
+			return "", err
+		}
+		check = constraint.Check
+		if includePrerelease {
+			check = constraint.CheckPrerelease
+		}
+	} else if !includePrerelease {
+		check = func(v semver.Version) bool { return !v.IsPrerelease() }
+	}
+
+	releaseRegex := ""
+	if binary.ReleaseRegex != nil {
+		releaseRegex = *binary.ReleaseRegex
+	}
+
+	latest, pages := "", 0
+	err = github.WalkReleasePages(ctx, binary, func(releases []github.ReleaseInfo) bool {
+		pages++
+		newer := false
+		for _, release := range releases {
+			if release.Draft || nightly.MatchString(release.TagName) {
+				continue
+			}
+			if pattern != nil && !pattern.MatchString(release.TagName) {
+				continue
+			}
+
+			v, err := semver.Parse(semver.StripTagPrefix(release.TagName, releaseRegex))
+			if err != nil {
+				continue
+			}
+			// Releases flagged as prereleases are only considered on the stable channel when their tag is a semver prerelease
+			if release.Prerelease && !includePrerelease && !v.IsPrerelease() {
+				continue
+			}
+
+			if check(v) && (latest == "" || CompareVersions(binary, release.TagName, latest) > 0) {
+				latest = release.TagName
+				newer = true
+			}
+		}
+		return (latest == "" || newer) && pages < maxReleasePages
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list releases: %w", err)
+	}
+
+	if latest == "" {
+		if rawConstraint != "" {
+			return "", fmt.Errorf("no release matches version constraint '%s'%s", rawConstraint, scanned(pages))
+		}
+		if pattern != nil {
+			return "", fmt.Errorf("no release tags match releaseRegex '%s'%s", pattern, scanned(pages))
+		}
+		return "", fmt.Errorf("no releases found")
+	}
+	return latest, nil
+}
+
+// scanned describes how far a release scan reached when it gave up at maxReleasePages
+func scanned(pages int) string {
+	if pages < maxReleasePages {
+		return ""
+	}
+	return fmt.Sprintf(" in the latest %d pages of releases", maxReleasePages)
+}
+
+// releaseTag maps a version to the release tag it refers to. Versions already matching the
+// binary's releaseRegex are used as-is; otherwise the release list is scanned for a matching
+// tag whose version, once the prefix is stripped, equals the requested one, giving up after
+// maxReleasePages.
+func releaseTag(ctx context.Context, binary *database.Binary, version string) (string, error) {
+	pattern, err := github.TagPattern(binary)
+	if err != nil {
+		return "", err
+	}
+	if pattern == nil || pattern.MatchString(version) {
+		return version, nil
+	}
+
+	tag, pages := "", 0
+	err = github.WalkReleasePages(ctx, binary, func(releases []github.ReleaseInfo) bool {
+		pages++
+		for _, release := range releases {
+			if release.Draft || !pattern.MatchString(release.TagName) {
+				continue
+			}
+			if semver.CompareTags(release.TagName, version, pattern.String()) == 0 {
+				tag = release.TagName
+				return false
+			}
+		}
+		return pages < maxReleasePages
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list releases: %w", err)
+	}
+
+	if tag == "" {
+		return "", fmt.Errorf("no release tag matching releaseRegex '%s' found for version '%s'%s", pattern, version, scanned(pages))
+	}
+	return tag, nil
+}
+
+// latestNightlyTag returns the most recently published release whose tag matches the nightly pattern.
+// Nightly tags are frequently not versions (e.g. "nightly" or "nightly-abc1234"), so publish date decides.
+// As with latestMatchingTag, the scan stops once a page has no newer match or after maxReleasePages.
+func latestNightlyTag(ctx context.Context, binary *database.Binary) (string, error) {
+	re, err := nightlyPattern(binary)
+	if err != nil {
+		return "", err
+	}
+
+	var latest *github.ReleaseInfo
+	pages := 0
+	err = github.WalkReleasePages(ctx, binary, func(releases []github.ReleaseInfo) bool {
+		pages++
+		newer := false
+		for _, release := range releases {
+			if release.Draft || !re.MatchString(release.TagName) {
+				continue
+			}
+			if latest == nil || release.PublishedAt.After(latest.PublishedAt) {
+				latest = &release
+				newer = true
+			}
+		}
+		return (latest == nil || newer) && pages < maxReleasePages
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list releases: %w", err)
+	}
+
+	if latest == nil {
+		return "", fmt.Errorf("no nightly release matches '%s'%s", re, scanned(pages))
+	}
+	return latest.TagName, nil
+}
+
+// nightlyPattern compiles the binary's nightly tag pattern, or the default pattern if unset
+func nightlyPattern(binary *database.Binary) (*regexp.Regexp, error) {
+	pattern := defaultNightlyRegex
+	if binary.NightlyRegex != nil && *binary.NightlyRegex != "" {
+		pattern = *binary.NightlyRegex
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid nightlyRegex pattern '%s': %w", pattern, err)
+	}
+	return re, nil
+}
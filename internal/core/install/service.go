@@ -1,27 +1,55 @@
 package install
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
+	"runtime"
+	"sync"
 	"time"
 
 	"cturner8/binmate/internal/core/crypto"
+	"cturner8/binmate/internal/core/progress"
 	v "cturner8/binmate/internal/core/version"
 	"cturner8/binmate/internal/database"
 	"cturner8/binmate/internal/database/repository"
 	"cturner8/binmate/internal/providers/github"
 )
 
+// ErrPinned is returned when updating a binary that is pinned to a version
+var ErrPinned = errors.New("binary is pinned")
+
+// commitMu serialises symlink updates and database writes so that installs
+// can fetch, download and extract concurrently without racing on shared state
+var commitMu sync.Mutex
+
 // InstallBinaryResult contains the results of a binary installation
 type InstallBinaryResult struct {
-	Binary       *database.Binary
-	Installation *database.Installation
-	Version      string
+	Binary           *database.Binary
+	Installation     *database.Installation
+	Version          string
+	AlreadyInstalled bool                     // True if the version was already installed and only re-activated
+	Pruned           []*database.Installation // Old versions removed by the binary's keepVersions setting after an update
+}
+
+// InstallOptions contains optional settings for an installation
+type InstallOptions struct {
+	Progress    progress.Func // Receives download progress updates, may be nil
+	OnlyIfNewer bool          // Skip the install if the active version is the same as or newer than the resolved release
+	NoActivate  bool          // Install without changing the active version, e.g. for shims and exec
 }
 
 // InstallBinary installs a specific version of a binary
-func InstallBinary(binaryID string, version string, dbService *repository.Service) (*InstallBinaryResult, error) {
+func InstallBinary(ctx context.Context, binaryID string, version string, dbService *repository.Service) (*InstallBinaryResult, error) {
+	return InstallBinaryWithOptions(ctx, binaryID, version, dbService, InstallOptions{})
+}
+
+// InstallBinaryWithOptions installs a specific version of a binary with additional options.
+// Cancelling ctx aborts any in-flight request, download or extraction and removes partial artifacts.
+// Once symlink and database updates have started they run to completion to keep state consistent.
+func InstallBinaryWithOptions(ctx context.Context, binaryID string, version string, dbService *repository.Service, opts InstallOptions) (*InstallBinaryResult, error) {
 	// Get the binary configuration
 	binaryConfig, err := dbService.Binaries.GetByUserID(binaryID)
 	if err != nil {
@@ -33,16 +61,34 @@ func InstallBinary(binaryID string, version string, dbService *repository.Servic
 	}
 
 	// Fetch release and asset information
-	release, asset, err := github.FetchReleaseAsset(binaryConfig, version)
+	release, asset, err := ResolveRelease(ctx, binaryConfig, version)
 	if err != nil {
 		return nil, fmt.Errorf("fetch failed: %w", err)
 	}
 
+	// Keep the active version if it is not older than the resolved release (e.g. a newer prerelease)
+	if opts.OnlyIfNewer {
+		active, err := activeInstallation(binaryConfig, dbService)
+		if err != nil {
+			return nil, err
+		}
+		if active != nil && CompareVersions(binaryConfig, active.Version, release.TagName) >= 0 {
+			log.Printf("Active version %s is up to date (latest: %s)", active.Version, release.TagName)
+			return &InstallBinaryResult{
+				Binary:           binaryConfig,
+				Installation:     active,
+				Version:          active.Version,
+				AlreadyInstalled: true,
+			}, nil
+		}
+	}
+
 	// Download the asset
-	downloadPath, err := github.DownloadAsset(binaryConfig.ProviderPath, asset.Id, asset.Name, binaryConfig.Authenticated)
+	downloadPath, err := github.DownloadAssetWithProgress(ctx, binaryConfig.ProviderPath, asset.Id, asset.Name, int64(asset.Size), binaryConfig.Authenticated, opts.Progress)
 	if err != nil {
 		return nil, fmt.Errorf("download failed: %w", err)
 	}
+	defer os.Remove(downloadPath)
 
 	// Verify downloaded archive checksum if digest is provided
 	if asset.Digest != "" {
@@ -52,10 +98,14 @@ func InstallBinary(binaryID string, version string, dbService *repository.Servic
 		log.Printf("✓ archive checksum verified")
 	}
 
-	// Resolve version (convert "latest" to actual tag name)
-	resolvedVersion := version
-	if version == "latest" {
-		resolvedVersion = release.TagName
+	// Record the release tag, converting "latest" and versions given without their tag prefix
+	resolvedVersion := release.TagName
+	if resolvedVersion == "" {
+		resolvedVersion = version
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("installation cancelled: %w", err)
 	}
 
 	// Check if this version is already installed
@@ -63,15 +113,21 @@ func InstallBinary(binaryID string, version string, dbService *repository.Servic
 	if err == nil {
 		log.Printf("Version %s already installed", resolvedVersion)
 
-		// Even if already installed, ensure it's set as active version
-		// Handle optional InstallPath
-		customInstallPath := ""
-		if binaryConfig.InstallPath != nil {
-			customInstallPath = *binaryConfig.InstallPath
+		result := &InstallBinaryResult{
+			Binary:           binaryConfig,
+			Installation:     existingInstallation,
+			Version:          resolvedVersion,
+			AlreadyInstalled: true,
+		}
+		if opts.NoActivate {
+			return result, nil
 		}
 
-		// Set as active version (create/update symlink)
-		symlinkPath, err := v.SetActiveVersion(existingInstallation.InstalledPath, customInstallPath, binaryConfig.Name, nil)
+		commitMu.Lock()
+		defer commitMu.Unlock()
+
+		// Even if already installed, ensure it's set as active version (create/update symlink or shim)
+		symlinkPath, err := v.LinkVersion(binaryConfig, existingInstallation.InstalledPath)
 		if err != nil {
 			return nil, fmt.Errorf("failed to set active version: %w", err)
 		}
@@ -81,17 +137,13 @@ func InstallBinary(binaryID string, version string, dbService *repository.Servic
 			return nil, fmt.Errorf("failed to save version: %w", err)
 		}
 
-		return &InstallBinaryResult{
-			Binary:       binaryConfig,
-			Installation: existingInstallation,
-			Version:      resolvedVersion,
-		}, nil
+		return result, nil
 	} else if err != database.ErrNotFound {
 		return nil, fmt.Errorf("failed to check existing installation: %w", err)
 	}
 
 	// Extract the asset
-	destPath, err := ExtractAsset(downloadPath, binaryConfig, resolvedVersion)
+	destPath, err := ExtractAsset(ctx, downloadPath, binaryConfig, resolvedVersion)
 	if err != nil {
 		return nil, fmt.Errorf("extraction failed: %w", err)
 	}
@@ -99,25 +151,34 @@ func InstallBinary(binaryID string, version string, dbService *repository.Servic
 	// Compute checksum of extracted binary
 	binaryChecksum, err := crypto.ComputeSHA256(destPath)
 	if err != nil {
+		removePartialInstall(destPath)
 		return nil, fmt.Errorf("failed to compute binary checksum: %w", err)
 	}
 
 	// Get file size of extracted binary
 	fileInfo, err := os.Stat(destPath)
 	if err != nil {
+		removePartialInstall(destPath)
 		return nil, fmt.Errorf("failed to get file info: %w", err)
 	}
 
-	// Handle optional InstallPath
-	customInstallPath := ""
-	if binaryConfig.InstallPath != nil {
-		customInstallPath = *binaryConfig.InstallPath
+	// Last point at which the install can be cancelled without leaving inconsistent state
+	if err := ctx.Err(); err != nil {
+		removePartialInstall(destPath)
+		return nil, fmt.Errorf("installation cancelled: %w", err)
 	}
 
-	// Set active version (create symlink)
-	symlinkPath, err := v.SetActiveVersion(destPath, customInstallPath, binaryConfig.Name, binaryConfig.Alias)
-	if err != nil {
-		return nil, fmt.Errorf("failed to set active version: %w", err)
+	commitMu.Lock()
+	defer commitMu.Unlock()
+
+	// Set active version (create symlink or shim)
+	symlinkPath := ""
+	if !opts.NoActivate {
+		symlinkPath, err = v.LinkVersion(binaryConfig, destPath)
+		if err != nil {
+			removePartialInstall(destPath)
+			return nil, fmt.Errorf("failed to set active version: %w", err)
+		}
 	}
 
 	// Create installation record
@@ -137,8 +198,10 @@ func InstallBinary(binaryID string, version string, dbService *repository.Servic
 	}
 
 	// Update active version
-	if err := dbService.Versions.Set(binaryConfig.ID, installation.ID, symlinkPath); err != nil {
-		return nil, fmt.Errorf("failed to save version: %w", err)
+	if !opts.NoActivate {
+		if err := dbService.Versions.Set(binaryConfig.ID, installation.ID, symlinkPath); err != nil {
+			return nil, fmt.Errorf("failed to save version: %w", err)
+		}
 	}
 
 	log.Printf("Successfully installed %s version %s", binaryID, resolvedVersion)
@@ -151,6 +214,104 @@ func InstallBinary(binaryID string, version string, dbService *repository.Servic
 }
 
 // UpdateToLatest updates a binary to the latest available version
-func UpdateToLatest(binaryID string, dbService *repository.Service) (*InstallBinaryResult, error) {
-	return InstallBinary(binaryID, "latest", dbService)
+func UpdateToLatest(ctx context.Context, binaryID string, dbService *repository.Service) (*InstallBinaryResult, error) {
+	return UpdateToLatestWithOptions(ctx, binaryID, dbService, InstallOptions{})
+}
+
+// UpdateToLatestWithOptions updates a binary to the latest available version with additional options
+// Updates never downgrade: the active version is kept if it is the same as or newer than the latest release.
+// Pinned binaries are not updated and return an error wrapping ErrPinned.
+func UpdateToLatestWithOptions(ctx context.Context, binaryID string, dbService *repository.Service, opts InstallOptions) (*InstallBinaryResult, error) {
+	binaryConfig, err := dbService.Binaries.GetByUserID(binaryID)
+	if err != nil {
+		return nil, fmt.Errorf("binary not found: %w", err)
+	}
+
+	pin, err := dbService.Pins.Get(binaryConfig.ID)
+	if err == nil {
+		return nil, fmt.Errorf("%w at %s, run 'binmate unpin --binary %s' to allow updates", ErrPinned, pin.Version, binaryID)
+	} else if err != database.ErrNotFound {
+		return nil, fmt.Errorf("failed to check pin: %w", err)
+	}
+
+	previous, err := activeInstallation(binaryConfig, dbService)
+	if err != nil {
+		return nil, err
+	}
+
+	opts.OnlyIfNewer = true
+	result, err := InstallBinaryWithOptions(ctx, binaryID, "latest", dbService, opts)
+	if err != nil {
+		return nil, err
+	}
+	if previous == nil || result.Installation.ID == previous.ID {
+		pruneOldVersions(binaryConfig, result, dbService)
+		return result, nil
+	}
+
+	// Roll back to the previously active version if the new version is broken
+	if checkErr := postInstallCheck(result.Installation); checkErr != nil {
+		commitMu.Lock()
+		defer commitMu.Unlock()
+
+		if err := v.ActivateInstallation(binaryConfig, previous, dbService); err != nil {
+			return nil, fmt.Errorf("post-install check failed for %s: %w (rollback to %s also failed: %v)", result.Version, checkErr, previous.Version, err)
+		}
+		if !result.AlreadyInstalled {
+			if err := dbService.Installations.Delete(result.Installation.ID); err != nil {
+				log.Printf("Warning: failed to remove installation record for %s: %v", result.Version, err)
+			}
+			removePartialInstall(result.Installation.InstalledPath)
+		}
+		return nil, fmt.Errorf("post-install check failed for %s, rolled back to %s: %w", result.Version, previous.Version, checkErr)
+	}
+
+	pruneOldVersions(binaryConfig, result, dbService)
+	return result, nil
+}
+
+// pruneOldVersions applies the binary's keepVersions setting after an update.
+// Failures are logged rather than returned as the update itself succeeded.
+func pruneOldVersions(binary *database.Binary, result *InstallBinaryResult, dbService *repository.Service) {
+	if binary.KeepVersions == nil || *binary.KeepVersions <= 0 {
+		return
+	}
+
+	pruned, err := v.PruneVersions(binary.UserID, *binary.KeepVersions, false, dbService)
+	if err != nil {
+		log.Printf("Warning: failed to prune old versions of %s: %v", binary.UserID, err)
+	}
+	result.Pruned = pruned
+}
+
+// postInstallCheck verifies a newly activated installation, replaced in tests
+var postInstallCheck = verifyInstallation
+
+// verifyInstallation checks that an installed binary is a non-empty regular file that can be executed
+func verifyInstallation(installation *database.Installation) error {
+	info, err := os.Stat(installation.InstalledPath)
+	if err != nil {
+		return fmt.Errorf("installed binary not found: %w", err)
+	}
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("installed binary %s is not a regular file", installation.InstalledPath)
+	}
+	if info.Size() == 0 {
+		return fmt.Errorf("installed binary %s is empty", installation.InstalledPath)
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0o111 == 0 {
+		return fmt.Errorf("installed binary %s is not executable", installation.InstalledPath)
+	}
+	return nil
+}
+
+// activeInstallation returns the active installation of a binary, or nil if none is active
+func activeInstallation(binary *database.Binary, dbService *repository.Service) (*database.Installation, error) {
+	_, installation, err := dbService.Versions.GetWithInstallation(binary.ID)
+	if err == database.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get active version: %w", err)
+	}
+	return installation, nil
 }
@@ -1,8 +1,11 @@
 package install
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"cturner8/binmate/internal/database"
@@ -44,7 +47,7 @@ func TestInstallBinary_BinaryNotFound(t *testing.T) {
 	dbService, cleanup := setupTestDB(t)
 	defer cleanup()
 
-	_, err := InstallBinary("nonexistent", "v1.0.0", dbService)
+	_, err := InstallBinary(context.Background(), "nonexistent", "v1.0.0", dbService)
 	if err == nil {
 		t.Error("Expected error for non-existent binary, got none")
 	}
@@ -66,7 +69,7 @@ func TestInstallBinary_UnsupportedProvider(t *testing.T) {
 		t.Fatalf("Failed to create test binary: %v", err)
 	}
 
-	_, err := InstallBinary("test", "v1.0.0", dbService)
+	_, err := InstallBinary(context.Background(), "test", "v1.0.0", dbService)
 	if err == nil {
 		t.Error("Expected error for unsupported provider, got none")
 	}
@@ -83,7 +86,7 @@ func TestInstallBinary_FetchFails(t *testing.T) {
 	createTestBinary(t, dbService, "test")
 
 	// This should fail at the fetch stage
-	_, err := InstallBinary("test", "v1.0.0", dbService)
+	_, err := InstallBinary(context.Background(), "test", "v1.0.0", dbService)
 	if err == nil {
 		t.Error("Expected error for fetch failure, got none")
 	}
@@ -97,7 +100,7 @@ func TestUpdateToLatest(t *testing.T) {
 	createTestBinary(t, dbService, "test")
 
 	// This should fail because we can't actually fetch from GitHub in tests
-	_, err := UpdateToLatest("test", dbService)
+	_, err := UpdateToLatest(context.Background(), "test", dbService)
 	if err == nil {
 		t.Error("Expected error (fetch failure), got none")
 	}
@@ -107,7 +110,7 @@ func TestUpdateToLatest_BinaryNotFound(t *testing.T) {
 	dbService, cleanup := setupTestDB(t)
 	defer cleanup()
 
-	_, err := UpdateToLatest("nonexistent", dbService)
+	_, err := UpdateToLatest(context.Background(), "nonexistent", dbService)
 	if err == nil {
 		t.Error("Expected error for non-existent binary, got none")
 	}
@@ -229,3 +232,494 @@ func TestInstallBinary_InstalledPathStoresActualBinaryPath(t *testing.T) {
 		t.Errorf("Symlink should point to binary path, got %s, want %s", target, binaryPath)
 	}
 }
+
+func TestInstallBinary_FromRelease(t *testing.T) {
+	fake := newFakeGitHub(t, fakeRelease{Tag: "v1.1.0"}, fakeRelease{Tag: "v1.0.0"})
+	dbService, cleanup := setupTestDB(t)
+	defer cleanup()
+	createTestBinary(t, dbService, "test")
+
+	result, err := InstallBinary(context.Background(), "test", "latest", dbService)
+	if err != nil {
+		t.Fatalf("InstallBinary() error = %v", err)
+	}
+	if result.Version != "v1.1.0" || result.AlreadyInstalled {
+		t.Errorf("result = %+v, want fresh install of v1.1.0", result)
+	}
+
+	content, err := os.ReadFile(result.Installation.InstalledPath)
+	if err != nil || string(content) != "v1.1.0" {
+		t.Errorf("installed binary content = %q, %v", content, err)
+	}
+	if downloads := fake.downloaded(); len(downloads) != 1 {
+		t.Errorf("downloads = %v, want 1", downloads)
+	}
+}
+
+func TestUpdateToLatest_DoesNotDowngrade(t *testing.T) {
+	tests := []struct {
+		name          string
+		activeVersion string
+		wantVersion   string
+		wantDownload  bool
+	}{
+		{name: "installs newer release", activeVersion: "v1.0.0", wantVersion: "v1.1.0", wantDownload: true},
+		{name: "keeps current release", activeVersion: "v1.1.0", wantVersion: "v1.1.0"},
+		{name: "keeps newer prerelease", activeVersion: "v2.0.0-rc.1", wantVersion: "v2.0.0-rc.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeGitHub(t,
+				fakeRelease{Tag: "v2.0.0-rc.1", Prerelease: true},
+				fakeRelease{Tag: "v1.1.0"},
+				fakeRelease{Tag: "v1.0.0"},
+			)
+			dbService, cleanup := setupTestDB(t)
+			defer cleanup()
+			createTestBinary(t, dbService, "test")
+
+			if _, err := InstallBinary(context.Background(), "test", tt.activeVersion, dbService); err != nil {
+				t.Fatalf("failed to install active version: %v", err)
+			}
+
+			result, err := UpdateToLatest(context.Background(), "test", dbService)
+			if err != nil {
+				t.Fatalf("UpdateToLatest() error = %v", err)
+			}
+			if result.Version != tt.wantVersion {
+				t.Errorf("Version = %s, want %s", result.Version, tt.wantVersion)
+			}
+
+			wantDownloads := 1
+			if tt.wantDownload {
+				wantDownloads = 2
+			}
+			if downloads := fake.downloaded(); len(downloads) != wantDownloads {
+				t.Errorf("downloads = %v, want %d", downloads, wantDownloads)
+			}
+		})
+	}
+}
+
+func TestInstallBinary_VersionConstraint(t *testing.T) {
+	releases := []fakeRelease{
+		{Tag: "v2.0.0"},
+		{Tag: "v1.5.0-rc.1", Prerelease: true},
+		{Tag: "v1.4.3"},
+		{Tag: "v1.5.0"},
+		{Tag: "v1.4.2"},
+	}
+
+	tests := []struct {
+		constraint  string
+		wantVersion string
+		wantErr     bool
+	}{
+		{constraint: "~1.4", wantVersion: "v1.4.3"},
+		{constraint: "^1.4", wantVersion: "v1.5.0"},
+		{constraint: ">=1.4.2 <1.4.3", wantVersion: "v1.4.2"},
+		{constraint: "", wantVersion: "v2.0.0"},
+		{constraint: "~3", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			newFakeGitHub(t, releases...)
+			dbService, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			binary := createTestBinary(t, dbService, "test")
+			if tt.constraint != "" {
+				binary.VersionConstraint = &tt.constraint
+				if err := dbService.Binaries.Update(binary); err != nil {
+					t.Fatalf("Failed to update binary: %v", err)
+				}
+			}
+
+			result, err := InstallBinary(context.Background(), "test", "latest", dbService)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("InstallBinary() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if result.Version != tt.wantVersion {
+				t.Errorf("Version = %s, want %s", result.Version, tt.wantVersion)
+			}
+		})
+	}
+}
+
+func TestInstallBinary_VersionConstraintBoundsScan(t *testing.T) {
+	releases := make([]fakeRelease, 700)
+	for i := range releases {
+		releases[i] = fakeRelease{Tag: fmt.Sprintf("v1.%d.0", len(releases)-1-i)}
+	}
+
+	tests := []struct {
+		constraint  string
+		wantVersion string
+		wantPages   int
+	}{
+		{constraint: "<1.650", wantVersion: "v1.649.0", wantPages: 2},
+		{constraint: "<1.550", wantVersion: "v1.549.0", wantPages: 3},
+		{constraint: "~2", wantPages: maxReleasePages},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			fake := newFakeGitHub(t, releases...)
+			dbService, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			binary := createTestBinary(t, dbService, "test")
+			binary.VersionConstraint = &tt.constraint
+			if err := dbService.Binaries.Update(binary); err != nil {
+				t.Fatalf("Failed to update binary: %v", err)
+			}
+
+			result, err := InstallBinary(context.Background(), "test", "latest", dbService)
+			if tt.wantVersion == "" {
+				if err == nil {
+					t.Fatalf("InstallBinary() = %s, want error", result.Version)
+				}
+			} else if err != nil {
+				t.Fatalf("InstallBinary() error = %v", err)
+			} else if result.Version != tt.wantVersion {
+				t.Errorf("Version = %s, want %s", result.Version, tt.wantVersion)
+			}
+
+			if got := fake.listedPages(); got != tt.wantPages {
+				t.Errorf("listed %d pages of releases, want %d", got, tt.wantPages)
+			}
+		})
+	}
+}
+
+func TestInstallBinary_NightlyBoundsScan(t *testing.T) {
+	releases := make([]fakeRelease, 700)
+	for i := range releases {
+		releases[i] = fakeRelease{Tag: fmt.Sprintf("v1.%d.0", len(releases)-1-i)}
+	}
+
+	tests := []struct {
+		name        string
+		nightly     int // Index of the nightly release, or -1 for none
+		wantVersion string
+		wantPages   int
+	}{
+		{name: "found", nightly: 150, wantVersion: "nightly", wantPages: 3},
+		{name: "not found", nightly: -1, wantPages: maxReleasePages},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listed := append([]fakeRelease(nil), releases...)
+			if tt.nightly >= 0 {
+				listed[tt.nightly] = fakeRelease{Tag: "nightly", Prerelease: true}
+			}
+			fake := newFakeGitHub(t, listed...)
+			dbService, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			binary := createTestBinary(t, dbService, "test")
+			channel := ChannelNightly
+			binary.Channel = &channel
+			if err := dbService.Binaries.Update(binary); err != nil {
+				t.Fatalf("Failed to update binary: %v", err)
+			}
+
+			result, err := InstallBinary(context.Background(), "test", "latest", dbService)
+			if tt.wantVersion == "" {
+				if err == nil || !strings.Contains(err.Error(), "pages of releases") {
+					t.Fatalf("InstallBinary() error = %v, want the pages scanned reported", err)
+				}
+			} else if err != nil {
+				t.Fatalf("InstallBinary() error = %v", err)
+			} else if result.Version != tt.wantVersion {
+				t.Errorf("Version = %s, want %s", result.Version, tt.wantVersion)
+			}
+
+			if got := fake.listedPages(); got != tt.wantPages {
+				t.Errorf("listed %d pages of releases, want %d", got, tt.wantPages)
+			}
+		})
+	}
+}
+
+func TestInstallBinary_ReleaseChannel(t *testing.T) {
+	releases := []fakeRelease{
+		{Tag: "nightly-20250102", Prerelease: true},
+		{Tag: "v2.0.0-rc.1", Prerelease: true},
+		{Tag: "nightly-20250101", Prerelease: true},
+		{Tag: "v1.5.0"},
+		{Tag: "v1.4.3"},
+	}
+
+	tests := []struct {
+		name         string
+		channel      string
+		constraint   string
+		nightlyRegex string
+		wantVersion  string
+		wantErr      bool
+	}{
+		{name: "stable", channel: "stable", wantVersion: "v1.5.0"},
+		{name: "prerelease", channel: "prerelease", wantVersion: "v2.0.0-rc.1"},
+		{name: "prerelease with constraint", channel: "prerelease", constraint: "~1.4", wantVersion: "v1.4.3"},
+		{name: "nightly", channel: "nightly", wantVersion: "nightly-20250102"},
+		{name: "nightly with custom pattern", channel: "nightly", nightlyRegex: `^nightly-20250101$`, wantVersion: "nightly-20250101"},
+		{name: "unsupported channel", channel: "beta", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newFakeGitHub(t, releases...)
+			dbService, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			binary := createTestBinary(t, dbService, "test")
+			binary.Channel = &tt.channel
+			if tt.constraint != "" {
+				binary.VersionConstraint = &tt.constraint
+			}
+			if tt.nightlyRegex != "" {
+				binary.NightlyRegex = &tt.nightlyRegex
+			}
+			if err := dbService.Binaries.Update(binary); err != nil {
+				t.Fatalf("Failed to update binary: %v", err)
+			}
+
+			result, err := InstallBinary(context.Background(), "test", "latest", dbService)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("InstallBinary() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if result.Version != tt.wantVersion {
+				t.Errorf("Version = %s, want %s", result.Version, tt.wantVersion)
+			}
+		})
+	}
+}
+
+func TestInstallBinary_TagPrefix(t *testing.T) {
+	// The repository's latest release belongs to another component
+	releases := []fakeRelease{
+		{Tag: "cli-v9.0.0"},
+		{Tag: "bun-v1.2.0"},
+		{Tag: "bun-v1.1.0"},
+	}
+
+	tests := []struct {
+		name         string
+		releaseRegex string
+		version      string
+		wantVersion  string
+		wantErr      bool
+	}{
+		{name: "latest scans for matching tags", releaseRegex: "^bun-v", version: "latest", wantVersion: "bun-v1.2.0"},
+		{name: "latest with capture group", releaseRegex: `^bun-v(.+)$`, version: "latest", wantVersion: "bun-v1.2.0"},
+		{name: "version without prefix", releaseRegex: "^bun-v", version: "1.1.0", wantVersion: "bun-v1.1.0"},
+		{name: "full tag", releaseRegex: "^bun-v", version: "bun-v1.1.0", wantVersion: "bun-v1.1.0"},
+		{name: "unknown version", releaseRegex: "^bun-v", version: "9.0.0", wantErr: true},
+		{name: "invalid pattern", releaseRegex: "(", version: "latest", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newFakeGitHub(t, releases...)
+			dbService, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			binary := createTestBinary(t, dbService, "test")
+			binary.ReleaseRegex = &tt.releaseRegex
+			if err := dbService.Binaries.Update(binary); err != nil {
+				t.Fatalf("Failed to update binary: %v", err)
+			}
+
+			result, err := InstallBinary(context.Background(), "test", tt.version, dbService)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("InstallBinary() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if result.Version != tt.wantVersion {
+				t.Errorf("Version = %s, want %s", result.Version, tt.wantVersion)
+			}
+			if result.Installation.Version != tt.wantVersion {
+				t.Errorf("Installation.Version = %s, want %s", result.Installation.Version, tt.wantVersion)
+			}
+			if got := DisplayVersion(binary, result.Version); got != strings.TrimPrefix(tt.wantVersion, "bun-v") {
+				t.Errorf("DisplayVersion() = %s, want %s", got, strings.TrimPrefix(tt.wantVersion, "bun-v"))
+			}
+		})
+	}
+}
+
+func TestInstallBinary_TagPrefixBoundsScan(t *testing.T) {
+	releases := make([]fakeRelease, 700)
+	for i := range releases {
+		releases[i] = fakeRelease{Tag: fmt.Sprintf("cli-v1.%d.0", len(releases)-1-i)}
+	}
+	fake := newFakeGitHub(t, releases...)
+	dbService, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	binary := createTestBinary(t, dbService, "test")
+	releaseRegex := "^bun-v"
+	binary.ReleaseRegex = &releaseRegex
+	if err := dbService.Binaries.Update(binary); err != nil {
+		t.Fatalf("Failed to update binary: %v", err)
+	}
+
+	_, err := InstallBinary(context.Background(), "test", "1.0.0", dbService)
+	if err == nil || !strings.Contains(err.Error(), "pages of releases") {
+		t.Fatalf("InstallBinary() error = %v, want the pages scanned reported", err)
+	}
+	if got := fake.listedPages(); got != maxReleasePages {
+		t.Errorf("listed %d pages of releases, want %d", got, maxReleasePages)
+	}
+}
+
+func TestUpdateToLatest_RollsBackFailedCheck(t *testing.T) {
+	newFakeGitHub(t, fakeRelease{Tag: "v1.1.0"}, fakeRelease{Tag: "v1.0.0"})
+	dbService, cleanup := setupTestDB(t)
+	defer cleanup()
+	binary := createTestBinary(t, dbService, "test")
+
+	if _, err := InstallBinary(context.Background(), "test", "v1.0.0", dbService); err != nil {
+		t.Fatalf("failed to install active version: %v", err)
+	}
+
+	postInstallCheck = func(installation *database.Installation) error {
+		return fmt.Errorf("%s is broken", installation.Version)
+	}
+	t.Cleanup(func() { postInstallCheck = verifyInstallation })
+
+	_, err := UpdateToLatest(context.Background(), "test", dbService)
+	if err == nil || !strings.Contains(err.Error(), "rolled back to v1.0.0") {
+		t.Fatalf("UpdateToLatest() error = %v, want rollback error", err)
+	}
+
+	_, active, err := dbService.Versions.GetWithInstallation(binary.ID)
+	if err != nil {
+		t.Fatalf("failed to get active version: %v", err)
+	}
+	if active.Version != "v1.0.0" {
+		t.Errorf("active version = %s, want v1.0.0", active.Version)
+	}
+
+	// The broken version is removed so the next update retries it
+	if _, err := dbService.Installations.Get(binary.ID, "v1.1.0"); err != database.ErrNotFound {
+		t.Errorf("Installations.Get(v1.1.0) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestInstallBinary_NoActivate(t *testing.T) {
+	newFakeGitHub(t, fakeRelease{Tag: "v1.1.0"}, fakeRelease{Tag: "v1.0.0"})
+	dbService, cleanup := setupTestDB(t)
+	defer cleanup()
+	binary := createTestBinary(t, dbService, "test")
+
+	if _, err := InstallBinary(context.Background(), "test", "v1.0.0", dbService); err != nil {
+		t.Fatalf("failed to install active version: %v", err)
+	}
+
+	opts := InstallOptions{NoActivate: true}
+	result, err := InstallBinaryWithOptions(context.Background(), "test", "v1.1.0", dbService, opts)
+	if err != nil {
+		t.Fatalf("InstallBinaryWithOptions() error = %v", err)
+	}
+	if result.Version != "v1.1.0" || result.Installation.ID == 0 {
+		t.Fatalf("result = %+v, want installed v1.1.0", result)
+	}
+
+	// Installing again finds the existing installation, still without activating it
+	result, err = InstallBinaryWithOptions(context.Background(), "test", "v1.1.0", dbService, opts)
+	if err != nil || !result.AlreadyInstalled {
+		t.Fatalf("InstallBinaryWithOptions() = %+v, %v, want already installed", result, err)
+	}
+
+	_, active, err := dbService.Versions.GetWithInstallation(binary.ID)
+	if err != nil {
+		t.Fatalf("failed to get active version: %v", err)
+	}
+	if active.Version != "v1.0.0" {
+		t.Errorf("active version = %s, want v1.0.0", active.Version)
+	}
+}
+
+func TestUpdateToLatest_PrunesOldVersions(t *testing.T) {
+	newFakeGitHub(t, fakeRelease{Tag: "v1.2.0"}, fakeRelease{Tag: "v1.1.0"}, fakeRelease{Tag: "v1.0.0"})
+	dbService, cleanup := setupTestDB(t)
+	defer cleanup()
+	binary := createTestBinary(t, dbService, "test")
+
+	keep := 2
+	binary.KeepVersions = &keep
+	if err := dbService.Binaries.Update(binary); err != nil {
+		t.Fatalf("failed to update binary: %v", err)
+	}
+
+	var oldest *InstallBinaryResult
+	for _, version := range []string{"v1.0.0", "v1.1.0"} {
+		result, err := InstallBinary(context.Background(), "test", version, dbService)
+		if err != nil {
+			t.Fatalf("failed to install %s: %v", version, err)
+		}
+		if oldest == nil {
+			oldest = result
+		}
+	}
+
+	result, err := UpdateToLatest(context.Background(), "test", dbService)
+	if err != nil {
+		t.Fatalf("UpdateToLatest() error = %v", err)
+	}
+	if len(result.Pruned) != 1 || result.Pruned[0].Version != "v1.0.0" {
+		t.Fatalf("Pruned = %v, want [v1.0.0]", result.Pruned)
+	}
+
+	if _, err := dbService.Installations.Get(binary.ID, "v1.0.0"); err != database.ErrNotFound {
+		t.Errorf("Installations.Get(v1.0.0) error = %v, want ErrNotFound", err)
+	}
+	if _, err := os.Stat(oldest.Installation.InstalledPath); !os.IsNotExist(err) {
+		t.Errorf("expected v1.0.0 files to be removed, got: %v", err)
+	}
+	if _, err := dbService.Installations.Get(binary.ID, "v1.1.0"); err != nil {
+		t.Errorf("expected v1.1.0 to be kept: %v", err)
+	}
+}
+
+func TestVerifyInstallation(t *testing.T) {
+	dir := t.TempDir()
+
+	executable := filepath.Join(dir, "executable")
+	os.WriteFile(executable, []byte("binary"), 0o755)
+	empty := filepath.Join(dir, "empty")
+	os.WriteFile(empty, nil, 0o755)
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr bool
+	}{
+		{name: "executable file", path: executable},
+		{name: "empty file", path: empty, wantErr: true},
+		{name: "missing file", path: filepath.Join(dir, "missing"), wantErr: true},
+		{name: "directory", path: dir, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyInstallation(&database.Installation{InstalledPath: tt.path})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("verifyInstallation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
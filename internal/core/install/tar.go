@@ -10,9 +10,9 @@ import (
 )
 
 // extractTar extracts the specified binary from a tar.gz archive into destDir.
-// It searches for the binary by name within the archive (including subdirectories)
-// and extracts only that file to destDir/binaryName.
-func extractTar(srcTar string, destDir string, binaryName string) (string, error) {
+// It searches for the binary by name within the archive (including subdirectories),
+// or at binPath if set, and extracts only that file to destDir/binaryName.
+func extractTar(srcTar string, destDir string, binaryName string, binPath string) (string, error) {
 	if destDir == "" {
 		return "", fmt.Errorf("destination directory is required")
 	}
@@ -50,7 +50,7 @@ func extractTar(srcTar string, destDir string, binaryName string) (string, error
 		}
 
 		// Check if this is the binary we're looking for (could be in subdirectories like bin/gh)
-		if filepath.Base(header.Name) == binaryName && header.Typeflag == tar.TypeReg {
+		if matchesBinary(header.Name, binaryName, binPath) && header.Typeflag == tar.TypeReg {
 			targetPath := filepath.Join(destDir, binaryName)
 			if err := extractTarBinary(tr, header, targetPath); err != nil {
 				return "", err
@@ -59,6 +59,9 @@ func extractTar(srcTar string, destDir string, binaryName string) (string, error
 		}
 	}
 
+	if binPath != "" {
+		return "", fmt.Errorf("binary %s not found in archive at %s", binaryName, binPath)
+	}
 	return "", fmt.Errorf("binary %s not found in archive", binaryName)
 }
 
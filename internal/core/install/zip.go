@@ -9,9 +9,9 @@ import (
 )
 
 // extractZip extracts the specified binary from a ZIP archive into destDir.
-// It searches for the binary by name within the archive (including subdirectories)
-// and extracts only that file to destDir/binaryName.
-func extractZip(srcZip string, destDir string, binaryName string) (string, error) {
+// It searches for the binary by name within the archive (including subdirectories),
+// or at binPath if set, and extracts only that file to destDir/binaryName.
+func extractZip(srcZip string, destDir string, binaryName string, binPath string) (string, error) {
 	if destDir == "" {
 		return "", fmt.Errorf("destination directory is required")
 	}
@@ -33,7 +33,7 @@ func extractZip(srcZip string, destDir string, binaryName string) (string, error
 	// Search for the binary in the archive
 	for _, f := range r.File {
 		// Check if this is the binary we're looking for (could be in subdirectories like bin/gh)
-		if filepath.Base(f.Name) == binaryName && !f.FileInfo().IsDir() {
+		if matchesBinary(f.Name, binaryName, binPath) && !f.FileInfo().IsDir() {
 			targetPath := filepath.Join(destDir, binaryName)
 			if err := extractZipBinary(f, targetPath); err != nil {
 				return "", err
@@ -42,6 +42,9 @@ func extractZip(srcZip string, destDir string, binaryName string) (string, error
 		}
 	}
 
+	if binPath != "" {
+		return "", fmt.Errorf("binary %s not found in archive at %s", binaryName, binPath)
+	}
 	return "", fmt.Errorf("binary %s not found in archive", binaryName)
 }
 
@@ -0,0 +1,39 @@
+package parallel
+
+import "sync"
+
+// Map calls fn for every item using at most workers goroutines.
+// Results are returned in the same order as items regardless of completion order.
+func Map[T, R any](items []T, workers int, fn func(T) R) []R {
+	results := make([]R, len(items))
+	if len(items) == 0 {
+		return results
+	}
+
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(items) {
+		workers = len(items)
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = fn(items[i])
+			}
+		}()
+	}
+
+	for i := range items {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
@@ -0,0 +1,62 @@
+package parallel
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMap(t *testing.T) {
+	tests := []struct {
+		name    string
+		items   []int
+		workers int
+	}{
+		{name: "empty input", items: []int{}, workers: 4},
+		{name: "single worker", items: []int{1, 2, 3}, workers: 1},
+		{name: "more workers than items", items: []int{1, 2, 3}, workers: 10},
+		{name: "zero workers defaults to one", items: []int{1, 2, 3}, workers: 0},
+		{name: "many items", items: []int{5, 4, 3, 2, 1, 0, 9, 8, 7, 6}, workers: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Map(tt.items, tt.workers, func(i int) int {
+				// Finish later items first to check ordering is preserved
+				time.Sleep(time.Duration(10-i) * time.Millisecond)
+				return i * 2
+			})
+
+			if len(got) != len(tt.items) {
+				t.Fatalf("len = %d, want %d", len(got), len(tt.items))
+			}
+			for i, item := range tt.items {
+				if got[i] != item*2 {
+					t.Errorf("result[%d] = %d, want %d", i, got[i], item*2)
+				}
+			}
+		})
+	}
+}
+
+func TestMapBoundsConcurrency(t *testing.T) {
+	var running, peak atomic.Int32
+	items := make([]int, 20)
+
+	Map(items, 3, func(int) struct{} {
+		current := running.Add(1)
+		for {
+			p := peak.Load()
+			if current <= p || peak.CompareAndSwap(p, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		running.Add(-1)
+		return struct{}{}
+	})
+
+	if peak.Load() > 3 {
+		t.Errorf("peak concurrency = %d, want <= 3", peak.Load())
+	}
+}
@@ -0,0 +1,64 @@
+package progress
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-isatty"
+
+	"cturner8/binmate/internal/core/format"
+)
+
+// barWidth is the number of characters used for the bar itself
+const barWidth = 30
+
+// NewBar returns a Func rendering a single-line progress bar to w.
+// It returns nil when w is not a terminal so piped output stays clean.
+func NewBar(w io.Writer, label string) Func {
+	file, ok := w.(*os.File)
+	if !ok || !(isatty.IsTerminal(file.Fd()) || isatty.IsCygwinTerminal(file.Fd())) {
+		return nil
+	}
+
+	return func(p Progress) {
+		fmt.Fprintf(w, "\r\033[K%s", RenderLine(label, p))
+		if p.Done {
+			fmt.Fprintln(w)
+		}
+	}
+}
+
+// RenderLine formats progress as a text line, e.g.
+// "gh [=========>          ]  45% 12.0 MB/27.0 MB 3.2 MB/s ETA 5s"
+func RenderLine(label string, p Progress) string {
+	var b strings.Builder
+	if label != "" {
+		b.WriteString(label)
+		b.WriteString(" ")
+	}
+
+	if p.Total > 0 {
+		filled := int(p.Fraction() * barWidth)
+		b.WriteString("[")
+		b.WriteString(strings.Repeat("=", filled))
+		if filled < barWidth {
+			b.WriteString(">")
+			b.WriteString(strings.Repeat(" ", barWidth-filled-1))
+		}
+		b.WriteString("] ")
+		fmt.Fprintf(&b, "%3.0f%% %s/%s", p.Fraction()*100, format.FormatBytes(p.Downloaded), format.FormatBytes(p.Total))
+	} else {
+		b.WriteString(format.FormatBytes(p.Downloaded))
+	}
+
+	if p.Rate > 0 {
+		fmt.Fprintf(&b, " %s/s", format.FormatBytes(int64(p.Rate)))
+	}
+	if p.ETA > 0 && !p.Done {
+		fmt.Fprintf(&b, " ETA %s", p.ETA.Round(time.Second))
+	}
+	return b.String()
+}
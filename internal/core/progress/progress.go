@@ -0,0 +1,116 @@
+package progress
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// reportInterval is the minimum time between progress callbacks
+const reportInterval = 100 * time.Millisecond
+
+// Progress describes the state of a transfer
+type Progress struct {
+	Downloaded int64         // Bytes transferred so far
+	Total      int64         // Expected total bytes, 0 if unknown
+	Rate       float64       // Average transfer rate in bytes per second
+	ETA        time.Duration // Estimated time remaining, 0 if unknown
+	Done       bool          // True for the final report once the transfer has completed
+}
+
+// Fraction returns the completed fraction between 0 and 1, or 0 if the total is unknown
+func (p Progress) Fraction() float64 {
+	if p.Total <= 0 {
+		return 0
+	}
+	fraction := float64(p.Downloaded) / float64(p.Total)
+	if fraction > 1 {
+		return 1
+	}
+	return fraction
+}
+
+// Func receives progress updates during a transfer
+type Func func(Progress)
+
+// Reader wraps an io.Reader and reports progress as data is read
+type Reader struct {
+	reader     io.Reader
+	onProgress Func
+	total      int64
+	downloaded int64
+	start      time.Time
+	lastReport time.Time
+	now        func() time.Time
+	mu         sync.Mutex
+}
+
+// NewReader returns a Reader reporting progress of reads from r to onProgress.
+// A nil onProgress disables reporting.
+func NewReader(r io.Reader, total int64, onProgress Func) *Reader {
+	now := time.Now
+	return &Reader{
+		reader:     r,
+		onProgress: onProgress,
+		total:      total,
+		start:      now(),
+		now:        now,
+	}
+}
+
+func (r *Reader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if r.onProgress == nil {
+		return n, err
+	}
+
+	r.mu.Lock()
+	r.downloaded += int64(n)
+	current := r.now()
+	report := current.Sub(r.lastReport) >= reportInterval
+	if report {
+		r.lastReport = current
+	}
+	snapshot := r.snapshot(current)
+	r.mu.Unlock()
+
+	if report && err != io.EOF {
+		r.onProgress(snapshot)
+	}
+	return n, err
+}
+
+// Finish sends a final report marking the transfer as complete
+func (r *Reader) Finish() {
+	if r.onProgress == nil {
+		return
+	}
+
+	r.mu.Lock()
+	snapshot := r.snapshot(r.now())
+	r.mu.Unlock()
+
+	snapshot.Done = true
+	snapshot.ETA = 0
+	if snapshot.Total <= 0 {
+		snapshot.Total = snapshot.Downloaded
+	}
+	r.onProgress(snapshot)
+}
+
+// snapshot builds a Progress value, the caller must hold r.mu
+func (r *Reader) snapshot(current time.Time) Progress {
+	p := Progress{
+		Downloaded: r.downloaded,
+		Total:      r.total,
+	}
+
+	elapsed := current.Sub(r.start).Seconds()
+	if elapsed > 0 {
+		p.Rate = float64(r.downloaded) / elapsed
+	}
+	if p.Rate > 0 && r.total > r.downloaded {
+		p.ETA = time.Duration(float64(r.total-r.downloaded) / p.Rate * float64(time.Second))
+	}
+	return p
+}
@@ -0,0 +1,141 @@
+package progress
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReader(t *testing.T) {
+	data := strings.Repeat("x", 1000)
+
+	var updates []Progress
+	reader := NewReader(strings.NewReader(data), int64(len(data)), func(p Progress) {
+		updates = append(updates, p)
+	})
+
+	// Advance a fake clock past the report interval on every read
+	current := reader.start
+	reader.now = func() time.Time {
+		current = current.Add(reportInterval)
+		return current
+	}
+
+	buf := make([]byte, 250)
+	for {
+		_, err := reader.Read(buf)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Read() error = %v", err)
+		}
+	}
+	reader.Finish()
+
+	if len(updates) < 2 {
+		t.Fatalf("expected multiple progress updates, got %d", len(updates))
+	}
+
+	first := updates[0]
+	if first.Downloaded != 250 || first.Total != 1000 {
+		t.Errorf("first update = %+v, want 250/1000 bytes", first)
+	}
+	if first.Rate <= 0 || first.ETA <= 0 {
+		t.Errorf("first update should have rate and ETA, got %+v", first)
+	}
+
+	last := updates[len(updates)-1]
+	if !last.Done || last.Downloaded != 1000 || last.ETA != 0 {
+		t.Errorf("final update = %+v, want done with 1000 bytes", last)
+	}
+	if last.Fraction() != 1 {
+		t.Errorf("final fraction = %v, want 1", last.Fraction())
+	}
+}
+
+func TestReaderThrottlesUpdates(t *testing.T) {
+	count := 0
+	reader := NewReader(bytes.NewReader(make([]byte, 100)), 100, func(Progress) { count++ })
+
+	// Clock never advances, so only the first read is reported
+	reader.now = func() time.Time { return reader.start.Add(reportInterval) }
+
+	buf := make([]byte, 10)
+	for {
+		if _, err := reader.Read(buf); err != nil {
+			break
+		}
+	}
+
+	if count != 1 {
+		t.Errorf("expected 1 throttled update, got %d", count)
+	}
+}
+
+func TestReaderUnknownTotal(t *testing.T) {
+	var final Progress
+	reader := NewReader(strings.NewReader("hello"), 0, func(p Progress) { final = p })
+	io.ReadAll(reader)
+	reader.Finish()
+
+	if final.Total != 5 || final.Fraction() != 1 {
+		t.Errorf("final update = %+v, want total set to downloaded size", final)
+	}
+}
+
+func TestReaderNilFunc(t *testing.T) {
+	reader := NewReader(strings.NewReader("hello"), 5, nil)
+	got, err := io.ReadAll(reader)
+	if err != nil || string(got) != "hello" {
+		t.Errorf("ReadAll() = %q, %v", got, err)
+	}
+	reader.Finish()
+}
+
+func TestRenderLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		progress Progress
+		want     []string
+	}{
+		{
+			name:     "known total",
+			progress: Progress{Downloaded: 512 * 1024, Total: 1024 * 1024, Rate: 1024, ETA: 3 * time.Second},
+			want:     []string{"gh [", " 50%", "512.0 KB/1.0 MB", "1.0 KB/s", "ETA 3s"},
+		},
+		{
+			name:     "unknown total",
+			progress: Progress{Downloaded: 2048},
+			want:     []string{"gh 2.0 KB"},
+		},
+		{
+			name:     "done hides ETA",
+			progress: Progress{Downloaded: 10, Total: 10, ETA: time.Second, Done: true},
+			want:     []string{"100%"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RenderLine("gh", tt.progress)
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("RenderLine() = %q, want to contain %q", got, want)
+				}
+			}
+			if tt.progress.Done && strings.Contains(got, "ETA") {
+				t.Errorf("RenderLine() = %q, should not show ETA when done", got)
+			}
+		})
+	}
+}
+
+func TestNewBarSilentWhenNotTerminal(t *testing.T) {
+	var buf bytes.Buffer
+	if bar := NewBar(&buf, "gh"); bar != nil {
+		t.Error("NewBar() should return nil for non-terminal writers")
+	}
+}
@@ -0,0 +1,38 @@
+package runner
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+)
+
+// ExitError reports a non-zero exit code from a command run on the user's behalf,
+// which binmate exits with in turn
+type ExitError struct {
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.Code)
+}
+
+// Run executes a binary with the given arguments and standard streams, waiting for it to exit.
+// A non-zero exit code is returned as an *ExitError.
+func Run(path string, args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	// The child shares binmate's terminal, so it receives interrupts directly and is not
+	// tied to binmate's context, which is cancelled on the same signals
+	cmd := exec.Command(path, args...)
+	cmd.Stdin = stdin
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return &ExitError{Code: exitErr.ExitCode()}
+	} else if err != nil {
+		return fmt.Errorf("failed to run %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package runner
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell scripts are not executable on windows")
+	}
+
+	script := filepath.Join(t.TempDir(), "tool")
+	content := "#!/bin/sh\nread line\necho \"out $1 $line\"\necho err >&2\nexit $2\n"
+	if err := os.WriteFile(script, []byte(content), 0o755); err != nil {
+		t.Fatalf("failed to write script: %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	err := Run(script, []string{"arg", "3"}, strings.NewReader("input\n"), &stdout, &stderr)
+
+	var exitErr *ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != 3 {
+		t.Fatalf("Run() error = %v, want exit status 3", err)
+	}
+	if stdout.String() != "out arg input\n" {
+		t.Errorf("stdout = %q, want %q", stdout.String(), "out arg input\n")
+	}
+	if stderr.String() != "err\n" {
+		t.Errorf("stderr = %q, want %q", stderr.String(), "err\n")
+	}
+
+	if err := Run(script, []string{"arg", "0"}, strings.NewReader("input\n"), &stdout, &stderr); err != nil {
+		t.Errorf("Run() error = %v, want nil", err)
+	}
+
+	if err := Run(filepath.Join(t.TempDir(), "missing"), nil, nil, &stdout, &stderr); err == nil || errors.As(err, &exitErr) {
+		t.Errorf("Run() error = %v, want start failure", err)
+	}
+}
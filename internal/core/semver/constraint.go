@@ -0,0 +1,271 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Constraint is a parsed version constraint such as "~1.4", "^2", ">=2.40 <3" or "1.x || >=3".
+// Comparators separated by spaces or commas must all match; "||" separates alternatives.
+type Constraint struct {
+	raw  string
+	sets [][]comparator
+}
+
+// comparator matches versions within [lower, upper); a nil bound is unbounded
+type comparator struct {
+	lower, upper                   *Version
+	lowerInclusive, upperInclusive bool
+	negate                         bool // Matches versions outside the range, used by "!="
+	prerelease                     *Version
+}
+
+var comparatorRegex = regexp.MustCompile(`(!=|>=|<=|>|<|=|~|\^)?\s*([vV]?[0-9xX*][^\s,<>=!~^|]*)`)
+
+// ParseConstraint parses a version constraint.
+//
+// Supported operators are =, !=, >, >=, <, <=, ~ and ^. Partial versions ("1.4")
+// and wildcards ("1.x", "*") match every version they cover. Tilde allows patch
+// updates ("~1.4.2" is >=1.4.2 <1.5.0) and caret allows updates that do not change
+// the left-most non-zero segment ("^0.2.3" is >=0.2.3 <0.3.0).
+func ParseConstraint(raw string) (Constraint, error) {
+	c := Constraint{raw: raw}
+
+	for _, set := range strings.Split(raw, "||") {
+		comparators, err := parseComparatorSet(set)
+		if err != nil {
+			return Constraint{}, fmt.Errorf("invalid version constraint '%s': %w", raw, err)
+		}
+		c.sets = append(c.sets, comparators)
+	}
+
+	return c, nil
+}
+
+// String returns the constraint as written
+func (c Constraint) String() string {
+	return c.raw
+}
+
+// Check reports whether v satisfies the constraint. Prerelease versions only
+// match when a comparator in the same set names a prerelease of the same release,
+// so "~1.4" never selects "1.5.0-rc.1".
+func (c Constraint) Check(v Version) bool {
+	return c.check(v, false)
+}
+
+// CheckPrerelease reports whether v satisfies the constraint, allowing prereleases
+// of any release within range, e.g. "~1.4" selects "1.4.5-rc.1" but not "1.5.0-rc.1"
+func (c Constraint) CheckPrerelease(v Version) bool {
+	return c.check(v, true)
+}
+
+func (c Constraint) check(v Version, includePrerelease bool) bool {
+	for _, set := range c.sets {
+		if setMatches(set, v, includePrerelease) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckString parses a version and reports whether it satisfies the constraint
+func (c Constraint) CheckString(version string) bool {
+	v, err := Parse(version)
+	if err != nil {
+		return false
+	}
+	return c.Check(v)
+}
+
+func parseComparatorSet(set string) ([]comparator, error) {
+	set = strings.TrimSpace(set)
+	if set == "" {
+		return nil, fmt.Errorf("empty comparator set")
+	}
+
+	var comparators []comparator
+	end := 0
+	for _, loc := range comparatorRegex.FindAllStringSubmatchIndex(set, -1) {
+		if gap := strings.Trim(set[end:loc[0]], " \t,"); gap != "" {
+			return nil, fmt.Errorf("unexpected '%s'", gap)
+		}
+		end = loc[1]
+
+		op := ""
+		if loc[2] >= 0 {
+			op = set[loc[2]:loc[3]]
+		}
+		comp, err := newComparator(op, set[loc[4]:loc[5]])
+		if err != nil {
+			return nil, err
+		}
+		comparators = append(comparators, comp)
+	}
+	if gap := strings.Trim(set[end:], " \t,"); gap != "" {
+		return nil, fmt.Errorf("unexpected '%s'", gap)
+	}
+
+	return comparators, nil
+}
+
+// newComparator expands an operator and a possibly partial version into a range
+func newComparator(op, raw string) (comparator, error) {
+	v, specified, err := parsePartial(raw)
+	if err != nil {
+		return comparator{}, err
+	}
+
+	var comp comparator
+	if v.IsPrerelease() {
+		comp.prerelease = &v
+	}
+
+	// Wildcards match everything, whatever the operator
+	if specified == 0 {
+		if op == "<" || op == ">" || op == "!=" {
+			comp.negate = true
+		}
+		return comp, nil
+	}
+
+	// A partial version covers a range of releases, e.g. "1.4" is [1.4.0, 1.5.0)
+	partial := specified < 3
+	next := bump(v, specified-1)
+
+	switch op {
+	case "", "=":
+		if partial {
+			return withRange(comp, &v, true, &next, false), nil
+		}
+		return withRange(comp, &v, true, &v, true), nil
+	case "!=":
+		comp.negate = true
+		if partial {
+			return withRange(comp, &v, true, &next, false), nil
+		}
+		return withRange(comp, &v, true, &v, true), nil
+	case ">":
+		if partial {
+			return withRange(comp, &next, true, nil, false), nil
+		}
+		return withRange(comp, &v, false, nil, false), nil
+	case ">=":
+		return withRange(comp, &v, true, nil, false), nil
+	case "<":
+		return withRange(comp, nil, false, &v, false), nil
+	case "<=":
+		if partial {
+			return withRange(comp, nil, false, &next, false), nil
+		}
+		return withRange(comp, nil, false, &v, true), nil
+	case "~":
+		upper := bump(v, min(specified-1, 1))
+		return withRange(comp, &v, true, &upper, false), nil
+	case "^":
+		index := specified - 1
+		for i := 0; i < specified; i++ {
+			if v.Segments[i] != 0 {
+				index = i
+				break
+			}
+		}
+		upper := bump(v, index)
+		return withRange(comp, &v, true, &upper, false), nil
+	}
+
+	return comparator{}, fmt.Errorf("unsupported operator '%s'", op)
+}
+
+func withRange(comp comparator, lower *Version, lowerInclusive bool, upper *Version, upperInclusive bool) comparator {
+	comp.lower, comp.lowerInclusive = lower, lowerInclusive
+	comp.upper, comp.upperInclusive = upper, upperInclusive
+	return comp
+}
+
+// parsePartial parses a version that may omit trailing segments or use x/X/* wildcards,
+// returning the number of segments that were specified
+func parsePartial(raw string) (Version, int, error) {
+	v := Version{Original: raw}
+	s := strings.TrimPrefix(strings.TrimPrefix(raw, "v"), "V")
+
+	if i := strings.Index(s, "+"); i >= 0 {
+		v.Build = s[i+1:]
+		s = s[:i]
+	}
+	if i := strings.Index(s, "-"); i >= 0 {
+		if s[i+1:] == "" {
+			return Version{}, 0, fmt.Errorf("invalid version '%s'", raw)
+		}
+		v.Prerelease = strings.Split(s[i+1:], ".")
+		s = s[:i]
+	}
+
+	specified := 0
+	for _, part := range strings.Split(s, ".") {
+		if part == "x" || part == "X" || part == "*" {
+			break
+		}
+		n, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			return Version{}, 0, fmt.Errorf("invalid version '%s'", raw)
+		}
+		v.Segments = append(v.Segments, n)
+		specified++
+	}
+
+	if specified == 0 {
+		v.Prerelease = nil
+	}
+	return v, specified, nil
+}
+
+// bump returns the first release after every version sharing v's segments up to index
+func bump(v Version, index int) Version {
+	segments := make([]int64, index+1)
+	copy(segments, v.Segments)
+	segments[index]++
+	return Version{Segments: segments}
+}
+
+func (c comparator) matches(v Version) bool {
+	in := true
+	if c.lower != nil {
+		cmp := v.Compare(*c.lower)
+		in = cmp > 0 || (cmp == 0 && c.lowerInclusive)
+	}
+	if in && c.upper != nil {
+		cmp := v.Compare(*c.upper)
+		in = cmp < 0 || (cmp == 0 && c.upperInclusive)
+		// "<1.5.0" excludes prereleases of 1.5.0, which would otherwise sort below the bound
+		if in && !c.upperInclusive && v.IsPrerelease() && !c.upper.IsPrerelease() && sameRelease(v, *c.upper) {
+			in = false
+		}
+	}
+	return in != c.negate
+}
+
+func setMatches(set []comparator, v Version, includePrerelease bool) bool {
+	for _, comp := range set {
+		if !comp.matches(v) {
+			return false
+		}
+	}
+
+	if includePrerelease || !v.IsPrerelease() {
+		return true
+	}
+	for _, comp := range set {
+		if comp.prerelease != nil && sameRelease(*comp.prerelease, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameRelease reports whether two versions share their numeric segments
+func sameRelease(a, b Version) bool {
+	return Version{Segments: a.Segments}.Compare(Version{Segments: b.Segments}) == 0
+}
@@ -0,0 +1,101 @@
+package semver
+
+import "testing"
+
+func TestConstraintCheck(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		want       bool
+	}{
+		{constraint: "~1.4", version: "v1.4.0", want: true},
+		{constraint: "~1.4", version: "v1.4.9", want: true},
+		{constraint: "~1.4", version: "v1.5.0", want: false},
+		{constraint: "~1.4.2", version: "1.4.1", want: false},
+		{constraint: "~1", version: "1.9.0", want: true},
+		{constraint: "^1.2.3", version: "1.9.0", want: true},
+		{constraint: "^1.2.3", version: "2.0.0", want: false},
+		{constraint: "^0.2.3", version: "0.2.9", want: true},
+		{constraint: "^0.2.3", version: "0.3.0", want: false},
+		{constraint: "^0.0.3", version: "0.0.4", want: false},
+		{constraint: ">=2.40 <3", version: "v2.45.0", want: true},
+		{constraint: ">=2.40 <3", version: "v2.39.1", want: false},
+		{constraint: ">=2.40 <3", version: "v3.0.0", want: false},
+		{constraint: ">=2.40, <3", version: "v2.40.0", want: true},
+		{constraint: ">= 2.40 < 3", version: "v2.50.0", want: true},
+		{constraint: ">1.4", version: "1.4.9", want: false},
+		{constraint: ">1.4", version: "1.5.0", want: true},
+		{constraint: "<=1.4", version: "1.4.9", want: true},
+		{constraint: "1.2", version: "1.2.7", want: true},
+		{constraint: "1.2.3", version: "1.2.4", want: false},
+		{constraint: "=1.2.3", version: "v1.2.3", want: true},
+		{constraint: "!=1.2.3", version: "1.2.3", want: false},
+		{constraint: "!=1.2", version: "1.3.0", want: true},
+		{constraint: "1.x", version: "1.8.0", want: true},
+		{constraint: "1.x", version: "2.0.0", want: false},
+		{constraint: "*", version: "9.9.9", want: true},
+		{constraint: "1.x || >=3", version: "3.1.0", want: true},
+		{constraint: "1.x || >=3", version: "2.1.0", want: false},
+		{constraint: "~1.4", version: "1.4.5-rc.1", want: false},
+		{constraint: ">=2.0.0-rc.1", version: "2.0.0-rc.2", want: true},
+		{constraint: ">=2.0.0-rc.1", version: "2.1.0-rc.1", want: false},
+		{constraint: ">=2.0.0-rc.1", version: "2.1.0", want: true},
+		{constraint: ">=2024.1", version: "2024.03.01", want: true},
+		{constraint: "~1.4", version: "nightly", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraint+" "+tt.version, func(t *testing.T) {
+			c, err := ParseConstraint(tt.constraint)
+			if err != nil {
+				t.Fatalf("ParseConstraint() error = %v", err)
+			}
+			if got := c.CheckString(tt.version); got != tt.want {
+				t.Errorf("CheckString(%s) = %v, want %v", tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseConstraint_Invalid(t *testing.T) {
+	tests := []string{"", "latest", "~>1.2", ">=1.2 ||", "1.2.a", ">=1.2 foo"}
+
+	for _, input := range tests {
+		t.Run(input, func(t *testing.T) {
+			if _, err := ParseConstraint(input); err == nil {
+				t.Errorf("ParseConstraint(%q) expected error", input)
+			}
+		})
+	}
+}
+
+func TestConstraintCheckPrerelease(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		want       bool
+	}{
+		{constraint: "~1.4", version: "1.4.5-rc.1", want: true},
+		{constraint: "~1.4", version: "1.5.0-rc.1", want: false},
+		{constraint: ">=2.40 <3", version: "v3.0.0-beta.1", want: false},
+		{constraint: ">=2.40 <3", version: "v2.41.0-rc.2", want: true},
+		{constraint: "^1.2.3", version: "1.2.3-rc.1", want: false},
+		{constraint: "*", version: "0.1.0-alpha", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraint+" "+tt.version, func(t *testing.T) {
+			c, err := ParseConstraint(tt.constraint)
+			if err != nil {
+				t.Fatalf("ParseConstraint() error = %v", err)
+			}
+			v, err := Parse(tt.version)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if got := c.CheckPrerelease(v); got != tt.want {
+				t.Errorf("CheckPrerelease(%s) = %v, want %v", tt.version, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Version is a parsed release version.
+// Semantic versions ("v1.2.3-rc.1+build"), shorter forms ("1.2") and
+// calendar/numeric versions ("2024.01.15", "2024-01-15", "20240115") are supported.
+type Version struct {
+	Original   string   // Unmodified input
+	Segments   []int64  // Numeric release segments, e.g. [1 2 3]
+	Prerelease []string // Dot-separated prerelease identifiers, e.g. ["rc" "1"]
+	Build      string   // Build metadata, ignored when comparing
+}
+
+var (
+	coreRegex     = regexp.MustCompile(`^(\d+(?:\.\d+)*)(.*)$`)
+	calendarRegex = regexp.MustCompile(`^(?:-\d+)+$`)
+)
+
+// Parse parses a version string. Any non-numeric prefix such as "v", "release-"
+// or "bun-v" is ignored, so tags can be compared without further processing.
+func Parse(raw string) (Version, error) {
+	v := Version{Original: raw}
+
+	s := strings.TrimSpace(raw)
+	start := strings.IndexFunc(s, func(r rune) bool { return r >= '0' && r <= '9' })
+	if start < 0 {
+		return Version{}, fmt.Errorf("invalid version '%s': no numeric component", raw)
+	}
+	s = s[start:]
+
+	if i := strings.Index(s, "+"); i >= 0 {
+		v.Build = s[i+1:]
+		s = s[:i]
+	}
+
+	match := coreRegex.FindStringSubmatch(s)
+	if match == nil {
+		return Version{}, fmt.Errorf("invalid version '%s'", raw)
+	}
+	core, rest := match[1], match[2]
+
+	parts := strings.Split(core, ".")
+	// Treat dash-separated dates ("2024-01-15") as calendar segments rather than a prerelease
+	if len(parts) == 1 && calendarRegex.MatchString(rest) {
+		parts = append(parts, strings.Split(rest[1:], "-")...)
+		rest = ""
+	}
+
+	for _, part := range parts {
+		n, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			return Version{}, fmt.Errorf("invalid version '%s': %w", raw, err)
+		}
+		v.Segments = append(v.Segments, n)
+	}
+
+	rest = strings.TrimLeft(rest, "-_.")
+	if rest != "" {
+		v.Prerelease = strings.Split(rest, ".")
+	}
+
+	return v, nil
+}
+
+// IsPrerelease reports whether the version has prerelease identifiers
+func (v Version) IsPrerelease() bool {
+	return len(v.Prerelease) > 0
+}
+
+// Compare returns -1, 0 or 1 if v is older than, equal to or newer than other
+func (v Version) Compare(other Version) int {
+	n := max(len(v.Segments), len(other.Segments))
+	for i := range n {
+		a, b := segment(v.Segments, i), segment(other.Segments, i)
+		if a != b {
+			return cmpInt(a, b)
+		}
+	}
+
+	// A release is newer than any of its prereleases
+	switch {
+	case len(v.Prerelease) == 0 && len(other.Prerelease) == 0:
+		return 0
+	case len(v.Prerelease) == 0:
+		return 1
+	case len(other.Prerelease) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(v.Prerelease) && i < len(other.Prerelease); i++ {
+		if c := compareIdentifier(v.Prerelease[i], other.Prerelease[i]); c != 0 {
+			return c
+		}
+	}
+	return cmpInt(int64(len(v.Prerelease)), int64(len(other.Prerelease)))
+}
+
+// Compare compares two version strings, returning -1, 0 or 1 if a is older than,
+// equal to or newer than b. Parseable versions sort after unparseable ones, and
+// two unparseable versions fall back to natural ordering.
+func Compare(a, b string) int {
+	va, errA := Parse(a)
+	vb, errB := Parse(b)
+
+	switch {
+	case errA == nil && errB == nil:
+		return va.Compare(vb)
+	case errA == nil:
+		return 1
+	case errB == nil:
+		return -1
+	}
+	return compareNatural(a, b)
+}
+
+// CompareTags compares two release tags after stripping any prefix matched by releaseRegex
+func CompareTags(a, b, releaseRegex string) int {
+	return Compare(StripTagPrefix(a, releaseRegex), StripTagPrefix(b, releaseRegex))
+}
+
+// StripTagPrefix removes the part of a tag matched by releaseRegex.
+// If the pattern has a capture group, its first group is returned (e.g. "^cli-v(.+)");
+// otherwise a match at the start of the tag is removed (e.g. "^v" or "bun-v").
+// The tag is returned unchanged if the pattern is empty, invalid or does not match.
+func StripTagPrefix(tag, releaseRegex string) string {
+	if releaseRegex == "" {
+		return tag
+	}
+
+	re, err := regexp.Compile(releaseRegex)
+	if err != nil {
+		return tag
+	}
+
+	loc := re.FindStringSubmatchIndex(tag)
+	if loc == nil {
+		return tag
+	}
+
+	if len(loc) >= 4 && loc[2] >= 0 {
+		return tag[loc[2]:loc[3]]
+	}
+	if loc[0] == 0 && loc[1] < len(tag) {
+		return tag[loc[1]:]
+	}
+	return tag
+}
+
+func segment(segments []int64, i int) int64 {
+	if i < len(segments) {
+		return segments[i]
+	}
+	return 0
+}
+
+func cmpInt(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// compareIdentifier compares prerelease identifiers following semver precedence:
+// numeric identifiers compare numerically and sort before alphanumeric ones
+func compareIdentifier(a, b string) int {
+	na, errA := strconv.ParseInt(a, 10, 64)
+	nb, errB := strconv.ParseInt(b, 10, 64)
+
+	switch {
+	case errA == nil && errB == nil:
+		return cmpInt(na, nb)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return compareNatural(a, b)
+}
+
+// compareNatural compares strings treating runs of digits as numbers, e.g. "rc2" < "rc10"
+func compareNatural(a, b string) int {
+	for a != "" && b != "" {
+		chunkA, restA := nextChunk(a)
+		chunkB, restB := nextChunk(b)
+
+		na, errA := strconv.ParseInt(chunkA, 10, 64)
+		nb, errB := strconv.ParseInt(chunkB, 10, 64)
+		if errA == nil && errB == nil {
+			if na != nb {
+				return cmpInt(na, nb)
+			}
+		} else if c := strings.Compare(chunkA, chunkB); c != 0 {
+			return c
+		}
+
+		a, b = restA, restB
+	}
+	return cmpInt(int64(len(a)), int64(len(b)))
+}
+
+// nextChunk splits off the leading run of digits or non-digits
+func nextChunk(s string) (string, string) {
+	isDigit := s[0] >= '0' && s[0] <= '9'
+	i := 1
+	for i < len(s) && (s[i] >= '0' && s[i] <= '9') == isDigit {
+		i++
+	}
+	return s[:i], s[i:]
+}
@@ -0,0 +1,116 @@
+package semver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input          string
+		wantSegments   []int64
+		wantPrerelease []string
+		wantBuild      string
+		wantErr        bool
+	}{
+		{input: "v1.2.3", wantSegments: []int64{1, 2, 3}},
+		{input: "1.2", wantSegments: []int64{1, 2}},
+		{input: "v2.0.0-rc.1", wantSegments: []int64{2, 0, 0}, wantPrerelease: []string{"rc", "1"}},
+		{input: "1.0.0-beta+exp.sha.5114f85", wantSegments: []int64{1, 0, 0}, wantPrerelease: []string{"beta"}, wantBuild: "exp.sha.5114f85"},
+		{input: "1.2.3rc1", wantSegments: []int64{1, 2, 3}, wantPrerelease: []string{"rc1"}},
+		{input: "bun-v1.1.20", wantSegments: []int64{1, 1, 20}},
+		{input: "2024.01.15", wantSegments: []int64{2024, 1, 15}},
+		{input: "2024-01-15", wantSegments: []int64{2024, 1, 15}},
+		{input: "20240115", wantSegments: []int64{20240115}},
+		{input: "nightly", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got.Segments, tt.wantSegments) {
+				t.Errorf("Segments = %v, want %v", got.Segments, tt.wantSegments)
+			}
+			if !reflect.DeepEqual(got.Prerelease, tt.wantPrerelease) {
+				t.Errorf("Prerelease = %v, want %v", got.Prerelease, tt.wantPrerelease)
+			}
+			if got.Build != tt.wantBuild {
+				t.Errorf("Build = %q, want %q", got.Build, tt.wantBuild)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"v1.2.3", "1.2.3", 0},
+		{"v1.2.3", "v1.2.4", -1},
+		{"v1.10.0", "v1.9.0", 1},
+		{"v2.0.0", "v1.99.99", 1},
+		{"1.2", "1.2.0", 0},
+		{"v2.0.0-rc.1", "v2.0.0", -1},
+		{"v2.0.0-rc.1", "v1.9.0", 1},
+		{"v2.0.0-alpha", "v2.0.0-beta", -1},
+		{"v2.0.0-rc.2", "v2.0.0-rc.10", -1},
+		{"v2.0.0-1", "v2.0.0-alpha", -1},
+		{"v2.0.0-alpha", "v2.0.0-alpha.1", -1},
+		{"1.0.0+build1", "1.0.0+build2", 0},
+		{"2024.02.01", "2024.01.31", 1},
+		{"20240201", "20240131", 1},
+		{"nightly", "v1.0.0", -1},
+		{"nightly-10", "nightly-9", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"_vs_"+tt.b, func(t *testing.T) {
+			if got := Compare(tt.a, tt.b); got != tt.want {
+				t.Errorf("Compare(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+			if got := Compare(tt.b, tt.a); got != -tt.want {
+				t.Errorf("Compare(%q, %q) = %d, want %d", tt.b, tt.a, got, -tt.want)
+			}
+		})
+	}
+}
+
+func TestStripTagPrefix(t *testing.T) {
+	tests := []struct {
+		name         string
+		tag          string
+		releaseRegex string
+		want         string
+	}{
+		{name: "no pattern", tag: "v1.2.3", releaseRegex: "", want: "v1.2.3"},
+		{name: "anchored prefix", tag: "v1.2.3", releaseRegex: "^v", want: "1.2.3"},
+		{name: "literal prefix", tag: "bun-v1.1.0", releaseRegex: "bun-v", want: "1.1.0"},
+		{name: "capture group", tag: "cli-v1.0.0", releaseRegex: "^cli-v(.+)", want: "1.0.0"},
+		{name: "prefix containing digits", tag: "k8s-1.30.0", releaseRegex: "^k8s-", want: "1.30.0"},
+		{name: "no match", tag: "other-v1.0.0", releaseRegex: "^cli-v", want: "other-v1.0.0"},
+		{name: "invalid pattern", tag: "v1.0.0", releaseRegex: "[", want: "v1.0.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripTagPrefix(tt.tag, tt.releaseRegex); got != tt.want {
+				t.Errorf("StripTagPrefix() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareTags(t *testing.T) {
+	// Without stripping, the "8" in "k8s" would be compared as the major version
+	if got := CompareTags("k8s-1.30.0", "k8s-1.29.5", "^k8s-"); got != 1 {
+		t.Errorf("CompareTags() = %d, want 1", got)
+	}
+}
@@ -0,0 +1,45 @@
+package version
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// getVersionsPath returns the directory holding binmate-managed installations
+func getVersionsPath() (string, error) {
+	var baseDir string
+	if runtime.GOOS == "windows" {
+		cacheDir, err := os.UserCacheDir()
+		if err != nil {
+			return "", fmt.Errorf("unable to find user cache directory: %s", err)
+		}
+		baseDir = cacheDir
+	} else if xdgDataHome, ok := os.LookupEnv("XDG_DATA_HOME"); ok {
+		baseDir = xdgDataHome
+	} else {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("unable to find user home directory: %s", err)
+		}
+		baseDir = filepath.Join(homeDir, ".local", "share")
+	}
+
+	return filepath.Join(baseDir, "binmate", "versions"), nil
+}
+
+// isManagedPath reports whether a path lies within the binmate versions directory.
+// Binaries imported with their original location are not managed and never deleted.
+func isManagedPath(path string) bool {
+	versionsPath, err := getVersionsPath()
+	if err != nil {
+		return false
+	}
+	rel, err := filepath.Rel(versionsPath, path)
+	if err != nil || rel == "." || rel == ".." {
+		return false
+	}
+	return !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
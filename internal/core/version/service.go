@@ -1,9 +1,11 @@
 package version
 
 import (
+	"errors"
 	"fmt"
 	"log"
 
+	"cturner8/binmate/internal/core/semver"
 	"cturner8/binmate/internal/database"
 	"cturner8/binmate/internal/database/repository"
 )
@@ -17,19 +19,23 @@ func SwitchVersion(binaryID string, version string, dbService *repository.Servic
 	}
 
 	// Get the installation for this version
-	installation, err := dbService.Installations.Get(binary.ID, version)
+	installation, err := findInstallation(binary, version, dbService)
 	if err != nil {
 		return fmt.Errorf("version %s not installed: %w", version, err)
 	}
 
-	// Handle optional InstallPath
-	customInstallPath := ""
-	if binary.InstallPath != nil {
-		customInstallPath = *binary.InstallPath
+	if err := ActivateInstallation(binary, installation, dbService); err != nil {
+		return err
 	}
 
-	// Update the symlink
-	symlinkPath, err := SetActiveVersion(installation.InstalledPath, customInstallPath, binary.Name, binary.Alias)
+	log.Printf("Switched %s to version %s", binaryID, version)
+	return nil
+}
+
+// ActivateInstallation points a binary's symlink at an installation and records it as the active version
+func ActivateInstallation(binary *database.Binary, installation *database.Installation, dbService *repository.Service) error {
+	// Update the symlink or shim
+	symlinkPath, err := LinkVersion(binary, installation.InstalledPath)
 	if err != nil {
 		return fmt.Errorf("failed to set active version: %w", err)
 	}
@@ -39,10 +45,170 @@ func SwitchVersion(binaryID string, version string, dbService *repository.Servic
 		return fmt.Errorf("failed to update version record: %w", err)
 	}
 
-	log.Printf("Switched %s to version %s", binaryID, version)
 	return nil
 }
 
+// RollbackResult describes the versions before and after a rollback
+type RollbackResult struct {
+	From *database.Installation // Nil if no version was active
+	To   *database.Installation
+}
+
+// RollbackVersion re-activates the version that was active the given number of activations
+// ago (1 being the previously active version). The rollback is itself recorded in the
+// history, so rolling back twice returns to the original version.
+func RollbackVersion(binaryID string, steps int, dbService *repository.Service) (*RollbackResult, error) {
+	if steps < 1 {
+		return nil, fmt.Errorf("steps must be at least 1")
+	}
+
+	binary, err := dbService.Binaries.GetByUserID(binaryID)
+	if err != nil {
+		return nil, fmt.Errorf("binary not found: %w", err)
+	}
+
+	history, err := dbService.Versions.History(binary.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RollbackResult{}
+	if _, active, err := dbService.Versions.GetWithInstallation(binary.ID); err == nil {
+		result.From = active
+	} else if !errors.Is(err, database.ErrNotFound) {
+		return nil, err
+	}
+
+	// Walk back through the history, skipping the active version's own entry and repeated
+	// entries left behind when the versions between them were deleted
+	var last int64
+	if result.From != nil {
+		last = result.From.ID
+	}
+	remaining := steps
+	for _, activation := range history {
+		if activation.InstallationID == last {
+			continue
+		}
+		last = activation.InstallationID
+		if remaining--; remaining > 0 {
+			continue
+		}
+
+		installation, err := dbService.Installations.GetByID(activation.InstallationID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get installation: %w", err)
+		}
+		if err := ActivateInstallation(binary, installation, dbService); err != nil {
+			return nil, err
+		}
+
+		result.To = installation
+		log.Printf("Rolled back %s to version %s", binaryID, installation.Version)
+		return result, nil
+	}
+
+	if steps == 1 {
+		return nil, fmt.Errorf("no previous version of %s to roll back to", binaryID)
+	}
+	return nil, fmt.Errorf("cannot roll back %s %d steps, only %d previous versions in history", binaryID, steps, steps-remaining)
+}
+
+// findInstallation returns the installation of a version, which may omit the tag
+// prefix matched by the binary's releaseRegex (e.g. "1.1.0" for "bun-v1.1.0")
+func findInstallation(binary *database.Binary, version string, dbService *repository.Service) (*database.Installation, error) {
+	installation, err := dbService.Installations.Get(binary.ID, version)
+	if !errors.Is(err, database.ErrNotFound) || binary.ReleaseRegex == nil || *binary.ReleaseRegex == "" {
+		return installation, err
+	}
+
+	installations, listErr := dbService.Installations.ListByBinary(binary.ID)
+	if listErr != nil {
+		return nil, listErr
+	}
+	for _, candidate := range installations {
+		if semver.CompareTags(candidate.Version, version, *binary.ReleaseRegex) == 0 {
+			return candidate, nil
+		}
+	}
+	return nil, err
+}
+
+// FindInstallation returns the installed version of a binary matching version. Besides exact
+// matches, versions may omit the "v" or the tag prefix of the installed tag (e.g. "2.40.0" for
+// "v2.40.0"), as written in version files and on the command line.
+func FindInstallation(binary *database.Binary, version string, dbService *repository.Service) (*database.Installation, error) {
+	installation, err := findInstallation(binary, version, dbService)
+	if !errors.Is(err, database.ErrNotFound) {
+		return installation, err
+	}
+
+	want, parseErr := semver.Parse(version)
+	if parseErr != nil {
+		return nil, err
+	}
+	installations, listErr := dbService.Installations.ListByBinary(binary.ID)
+	if listErr != nil {
+		return nil, listErr
+	}
+	for _, candidate := range installations {
+		if got, parseErr := semver.Parse(candidate.Version); parseErr == nil && got.Compare(want) == 0 {
+			return candidate, nil
+		}
+	}
+	return nil, err
+}
+
+// PinVersion pins a binary so batch updates skip it.
+// If version is empty the active version is pinned; otherwise the version must be installed.
+func PinVersion(binaryID string, version string, dbService *repository.Service) (*database.Pin, error) {
+	binary, err := dbService.Binaries.GetByUserID(binaryID)
+	if err != nil {
+		return nil, fmt.Errorf("binary not found: %w", err)
+	}
+
+	if version == "" {
+		_, installation, err := dbService.Versions.GetWithInstallation(binary.ID)
+		if err != nil {
+			return nil, fmt.Errorf("no active version to pin, specify a version: %w", err)
+		}
+		version = installation.Version
+	} else {
+		installation, err := findInstallation(binary, version, dbService)
+		if err != nil {
+			return nil, fmt.Errorf("version %s not installed: %w", version, err)
+		}
+		version = installation.Version
+	}
+
+	if err := dbService.Pins.Set(binary.ID, version, "manual"); err != nil {
+		return nil, err
+	}
+
+	log.Printf("Pinned %s to version %s", binaryID, version)
+	return dbService.Pins.Get(binary.ID)
+}
+
+// UnpinVersion removes the pin from a binary, returning the removed pin
+func UnpinVersion(binaryID string, dbService *repository.Service) (*database.Pin, error) {
+	binary, err := dbService.Binaries.GetByUserID(binaryID)
+	if err != nil {
+		return nil, fmt.Errorf("binary not found: %w", err)
+	}
+
+	pin, err := dbService.Pins.Get(binary.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not pinned: %w", binaryID, err)
+	}
+
+	if err := dbService.Pins.Delete(binary.ID); err != nil {
+		return nil, err
+	}
+
+	log.Printf("Unpinned %s from version %s", binaryID, pin.Version)
+	return pin, nil
+}
+
 // GetActiveVersion retrieves the currently active version for a binary
 func GetActiveVersion(binaryID string, dbService *repository.Service) (*database.Version, error) {
 	// Get the binary
@@ -349,3 +349,143 @@ func TestSetActiveVersion_WithAlias(t *testing.T) {
 		t.Errorf("Expected symlink to point to %s, got %s", binary, target)
 	}
 }
+
+func TestPinVersion(t *testing.T) {
+	dbService, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	binary := createTestBinary(t, dbService)
+
+	// Nothing active or installed yet
+	if _, err := PinVersion(binary.UserID, "", dbService); err == nil {
+		t.Error("Expected error pinning without an active version, got none")
+	}
+	if _, err := PinVersion(binary.UserID, "v1.0.0", dbService); err == nil {
+		t.Error("Expected error pinning a version that is not installed, got none")
+	}
+
+	active := createTestInstallation(t, dbService, binary.ID, "v1.0.0")
+	createTestInstallation(t, dbService, binary.ID, "v2.0.0")
+	if err := dbService.Versions.Set(binary.ID, active.ID, "/tmp/testbin"); err != nil {
+		t.Fatalf("Failed to set active version: %v", err)
+	}
+
+	pin, err := PinVersion(binary.UserID, "", dbService)
+	if err != nil {
+		t.Fatalf("PinVersion() error = %v", err)
+	}
+	if pin.Version != "v1.0.0" || pin.Source != "manual" {
+		t.Errorf("pin = %+v, want manual pin of active version v1.0.0", pin)
+	}
+
+	pin, err = PinVersion(binary.UserID, "v2.0.0", dbService)
+	if err != nil {
+		t.Fatalf("PinVersion() error = %v", err)
+	}
+	if pin.Version != "v2.0.0" {
+		t.Errorf("pin.Version = %s, want v2.0.0", pin.Version)
+	}
+
+	removed, err := UnpinVersion(binary.UserID, dbService)
+	if err != nil {
+		t.Fatalf("UnpinVersion() error = %v", err)
+	}
+	if removed.Version != "v2.0.0" {
+		t.Errorf("removed.Version = %s, want v2.0.0", removed.Version)
+	}
+
+	if _, err := UnpinVersion(binary.UserID, dbService); err == nil {
+		t.Error("Expected error unpinning a binary that is not pinned, got none")
+	}
+}
+
+func TestPinVersion_TagPrefix(t *testing.T) {
+	dbService, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	binary := createTestBinary(t, dbService)
+	releaseRegex := "^bun-v"
+	binary.ReleaseRegex = &releaseRegex
+	if err := dbService.Binaries.Update(binary); err != nil {
+		t.Fatalf("Failed to update binary: %v", err)
+	}
+	createTestInstallation(t, dbService, binary.ID, "bun-v1.1.0")
+
+	// Versions may omit the tag prefix, the pin records the full tag
+	pin, err := PinVersion(binary.UserID, "1.1.0", dbService)
+	if err != nil {
+		t.Fatalf("PinVersion() error = %v", err)
+	}
+	if pin.Version != "bun-v1.1.0" {
+		t.Errorf("pin.Version = %s, want bun-v1.1.0", pin.Version)
+	}
+
+	if _, err := PinVersion(binary.UserID, "1.2.0", dbService); err == nil {
+		t.Error("Expected error pinning a version that is not installed, got none")
+	}
+}
+
+func TestRollbackVersion(t *testing.T) {
+	dbService, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	binary := createTestBinary(t, dbService)
+	installPath := t.TempDir()
+	binary.InstallPath = &installPath
+	if err := dbService.Binaries.Update(binary); err != nil {
+		t.Fatalf("Failed to update binary: %v", err)
+	}
+
+	if _, err := RollbackVersion(binary.UserID, 1, dbService); err == nil {
+		t.Error("Expected error rolling back without history, got none")
+	}
+
+	v1 := createTestInstallation(t, dbService, binary.ID, "v1.0.0")
+	v2 := createTestInstallation(t, dbService, binary.ID, "v2.0.0")
+	v3 := createTestInstallation(t, dbService, binary.ID, "v3.0.0")
+	for _, installation := range []*database.Installation{v1, v2, v3} {
+		if err := ActivateInstallation(binary, installation, dbService); err != nil {
+			t.Fatalf("ActivateInstallation() error = %v", err)
+		}
+	}
+	// Re-activating the active version does not add to the history
+	if err := ActivateInstallation(binary, v3, dbService); err != nil {
+		t.Fatalf("ActivateInstallation() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		steps    int
+		wantFrom string
+		wantTo   string
+		wantErr  bool
+	}{
+		{name: "too many steps", steps: 3, wantErr: true},
+		{name: "invalid steps", steps: 0, wantErr: true},
+		{name: "two steps back", steps: 2, wantFrom: "v3.0.0", wantTo: "v1.0.0"},
+		{name: "rollback of a rollback", steps: 1, wantFrom: "v1.0.0", wantTo: "v3.0.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := RollbackVersion(binary.UserID, tt.steps, dbService)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RollbackVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if result.From.Version != tt.wantFrom || result.To.Version != tt.wantTo {
+				t.Errorf("rolled back %s -> %s, want %s -> %s", result.From.Version, result.To.Version, tt.wantFrom, tt.wantTo)
+			}
+
+			active, err := GetActiveVersion(binary.UserID, dbService)
+			if err != nil {
+				t.Fatalf("GetActiveVersion() error = %v", err)
+			}
+			if active.InstallationID != result.To.ID {
+				t.Errorf("active installation = %d, want %d", active.InstallationID, result.To.ID)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package version
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"cturner8/binmate/internal/database"
+	"cturner8/binmate/internal/database/repository"
+)
+
+// LinkVersion points a binary's command in its install path at an installed version.
+// Binaries using shims get a shim dispatching to the version requested by the current
+// project instead of a symlink. Returns the path of the symlink or shim.
+func LinkVersion(binary *database.Binary, versionPath string) (string, error) {
+	if binary.Shim {
+		return WriteShim(binary)
+	}
+
+	installPath := ""
+	if binary.InstallPath != nil {
+		installPath = *binary.InstallPath
+	}
+	return SetActiveVersion(versionPath, installPath, binary.Name, binary.Alias)
+}
+
+// WriteShim writes a shim for a binary to its install path, replacing any existing symlink.
+// The shim runs "binmate shim exec", which resolves the version on every invocation.
+func WriteShim(binary *database.Binary) (string, error) {
+	initialInstallPath := ""
+	if binary.InstallPath != nil {
+		initialInstallPath = *binary.InstallPath
+	}
+
+	installPath, err := getInstallBinPath(initialInstallPath)
+	if err != nil {
+		return "", fmt.Errorf("unable to resolve install path: %s", err)
+	}
+
+	if err := os.MkdirAll(installPath, 0o755); err != nil {
+		return "", fmt.Errorf("unable to create install path: %s", err)
+	}
+
+	shimName := binary.Name
+	if binary.Alias != nil && *binary.Alias != "" {
+		shimName = *binary.Alias
+	}
+	if runtime.GOOS == "windows" {
+		shimName += ".cmd"
+	}
+	shimPath := filepath.Join(installPath, shimName)
+
+	executable, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("unable to locate binmate executable: %w", err)
+	}
+
+	// Remove the existing symlink first so writing the shim does not overwrite the linked binary
+	if err := os.Remove(shimPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("unable to remove existing symlink: %w", err)
+	}
+
+	if err := os.WriteFile(shimPath, []byte(shimScript(executable, binary.UserID)), 0o755); err != nil {
+		return "", fmt.Errorf("unable to write shim: %w", err)
+	}
+
+	return shimPath, nil
+}
+
+// shimScript returns the shell (or batch on Windows) script forwarding to binmate
+func shimScript(executable string, binaryID string) string {
+	if runtime.GOOS == "windows" {
+		return fmt.Sprintf("@echo off\r\n\"%s\" shim exec \"%s\" -- %%*\r\nexit /b %%ERRORLEVEL%%\r\n", executable, binaryID)
+	}
+
+	quote := func(s string) string {
+		return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+	}
+	return fmt.Sprintf("#!/bin/sh\n# binmate shim: runs the %s version set by the nearest %s or %s\nexec %s shim exec %s -- \"$@\"\n",
+		binaryID, BinmateVersionFile, ToolVersionsFile, quote(executable), quote(binaryID))
+}
+
+// ShimTarget is the version a shim dispatches to
+type ShimTarget struct {
+	Version      string                 // Requested version
+	Source       string                 // Version file requesting the version, empty for the active version
+	Installation *database.Installation // Nil if the requested version is not installed
+}
+
+// ResolveShim finds the version of a binary to run from dir: the nearest version file entry
+// naming the binary by id, name or alias, falling back to the globally active version
+func ResolveShim(binary *database.Binary, dir string, dbService *repository.Service) (*ShimTarget, error) {
+	names := []string{binary.UserID, binary.Name}
+	if binary.Alias != nil && *binary.Alias != "" {
+		names = append(names, *binary.Alias)
+	}
+
+	versionFile, err := FindVersionFile(dir, names...)
+	if err != nil {
+		return nil, err
+	}
+
+	if versionFile == nil {
+		_, installation, err := dbService.Versions.GetWithInstallation(binary.ID)
+		if errors.Is(err, database.ErrNotFound) {
+			return nil, fmt.Errorf("no version of %s is active and no %s or %s requests one", binary.UserID, BinmateVersionFile, ToolVersionsFile)
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to get active version: %w", err)
+		}
+		return &ShimTarget{Version: installation.Version, Installation: installation}, nil
+	}
+
+	target := &ShimTarget{Version: versionFile.Version, Source: versionFile.Path}
+	installation, err := FindInstallation(binary, versionFile.Version, dbService)
+	if err == nil {
+		target.Installation = installation
+	} else if !errors.Is(err, database.ErrNotFound) {
+		return nil, fmt.Errorf("failed to get installation: %w", err)
+	}
+	return target, nil
+}
@@ -0,0 +1,178 @@
+package version
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestFindVersionFile(t *testing.T) {
+	root := t.TempDir()
+	project := filepath.Join(root, "project")
+	nested := filepath.Join(project, "src", "pkg")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatalf("failed to create directories: %v", err)
+	}
+
+	writeFile := func(path, content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", path, err)
+		}
+	}
+	writeFile(filepath.Join(root, ToolVersionsFile), "terraform 1.4.0\ngolangci-lint 1.50.0\n")
+	writeFile(filepath.Join(project, ToolVersionsFile), "# pinned for CI\nterraform 1.5.7 1.5.6 # fallback\n")
+	writeFile(filepath.Join(project, BinmateVersionFile), "gh v2.40.0\n")
+
+	tests := []struct {
+		name        string
+		names       []string
+		wantVersion string
+		wantPath    string
+	}{
+		{name: "nearest tool-versions", names: []string{"terraform"}, wantVersion: "1.5.7", wantPath: filepath.Join(project, ToolVersionsFile)},
+		{name: "binmate-version", names: []string{"gh"}, wantVersion: "v2.40.0", wantPath: filepath.Join(project, BinmateVersionFile)},
+		{name: "parent directory", names: []string{"golangci-lint"}, wantVersion: "1.50.0", wantPath: filepath.Join(root, ToolVersionsFile)},
+		{name: "alternative name", names: []string{"tf", "terraform"}, wantVersion: "1.5.7", wantPath: filepath.Join(project, ToolVersionsFile)},
+		{name: "not requested", names: []string{"fzf"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FindVersionFile(nested, tt.names...)
+			if err != nil {
+				t.Fatalf("FindVersionFile() error = %v", err)
+			}
+			if tt.wantVersion == "" {
+				if got != nil {
+					t.Errorf("FindVersionFile() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil || got.Version != tt.wantVersion || got.Path != tt.wantPath {
+				t.Errorf("FindVersionFile() = %+v, want %s from %s", got, tt.wantVersion, tt.wantPath)
+			}
+		})
+	}
+
+	// .binmate-version takes precedence over .tool-versions in the same directory
+	writeFile(filepath.Join(project, BinmateVersionFile), "terraform 1.6.0\n")
+	got, err := FindVersionFile(nested, "terraform")
+	if err != nil {
+		t.Fatalf("FindVersionFile() error = %v", err)
+	}
+	if got == nil || got.Version != "1.6.0" {
+		t.Errorf("FindVersionFile() = %+v, want 1.6.0", got)
+	}
+}
+
+func TestResolveShim(t *testing.T) {
+	dbService, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	binary := createTestBinary(t, dbService)
+	active := createTestInstallation(t, dbService, binary.ID, "v1.0.0")
+	requested := createTestInstallation(t, dbService, binary.ID, "v1.1.0")
+	if err := dbService.Versions.Set(binary.ID, active.ID, filepath.Join(t.TempDir(), "testbin")); err != nil {
+		t.Fatalf("Failed to set active version: %v", err)
+	}
+
+	// Without a version file the active version is used
+	dir := t.TempDir()
+	target, err := ResolveShim(binary, dir, dbService)
+	if err != nil {
+		t.Fatalf("ResolveShim() error = %v", err)
+	}
+	if target.Installation == nil || target.Installation.ID != active.ID || target.Source != "" {
+		t.Errorf("ResolveShim() = %+v, want active version", target)
+	}
+
+	// Version files may omit the "v" and name the binary by its name
+	versionFile := filepath.Join(dir, BinmateVersionFile)
+	if err := os.WriteFile(versionFile, []byte("testbin 1.1.0\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write version file: %v", err)
+	}
+	target, err = ResolveShim(binary, dir, dbService)
+	if err != nil {
+		t.Fatalf("ResolveShim() error = %v", err)
+	}
+	if target.Installation == nil || target.Installation.ID != requested.ID || target.Source != versionFile {
+		t.Errorf("ResolveShim() = %+v, want v1.1.0 from %s", target, versionFile)
+	}
+
+	// Versions that are not installed are reported without an installation
+	if err := os.WriteFile(versionFile, []byte("test-binary v2.0.0\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write version file: %v", err)
+	}
+	target, err = ResolveShim(binary, dir, dbService)
+	if err != nil {
+		t.Fatalf("ResolveShim() error = %v", err)
+	}
+	if target.Installation != nil || target.Version != "v2.0.0" {
+		t.Errorf("ResolveShim() = %+v, want uninstalled v2.0.0", target)
+	}
+}
+
+func TestLinkVersion_Shim(t *testing.T) {
+	dbService, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	binary := createTestBinary(t, dbService)
+	installation := createTestInstallation(t, dbService, binary.ID, "v1.0.0")
+	installPath := t.TempDir()
+	binary.InstallPath = &installPath
+
+	// Start from a symlink, as left by a binary that did not use shims
+	linkPath, err := LinkVersion(binary, installation.InstalledPath)
+	if err != nil {
+		t.Fatalf("LinkVersion() error = %v", err)
+	}
+	if info, err := os.Lstat(linkPath); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Fatalf("expected symlink at %s: %v", linkPath, err)
+	}
+
+	binary.Shim = true
+	shimPath, err := LinkVersion(binary, installation.InstalledPath)
+	if err != nil {
+		t.Fatalf("LinkVersion() error = %v", err)
+	}
+
+	info, err := os.Lstat(shimPath)
+	if err != nil || !info.Mode().IsRegular() {
+		t.Fatalf("expected regular file at %s: %v", shimPath, err)
+	}
+	content, _ := os.ReadFile(shimPath)
+	if !strings.Contains(string(content), "shim exec") || !strings.Contains(string(content), binary.UserID) {
+		t.Errorf("unexpected shim content: %s", content)
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0o111 == 0 {
+		t.Errorf("expected shim to be executable, got mode %v", info.Mode())
+	}
+
+	// The previously linked binary must be untouched
+	original, _ := os.ReadFile(installation.InstalledPath)
+	if string(original) != "#!/bin/bash\necho test" {
+		t.Errorf("linked binary was overwritten: %s", original)
+	}
+}
+
+func TestFindInstallation(t *testing.T) {
+	dbService, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	binary := createTestBinary(t, dbService)
+	installation := createTestInstallation(t, dbService, binary.ID, "v1.5.7")
+
+	for _, version := range []string{"v1.5.7", "1.5.7"} {
+		got, err := FindInstallation(binary, version, dbService)
+		if err != nil || got.ID != installation.ID {
+			t.Errorf("FindInstallation(%s) = %v, %v, want v1.5.7", version, got, err)
+		}
+	}
+
+	if _, err := FindInstallation(binary, "1.5.8", dbService); err == nil {
+		t.Error("Expected error for a version that is not installed, got none")
+	}
+}
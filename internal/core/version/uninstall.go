@@ -0,0 +1,142 @@
+package version
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"cturner8/binmate/internal/core/semver"
+	"cturner8/binmate/internal/database"
+	"cturner8/binmate/internal/database/repository"
+)
+
+// UninstallVersion removes an inactive installed version of a binary, deleting its files
+// and installation record. The active and pinned versions cannot be uninstalled.
+func UninstallVersion(binaryID string, version string, dbService *repository.Service) (*database.Installation, error) {
+	binary, err := dbService.Binaries.GetByUserID(binaryID)
+	if err != nil {
+		return nil, fmt.Errorf("binary not found: %w", err)
+	}
+
+	installation, err := findInstallation(binary, version, dbService)
+	if err != nil {
+		return nil, fmt.Errorf("version %s not installed: %w", version, err)
+	}
+
+	protected, err := protectedInstallations(binary, dbService)
+	if err != nil {
+		return nil, err
+	}
+	if reason, ok := protected[installation.ID]; ok {
+		return nil, fmt.Errorf("cannot uninstall %s, it is the %s version", installation.Version, reason)
+	}
+
+	if err := removeInstallation(installation, dbService); err != nil {
+		return nil, err
+	}
+
+	log.Printf("Uninstalled %s version %s", binaryID, installation.Version)
+	return installation, nil
+}
+
+// PruneVersions removes all but the newest keep installed versions of a binary.
+// The active and pinned versions are always kept, in addition to the newest keep versions.
+// With dryRun set, the versions that would be removed are returned without removing them.
+func PruneVersions(binaryID string, keep int, dryRun bool, dbService *repository.Service) ([]*database.Installation, error) {
+	if keep < 0 {
+		return nil, fmt.Errorf("keep must not be negative")
+	}
+
+	binary, err := dbService.Binaries.GetByUserID(binaryID)
+	if err != nil {
+		return nil, fmt.Errorf("binary not found: %w", err)
+	}
+
+	installations, err := dbService.Installations.ListByBinary(binary.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list installations: %w", err)
+	}
+	if len(installations) <= keep {
+		return nil, nil
+	}
+
+	protected, err := protectedInstallations(binary, dbService)
+	if err != nil {
+		return nil, err
+	}
+
+	// Newest version first
+	releaseRegex := ""
+	if binary.ReleaseRegex != nil {
+		releaseRegex = *binary.ReleaseRegex
+	}
+	sort.SliceStable(installations, func(i, j int) bool {
+		return semver.CompareTags(installations[i].Version, installations[j].Version, releaseRegex) > 0
+	})
+
+	var pruned []*database.Installation
+	for _, installation := range installations[keep:] {
+		if _, ok := protected[installation.ID]; ok {
+			continue
+		}
+		if !dryRun {
+			if err := removeInstallation(installation, dbService); err != nil {
+				return pruned, err
+			}
+			log.Printf("Pruned %s version %s", binaryID, installation.Version)
+		}
+		pruned = append(pruned, installation)
+	}
+
+	return pruned, nil
+}
+
+// protectedInstallations returns the installations of a binary that must not be removed,
+// mapped to the reason they are protected
+func protectedInstallations(binary *database.Binary, dbService *repository.Service) (map[int64]string, error) {
+	protected := make(map[int64]string)
+
+	if active, err := dbService.Versions.Get(binary.ID); err == nil {
+		protected[active.InstallationID] = "active"
+	} else if !errors.Is(err, database.ErrNotFound) {
+		return nil, err
+	}
+
+	if pin, err := dbService.Pins.Get(binary.ID); err == nil {
+		if installation, err := dbService.Installations.Get(binary.ID, pin.Version); err == nil {
+			if _, ok := protected[installation.ID]; !ok {
+				protected[installation.ID] = "pinned"
+			}
+		}
+	} else if !errors.Is(err, database.ErrNotFound) {
+		return nil, err
+	}
+
+	return protected, nil
+}
+
+// removeInstallation deletes an installation's files and its database record.
+// Files outside the managed versions directory (e.g. imported in place) are left on disk.
+func removeInstallation(installation *database.Installation, dbService *repository.Service) error {
+	if installation.InstalledPath != "" && !isManagedPath(installation.InstalledPath) {
+		log.Printf("Leaving unmanaged file %s on disk", installation.InstalledPath)
+	} else if installation.InstalledPath != "" {
+		if err := os.RemoveAll(installation.InstalledPath); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", installation.InstalledPath, err)
+		}
+
+		// Remove the version directory once its binary has gone
+		dir := filepath.Dir(installation.InstalledPath)
+		if entries, err := os.ReadDir(dir); err == nil && len(entries) == 0 {
+			os.Remove(dir)
+		}
+	}
+
+	if err := dbService.Installations.Delete(installation.ID); err != nil {
+		return fmt.Errorf("failed to delete installation record: %w", err)
+	}
+	return nil
+}
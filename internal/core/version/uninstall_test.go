@@ -0,0 +1,184 @@
+package version
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cturner8/binmate/internal/database"
+	"cturner8/binmate/internal/database/repository"
+)
+
+// createManagedInstallation creates an installation whose file lives in the managed versions directory
+func createManagedInstallation(t *testing.T, dbService *repository.Service, binary *database.Binary, version string) *database.Installation {
+	versionsPath, err := getVersionsPath()
+	if err != nil {
+		t.Fatalf("Failed to get versions path: %v", err)
+	}
+
+	binaryPath := filepath.Join(versionsPath, binary.UserID, version, binary.Name)
+	if err := os.MkdirAll(filepath.Dir(binaryPath), 0755); err != nil {
+		t.Fatalf("Failed to create version directory: %v", err)
+	}
+	if err := os.WriteFile(binaryPath, []byte(version), 0755); err != nil {
+		t.Fatalf("Failed to create test binary file: %v", err)
+	}
+
+	installation := &database.Installation{
+		BinaryID:      binary.ID,
+		Version:       version,
+		InstalledPath: binaryPath,
+		SourceURL:     "https://example.com/" + version,
+	}
+	if err := dbService.Installations.Create(installation); err != nil {
+		t.Fatalf("Failed to create test installation: %v", err)
+	}
+	return installation
+}
+
+func TestUninstallVersion(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	dbService, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	binary := createTestBinary(t, dbService)
+	old := createManagedInstallation(t, dbService, binary, "v1.0.0")
+	active := createManagedInstallation(t, dbService, binary, "v1.1.0")
+	if err := ActivateInstallation(binary, active, dbService); err != nil {
+		t.Fatalf("Failed to activate installation: %v", err)
+	}
+
+	// The active version is protected
+	if _, err := UninstallVersion(binary.UserID, "v1.1.0", dbService); err == nil {
+		t.Error("Expected error uninstalling the active version, got none")
+	}
+
+	if _, err := UninstallVersion(binary.UserID, "v2.0.0", dbService); err == nil {
+		t.Error("Expected error uninstalling a version that is not installed, got none")
+	}
+
+	removed, err := UninstallVersion(binary.UserID, "v1.0.0", dbService)
+	if err != nil {
+		t.Fatalf("UninstallVersion failed: %v", err)
+	}
+	if removed.ID != old.ID {
+		t.Errorf("Expected installation %d to be removed, got %d", old.ID, removed.ID)
+	}
+
+	if _, err := os.Stat(filepath.Dir(old.InstalledPath)); !os.IsNotExist(err) {
+		t.Errorf("Expected version directory to be removed, got: %v", err)
+	}
+	if _, err := dbService.Installations.Get(binary.ID, "v1.0.0"); err != database.ErrNotFound {
+		t.Errorf("Expected installation record to be deleted, got: %v", err)
+	}
+	if _, err := os.Stat(active.InstalledPath); err != nil {
+		t.Errorf("Expected active version to be kept: %v", err)
+	}
+}
+
+func TestUninstallVersion_Pinned(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	dbService, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	binary := createTestBinary(t, dbService)
+	createManagedInstallation(t, dbService, binary, "v1.0.0")
+	active := createManagedInstallation(t, dbService, binary, "v1.1.0")
+	if err := ActivateInstallation(binary, active, dbService); err != nil {
+		t.Fatalf("Failed to activate installation: %v", err)
+	}
+	if err := dbService.Pins.Set(binary.ID, "v1.0.0", "cli"); err != nil {
+		t.Fatalf("Failed to pin version: %v", err)
+	}
+
+	if _, err := UninstallVersion(binary.UserID, "v1.0.0", dbService); err == nil {
+		t.Error("Expected error uninstalling the pinned version, got none")
+	}
+}
+
+func TestUninstallVersion_UnmanagedFile(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	dbService, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	binary := createTestBinary(t, dbService)
+	imported := createTestInstallation(t, dbService, binary.ID, "v1.0.0")
+
+	if _, err := UninstallVersion(binary.UserID, "v1.0.0", dbService); err != nil {
+		t.Fatalf("UninstallVersion failed: %v", err)
+	}
+
+	// Files outside the versions directory are left in place
+	if _, err := os.Stat(imported.InstalledPath); err != nil {
+		t.Errorf("Expected unmanaged file to be kept: %v", err)
+	}
+	if _, err := dbService.Installations.Get(binary.ID, "v1.0.0"); err != database.ErrNotFound {
+		t.Errorf("Expected installation record to be deleted, got: %v", err)
+	}
+}
+
+func TestPruneVersions(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	dbService, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	binary := createTestBinary(t, dbService)
+	installations := make(map[string]*database.Installation)
+	for _, version := range []string{"v1.10.0", "v1.2.0", "v1.9.0", "v1.0.0", "v2.0.0"} {
+		installations[version] = createManagedInstallation(t, dbService, binary, version)
+	}
+
+	// The oldest version is active so it must survive pruning
+	if err := ActivateInstallation(binary, installations["v1.0.0"], dbService); err != nil {
+		t.Fatalf("Failed to activate installation: %v", err)
+	}
+
+	// A dry run reports without removing anything
+	pruned, err := PruneVersions(binary.UserID, 2, true, dbService)
+	if err != nil {
+		t.Fatalf("PruneVersions dry run failed: %v", err)
+	}
+	if len(pruned) != 2 {
+		t.Fatalf("Expected 2 versions to prune, got %d", len(pruned))
+	}
+	for _, installation := range installations {
+		if _, err := os.Stat(installation.InstalledPath); err != nil {
+			t.Errorf("Expected %s to be kept by dry run: %v", installation.Version, err)
+		}
+	}
+
+	pruned, err = PruneVersions(binary.UserID, 2, false, dbService)
+	if err != nil {
+		t.Fatalf("PruneVersions failed: %v", err)
+	}
+	if len(pruned) != 2 || pruned[0].Version != "v1.9.0" || pruned[1].Version != "v1.2.0" {
+		t.Fatalf("Expected v1.9.0 and v1.2.0 to be pruned, got %v", pruned)
+	}
+
+	remaining, err := dbService.Installations.ListByBinary(binary.ID)
+	if err != nil {
+		t.Fatalf("Failed to list installations: %v", err)
+	}
+	if len(remaining) != 3 {
+		t.Errorf("Expected 3 installations to remain, got %d", len(remaining))
+	}
+	for _, version := range []string{"v2.0.0", "v1.10.0", "v1.0.0"} {
+		if _, err := os.Stat(installations[version].InstalledPath); err != nil {
+			t.Errorf("Expected %s to be kept: %v", version, err)
+		}
+	}
+	for _, version := range []string{"v1.9.0", "v1.2.0"} {
+		if _, err := os.Stat(installations[version].InstalledPath); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be removed, got: %v", version, err)
+		}
+	}
+
+	// Pruning again is a no-op
+	pruned, err = PruneVersions(binary.UserID, 2, false, dbService)
+	if err != nil {
+		t.Fatalf("PruneVersions failed: %v", err)
+	}
+	if len(pruned) != 0 {
+		t.Errorf("Expected nothing to prune, got %d versions", len(pruned))
+	}
+}
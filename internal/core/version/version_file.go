@@ -0,0 +1,84 @@
+package version
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Project version files, searched from the working directory upwards.
+// Both use the asdf ".tool-versions" format of one "<name> <version>" entry per line.
+const (
+	BinmateVersionFile = ".binmate-version"
+	ToolVersionsFile   = ".tool-versions"
+)
+
+// VersionFile is a version requested for a binary by a project version file
+type VersionFile struct {
+	Path    string // Path of the file declaring the version
+	Version string
+}
+
+// FindVersionFile returns the nearest version file entry for any of names, searching dir and
+// then each parent directory. Within a directory .binmate-version takes precedence over
+// .tool-versions. Files not mentioning the binary are skipped so a parent directory can
+// still set its version. Returns nil if no version file requests a version.
+func FindVersionFile(dir string, names ...string) (*VersionFile, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve directory: %w", err)
+	}
+
+	for {
+		for _, fileName := range []string{BinmateVersionFile, ToolVersionsFile} {
+			path := filepath.Join(dir, fileName)
+			version, err := readVersionFile(path, names)
+			if err != nil {
+				return nil, err
+			}
+			if version != "" {
+				return &VersionFile{Path: path, Version: version}, nil
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+// readVersionFile returns the version a file sets for any of names, or an empty string
+// if the file does not exist or has no entry for the binary
+func readVersionFile(path string, names []string) (string, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	} else if err != nil {
+		return "", fmt.Errorf("unable to read %s: %w", path, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+
+		// Further fields are asdf fallback versions, only the first is used
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && slices.Contains(names, fields[0]) {
+			return fields[1], nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("unable to read %s: %w", path, err)
+	}
+	return "", nil
+}
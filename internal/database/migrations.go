@@ -18,6 +18,46 @@ var migrations = []Migration{
 		Description: "Initial schema",
 		SQL:         InitialSchema,
 	},
+	{
+		Version:     2,
+		Description: "Add binary version constraints",
+		SQL:         AddVersionConstraint,
+	},
+	{
+		Version:     3,
+		Description: "Add binary pins",
+		SQL:         AddPins,
+	},
+	{
+		Version:     4,
+		Description: "Add binary release channels",
+		SQL:         AddReleaseChannels,
+	},
+	{
+		Version:     5,
+		Description: "Add version activation history",
+		SQL:         AddActivationHistory,
+	},
+	{
+		Version:     6,
+		Description: "Add binary version retention",
+		SQL:         AddKeepVersions,
+	},
+	{
+		Version:     7,
+		Description: "Add per-project version shims",
+		SQL:         AddShims,
+	},
+	{
+		Version:     8,
+		Description: "Add binary archive paths",
+		SQL:         AddBinPaths,
+	},
+	{
+		Version:     9,
+		Description: "Add binary project config files",
+		SQL:         AddConfigFiles,
+	},
 }
 
 // Migrate runs all pending migrations
@@ -78,6 +118,14 @@ func (db *DB) runMigration(migration Migration) error {
 		return fmt.Errorf("failed to execute migration SQL: %w", err)
 	}
 
+	// Record migration
+	if _, err := tx.Exec(`
+INSERT OR IGNORE INTO migrations (version, applied_at, description)
+VALUES (?, strftime('%s', 'now'), ?)
+`, migration.Version, migration.Description); err != nil {
+		return fmt.Errorf("failed to record migration: %w", err)
+	}
+
 	// Commit transaction
 	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("failed to commit migration: %w", err)
@@ -99,12 +99,13 @@ func TestGetCurrentVersion(t *testing.T) {
 		t.Fatalf("Failed to run migrations: %v", err)
 	}
 
-	// Version should be 1 after migrations
+	// Version should be the latest migration after migrations
 	version, err = db.getCurrentVersion()
 	if err != nil {
 		t.Fatalf("Failed to get current version: %v", err)
 	}
-	if version != 1 {
-		t.Errorf("Expected version 1, got %d", version)
+	latest := migrations[len(migrations)-1].Version
+	if version != latest {
+		t.Errorf("Expected version %d, got %d", latest, version)
 	}
 }
@@ -16,8 +16,16 @@ type Binary struct {
 	CreatedAt     int64
 	UpdatedAt     int64
 	ConfigVersion int
-	Source        string // "config" for binaries from config.json, "manual" for user-added binaries
+	Source        string // "config" for binaries from config.json, "project" for binaries only a project config declares, "manual" for user-added binaries
 	Authenticated bool   // Whether to use GitHub token for authentication (for private repos or rate limit avoidance)
+
+	VersionConstraint *string // Semver constraint restricting which releases count as latest, e.g. "~1.4"
+	Channel           *string // Release channel used to resolve latest: "stable" (default), "prerelease" or "nightly"
+	NightlyRegex      *string // Tag pattern identifying nightly releases for the nightly channel
+	KeepVersions      *int    // Number of installed versions retained after each update, nil to keep all
+	Shim              bool    // Whether the command is a shim resolving the version per project instead of a symlink
+	BinPath           *string // Path of the binary inside the release archive, nil to find it by name
+	ConfigFile        *string // Project config file declaring a "project" binary, nil for other binaries
 }
 
 // Installation represents an installed binary version
@@ -41,6 +49,22 @@ type Version struct {
 	SymlinkPath    string
 }
 
+// Activation records a version becoming active, forming the history used by rollback
+type Activation struct {
+	ID             int64
+	BinaryID       int64
+	InstallationID int64
+	ActivatedAt    int64
+}
+
+// Pin represents a binary held at a version so batch updates skip it
+type Pin struct {
+	BinaryID int64
+	Version  string
+	Source   string // "config" for pins declared in config.json, "manual" for pins added via the CLI or TUI
+	PinnedAt int64
+}
+
 // Download represents a cached download
 type Download struct {
 	ID                int64
@@ -4,9 +4,9 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
-	"cturner8/binmate/internal/core/crypto"
 	"cturner8/binmate/internal/database"
 )
 
@@ -18,6 +18,41 @@ func NewBinariesRepository(db *database.DB) *BinariesRepository {
 	return &BinariesRepository{db: db}
 }
 
+// binaryColumns lists the binaries table columns in the order read by scanBinary
+const binaryColumns = `id, user_id, name, alias, provider, provider_path, install_path,
+format, asset_regex, release_regex, config_digest, created_at, updated_at, config_version, source, authenticated,
+version_constraint, channel, nightly_regex, keep_versions, shim, bin_path, config_file`
+
+// prefixedBinaryColumns returns binaryColumns qualified with a table alias for joins
+func prefixedBinaryColumns(alias string) string {
+	columns := strings.Split(binaryColumns, ",")
+	for i, column := range columns {
+		columns[i] = alias + "." + strings.TrimSpace(column)
+	}
+	return strings.Join(columns, ", ")
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// binaryFields returns scan destinations matching binaryColumns
+func binaryFields(binary *database.Binary) []any {
+	return []any{&binary.ID, &binary.UserID, &binary.Name, &binary.Alias, &binary.Provider,
+		&binary.ProviderPath, &binary.InstallPath, &binary.Format, &binary.AssetRegex,
+		&binary.ReleaseRegex, &binary.ConfigDigest, &binary.CreatedAt, &binary.UpdatedAt,
+		&binary.ConfigVersion, &binary.Source, &binary.Authenticated, &binary.VersionConstraint,
+		&binary.Channel, &binary.NightlyRegex, &binary.KeepVersions, &binary.Shim, &binary.BinPath, &binary.ConfigFile}
+}
+
+func scanBinary(row rowScanner) (*database.Binary, error) {
+	binary := &database.Binary{}
+	if err := row.Scan(binaryFields(binary)...); err != nil {
+		return nil, err
+	}
+	return binary, nil
+}
+
 // Create inserts a new binary
 func (r *BinariesRepository) Create(binary *database.Binary) error {
 	now := time.Now().Unix()
@@ -31,11 +66,13 @@ func (r *BinariesRepository) Create(binary *database.Binary) error {
 
 	result, err := r.db.Exec(`
 INSERT INTO binaries (user_id, name, alias, provider, provider_path, install_path, 
-format, asset_regex, release_regex, config_digest, created_at, updated_at, config_version, source, authenticated)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+format, asset_regex, release_regex, config_digest, created_at, updated_at, config_version, source, authenticated,
+version_constraint, channel, nightly_regex, keep_versions, shim, bin_path, config_file)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 `, binary.UserID, binary.Name, binary.Alias, binary.Provider, binary.ProviderPath,
 		binary.InstallPath, binary.Format, binary.AssetRegex, binary.ReleaseRegex,
-		binary.ConfigDigest, binary.CreatedAt, binary.UpdatedAt, binary.ConfigVersion, binary.Source, binary.Authenticated)
+		binary.ConfigDigest, binary.CreatedAt, binary.UpdatedAt, binary.ConfigVersion, binary.Source, binary.Authenticated,
+		binary.VersionConstraint, binary.Channel, binary.NightlyRegex, binary.KeepVersions, binary.Shim, binary.BinPath, binary.ConfigFile)
 
 	if err != nil {
 		return fmt.Errorf("failed to create binary: %w", err)
@@ -53,11 +90,13 @@ func (r *BinariesRepository) Update(binary *database.Binary) error {
 UPDATE binaries 
 SET user_id = ?, name = ?, alias = ?, provider = ?, provider_path = ?,
 install_path = ?, format = ?, asset_regex = ?, release_regex = ?,
-config_digest = ?, updated_at = ?, config_version = ?, source = ?, authenticated = ?
+config_digest = ?, updated_at = ?, config_version = ?, source = ?, authenticated = ?,
+version_constraint = ?, channel = ?, nightly_regex = ?, keep_versions = ?, shim = ?, bin_path = ?, config_file = ?
 WHERE id = ?
 `, binary.UserID, binary.Name, binary.Alias, binary.Provider, binary.ProviderPath,
 		binary.InstallPath, binary.Format, binary.AssetRegex, binary.ReleaseRegex,
-		binary.ConfigDigest, binary.UpdatedAt, binary.ConfigVersion, binary.Source, binary.Authenticated, binary.ID)
+		binary.ConfigDigest, binary.UpdatedAt, binary.ConfigVersion, binary.Source, binary.Authenticated,
+		binary.VersionConstraint, binary.Channel, binary.NightlyRegex, binary.KeepVersions, binary.Shim, binary.BinPath, binary.ConfigFile, binary.ID)
 
 	if err != nil {
 		return fmt.Errorf("failed to update binary: %w", err)
@@ -76,14 +115,10 @@ WHERE id = ?
 
 // Get retrieves a binary by ID
 func (r *BinariesRepository) Get(id int64) (*database.Binary, error) {
-	binary := &database.Binary{}
-	err := r.db.QueryRow(`
-SELECT id, user_id, name, alias, provider, provider_path, install_path,
-format, asset_regex, release_regex, config_digest, created_at, updated_at, config_version, source, authenticated
+	binary, err := scanBinary(r.db.QueryRow(`
+SELECT `+binaryColumns+`
 FROM binaries WHERE id = ?
-`, id).Scan(&binary.ID, &binary.UserID, &binary.Name, &binary.Alias, &binary.Provider,
-		&binary.ProviderPath, &binary.InstallPath, &binary.Format, &binary.AssetRegex,
-		&binary.ReleaseRegex, &binary.ConfigDigest, &binary.CreatedAt, &binary.UpdatedAt, &binary.ConfigVersion, &binary.Source, &binary.Authenticated)
+`, id))
 
 	if err == sql.ErrNoRows {
 		return nil, database.ErrNotFound
@@ -97,14 +132,10 @@ FROM binaries WHERE id = ?
 
 // GetByUserID retrieves a binary by user_id
 func (r *BinariesRepository) GetByUserID(userID string) (*database.Binary, error) {
-	binary := &database.Binary{}
-	err := r.db.QueryRow(`
-SELECT id, user_id, name, alias, provider, provider_path, install_path,
-format, asset_regex, release_regex, config_digest, created_at, updated_at, config_version, source, authenticated
+	binary, err := scanBinary(r.db.QueryRow(`
+SELECT `+binaryColumns+`
 FROM binaries WHERE user_id = ?
-`, userID).Scan(&binary.ID, &binary.UserID, &binary.Name, &binary.Alias, &binary.Provider,
-		&binary.ProviderPath, &binary.InstallPath, &binary.Format, &binary.AssetRegex,
-		&binary.ReleaseRegex, &binary.ConfigDigest, &binary.CreatedAt, &binary.UpdatedAt, &binary.ConfigVersion, &binary.Source, &binary.Authenticated)
+`, userID))
 
 	if err == sql.ErrNoRows {
 		return nil, database.ErrNotFound
@@ -118,14 +149,10 @@ FROM binaries WHERE user_id = ?
 
 // GetByName retrieves a binary by name
 func (r *BinariesRepository) GetByName(name string) (*database.Binary, error) {
-	binary := &database.Binary{}
-	err := r.db.QueryRow(`
-SELECT id, user_id, name, alias, provider, provider_path, install_path,
-format, asset_regex, release_regex, config_digest, created_at, updated_at, config_version, source, authenticated
+	binary, err := scanBinary(r.db.QueryRow(`
+SELECT `+binaryColumns+`
 FROM binaries WHERE name = ?
-`, name).Scan(&binary.ID, &binary.UserID, &binary.Name, &binary.Alias, &binary.Provider,
-		&binary.ProviderPath, &binary.InstallPath, &binary.Format, &binary.AssetRegex,
-		&binary.ReleaseRegex, &binary.ConfigDigest, &binary.CreatedAt, &binary.UpdatedAt, &binary.ConfigVersion, &binary.Source, &binary.Authenticated)
+`, name))
 
 	if err == sql.ErrNoRows {
 		return nil, database.ErrNotFound
@@ -140,8 +167,7 @@ FROM binaries WHERE name = ?
 // List retrieves all binaries
 func (r *BinariesRepository) List() ([]*database.Binary, error) {
 	rows, err := r.db.Query(`
-SELECT id, user_id, name, alias, provider, provider_path, install_path,
-format, asset_regex, release_regex, config_digest, created_at, updated_at, config_version, source, authenticated
+SELECT ` + binaryColumns + `
 FROM binaries ORDER BY name
 `)
 	if err != nil {
@@ -151,11 +177,7 @@ FROM binaries ORDER BY name
 
 	var binaries []*database.Binary
 	for rows.Next() {
-		binary := &database.Binary{}
-		err := rows.Scan(&binary.ID, &binary.UserID, &binary.Name, &binary.Alias,
-			&binary.Provider, &binary.ProviderPath, &binary.InstallPath, &binary.Format,
-			&binary.AssetRegex, &binary.ReleaseRegex, &binary.ConfigDigest, &binary.CreatedAt, &binary.UpdatedAt,
-			&binary.ConfigVersion, &binary.Source, &binary.Authenticated)
+		binary, err := scanBinary(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan binary: %w", err)
 		}
@@ -183,111 +205,20 @@ func (r *BinariesRepository) Delete(id int64) error {
 	return nil
 }
 
-// SyncFromConfig syncs binaries from config file to database
+// SyncFromConfig syncs binaries from config file to database, deleting config binaries
+// no longer in config
 func (r *BinariesRepository) SyncFromConfig(configBinaries []ConfigBinary, configVersion int) error {
-	// Get existing binaries from database
-	existing, err := r.List()
+	plan, err := r.PlanSync(configBinaries, "")
 	if err != nil {
-		return fmt.Errorf("failed to list existing binaries: %w", err)
-	}
-
-	// Create map of existing binaries by user_id
-	existingMap := make(map[string]*database.Binary)
-	for _, b := range existing {
-		existingMap[b.UserID] = b
-	}
-
-	// Track which user_ids are in config
-	configUserIDs := make(map[string]bool)
-
-	// Upsert binaries from config
-	for _, cb := range configBinaries {
-		configUserIDs[cb.ID] = true
-
-		// Compute digest for config binary
-		authenticatedStr := fmt.Sprintf("%t", cb.Authenticated)
-		configDigest := crypto.ComputeDigest(
-			cb.ID, cb.Name, cb.Alias, cb.Provider, cb.Path,
-			cb.InstallPath, cb.Format, cb.AssetRegex, cb.ReleaseRegex,
-			authenticatedStr,
-		)
-
-		if existingBinary, exists := existingMap[cb.ID]; exists {
-			// Check if config has changed using digest comparison
-			if existingBinary.ConfigDigest == configDigest {
-				log.Printf("Binary %s unchanged, skipping update", cb.ID)
-				continue
-			}
-
-			log.Printf("Binary %s changed, updating", cb.ID)
-
-			// Update existing binary
-			existingBinary.Name = cb.Name
-			existingBinary.Alias = stringToPtr(cb.Alias)
-			existingBinary.Provider = cb.Provider
-			existingBinary.ProviderPath = cb.Path
-			existingBinary.InstallPath = stringToPtr(cb.InstallPath)
-			existingBinary.Format = cb.Format
-			existingBinary.AssetRegex = stringToPtr(cb.AssetRegex)
-			existingBinary.ReleaseRegex = stringToPtr(cb.ReleaseRegex)
-			existingBinary.Authenticated = cb.Authenticated
-			existingBinary.ConfigDigest = configDigest
-			existingBinary.ConfigVersion = configVersion
-			existingBinary.Source = "config"
-
-			if err := r.Update(existingBinary); err != nil {
-				return fmt.Errorf("failed to update binary %s: %w", cb.ID, err)
-			}
-		} else {
-			log.Printf("Binary %s not found, creating", cb.ID)
-
-			// Create new binary
-			binary := &database.Binary{
-				UserID:        cb.ID,
-				Name:          cb.Name,
-				Alias:         stringToPtr(cb.Alias),
-				Provider:      cb.Provider,
-				ProviderPath:  cb.Path,
-				InstallPath:   stringToPtr(cb.InstallPath),
-				Format:        cb.Format,
-				AssetRegex:    stringToPtr(cb.AssetRegex),
-				ReleaseRegex:  stringToPtr(cb.ReleaseRegex),
-				Authenticated: cb.Authenticated,
-				ConfigDigest:  configDigest,
-				ConfigVersion: configVersion,
-				Source:        "config",
-			}
-
-			if err := r.Create(binary); err != nil {
-				return fmt.Errorf("failed to create binary %s: %w", cb.ID, err)
-			}
-		}
-	}
-
-	// Remove binaries that are no longer in config (but only config-managed binaries)
-	for userID, binary := range existingMap {
-		if !configUserIDs[userID] && binary.Source == "config" {
-			log.Printf("Binary %s removed from config, deleting", userID)
-			if err := r.Delete(binary.ID); err != nil {
-				return fmt.Errorf("failed to delete binary %s: %w", userID, err)
-			}
-		} else if !configUserIDs[userID] && binary.Source == "manual" {
-			log.Printf("Binary %s not in config but manually added, keeping", userID)
-		}
+		return err
 	}
-
-	return nil
+	return r.ApplySync(plan, configVersion, false)
 }
 
 // SyncBinary syncs a single binary from config to database by user ID
 func (r *BinariesRepository) SyncBinary(configBinary ConfigBinary, configVersion int) error {
 	// Compute digest for config binary
-	authenticatedStr := fmt.Sprintf("%t", configBinary.Authenticated)
-	configDigest := crypto.ComputeDigest(
-		configBinary.ID, configBinary.Name, configBinary.Alias, configBinary.Provider,
-		configBinary.Path, configBinary.InstallPath, configBinary.Format,
-		configBinary.AssetRegex, configBinary.ReleaseRegex, authenticatedStr,
-	)
+	configDigest := configBinary.digest()
 
 	// Check if binary exists
 	existingBinary, err := r.GetByUserID(configBinary.ID)
@@ -298,21 +229,8 @@ func (r *BinariesRepository) SyncBinary(configBinary ConfigBinary, configVersion
 	if err == database.ErrNotFound {
 		// Create new binary
 		log.Printf("Binary %s not found, creating", configBinary.ID)
-		binary := &database.Binary{
-			UserID:        configBinary.ID,
-			Name:          configBinary.Name,
-			Alias:         stringToPtr(configBinary.Alias),
-			Provider:      configBinary.Provider,
-			ProviderPath:  configBinary.Path,
-			InstallPath:   stringToPtr(configBinary.InstallPath),
-			Format:        configBinary.Format,
-			AssetRegex:    stringToPtr(configBinary.AssetRegex),
-			ReleaseRegex:  stringToPtr(configBinary.ReleaseRegex),
-			Authenticated: configBinary.Authenticated,
-			ConfigDigest:  configDigest,
-			ConfigVersion: configVersion,
-			Source:        "config",
-		}
+		binary := &database.Binary{UserID: configBinary.ID}
+		applyConfigBinary(binary, configBinary, configDigest, configVersion)
 
 		if err := r.Create(binary); err != nil {
 			return fmt.Errorf("failed to create binary %s: %w", configBinary.ID, err)
@@ -329,18 +247,7 @@ func (r *BinariesRepository) SyncBinary(configBinary ConfigBinary, configVersion
 	log.Printf("Binary %s changed, updating", configBinary.ID)
 
 	// Update existing binary
-	existingBinary.Name = configBinary.Name
-	existingBinary.Alias = stringToPtr(configBinary.Alias)
-	existingBinary.Provider = configBinary.Provider
-	existingBinary.ProviderPath = configBinary.Path
-	existingBinary.InstallPath = stringToPtr(configBinary.InstallPath)
-	existingBinary.Format = configBinary.Format
-	existingBinary.AssetRegex = stringToPtr(configBinary.AssetRegex)
-	existingBinary.ReleaseRegex = stringToPtr(configBinary.ReleaseRegex)
-	existingBinary.Authenticated = configBinary.Authenticated
-	existingBinary.ConfigDigest = configDigest
-	existingBinary.ConfigVersion = configVersion
-	existingBinary.Source = "config"
+	applyConfigBinary(existingBinary, configBinary, configDigest, configVersion)
 
 	if err := r.Update(existingBinary); err != nil {
 		return fmt.Errorf("failed to update binary %s: %w", configBinary.ID, err)
@@ -349,6 +256,32 @@ func (r *BinariesRepository) SyncBinary(configBinary ConfigBinary, configVersion
 	return nil
 }
 
+// applyConfigBinary copies config fields onto a database binary
+func applyConfigBinary(binary *database.Binary, cb ConfigBinary, configDigest string, configVersion int) {
+	binary.Name = cb.Name
+	binary.Alias = stringToPtr(cb.Alias)
+	binary.Provider = cb.Provider
+	binary.ProviderPath = cb.Path
+	binary.InstallPath = stringToPtr(cb.InstallPath)
+	binary.Format = cb.Format
+	binary.AssetRegex = stringToPtr(cb.AssetRegex)
+	binary.ReleaseRegex = stringToPtr(cb.ReleaseRegex)
+	binary.Authenticated = cb.Authenticated
+	binary.VersionConstraint = stringToPtr(cb.Version)
+	binary.Channel = stringToPtr(cb.Channel)
+	binary.NightlyRegex = stringToPtr(cb.NightlyRegex)
+	binary.KeepVersions = intToPtr(cb.KeepVersions)
+	binary.Shim = cb.Shim
+	binary.BinPath = stringToPtr(cb.BinPath)
+	binary.ConfigDigest = configDigest
+	binary.ConfigVersion = configVersion
+	binary.Source = "config"
+	binary.ConfigFile = stringToPtr(cb.ProjectFile)
+	if cb.ProjectFile != "" {
+		binary.Source = "project"
+	}
+}
+
 func stringToPtr(s string) *string {
 	if s == "" {
 		return nil
@@ -356,27 +289,36 @@ func stringToPtr(s string) *string {
 	return &s
 }
 
+func intToPtr(n int) *int {
+	if n == 0 {
+		return nil
+	}
+	return &n
+}
+
 // BinaryWithVersionDetails represents a binary with version and installation metadata
 type BinaryWithVersionDetails struct {
 	Binary             *database.Binary
 	ActiveVersion      string
 	InstallCount       int
 	ActiveInstallation *database.Installation
+	PinnedVersion      string // Empty unless the binary is pinned
 }
 
 // ListWithVersionDetails retrieves all binaries with their version metadata using a single optimized query
 func (r *BinariesRepository) ListWithVersionDetails(noActiveVersionLabel string) ([]*BinaryWithVersionDetails, error) {
 	query := `
 		SELECT 
-			b.id, b.user_id, b.name, b.alias, b.provider, b.provider_path, b.install_path,
-			b.format, b.asset_regex, b.release_regex, b.config_digest, b.created_at, b.updated_at, b.config_version, b.source, b.authenticated,
+			` + prefixedBinaryColumns("b") + `,
 			COALESCE(i.version, ?) as active_version,
 			COALESCE(install_count.count, 0) as install_count,
 			i.id as installation_id, i.installed_path, i.source_url, i.file_size,
-			i.checksum, i.checksum_algorithm, i.installed_at
+			i.checksum, i.checksum_algorithm, i.installed_at,
+			COALESCE(p.version, '') as pinned_version
 		FROM binaries b
 		LEFT JOIN versions v ON b.id = v.binary_id
 		LEFT JOIN installations i ON v.installation_id = i.id
+		LEFT JOIN pins p ON b.id = p.binary_id
 		LEFT JOIN (
 			SELECT binary_id, COUNT(*) as count
 			FROM installations
@@ -403,14 +345,12 @@ func (r *BinariesRepository) ListWithVersionDetails(noActiveVersionLabel string)
 		var installedPath, sourceURL, checksum, checksumAlgorithm *string
 		var fileSize *int64
 
-		err := rows.Scan(
-			&binary.ID, &binary.UserID, &binary.Name, &binary.Alias,
-			&binary.Provider, &binary.ProviderPath, &binary.InstallPath, &binary.Format,
-			&binary.AssetRegex, &binary.ReleaseRegex, &binary.ConfigDigest, &binary.CreatedAt, &binary.UpdatedAt,
-			&binary.ConfigVersion, &binary.Source, &binary.Authenticated, &activeVersion, &details.InstallCount,
+		err := rows.Scan(append(binaryFields(binary),
+			&activeVersion, &details.InstallCount,
 			&installationID, &installedPath, &sourceURL, &fileSize,
 			&checksum, &checksumAlgorithm, &installedAt,
-		)
+			&details.PinnedVersion,
+		)...)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan binary version details: %w", err)
 		}
@@ -1,5 +1,11 @@
 package repository
 
+import (
+	"fmt"
+
+	"cturner8/binmate/internal/core/crypto"
+)
+
 // ConfigBinary represents a binary from config file for syncing
 type ConfigBinary struct {
 	ID            string
@@ -12,4 +18,43 @@ type ConfigBinary struct {
 	AssetRegex    string
 	ReleaseRegex  string
 	Authenticated bool
+	Version       string // Semver constraint for latest release resolution
+	Channel       string // Release channel: stable, prerelease or nightly
+	NightlyRegex  string // Tag pattern for the nightly channel
+	KeepVersions  int    // Installed versions retained after updates, 0 to keep all
+	Shim          bool   // Dispatch through a shim honouring per-project version files
+	BinPath       string // Path of the binary inside the release archive
+	ProjectFile   string // Project config file declaring the binary, empty if the user's config declares it
+}
+
+// digest computes the config digest used to detect changes between syncs
+func (cb ConfigBinary) digest() string {
+	fields := []string{
+		cb.ID, cb.Name, cb.Alias, cb.Provider, cb.Path,
+		cb.InstallPath, cb.Format, cb.AssetRegex, cb.ReleaseRegex,
+		fmt.Sprintf("%t", cb.Authenticated),
+	}
+	// Only include new fields when set so existing digests remain stable
+	if cb.Version != "" {
+		fields = append(fields, "version="+cb.Version)
+	}
+	if cb.Channel != "" {
+		fields = append(fields, "channel="+cb.Channel)
+	}
+	if cb.NightlyRegex != "" {
+		fields = append(fields, "nightlyRegex="+cb.NightlyRegex)
+	}
+	if cb.KeepVersions != 0 {
+		fields = append(fields, fmt.Sprintf("keepVersions=%d", cb.KeepVersions))
+	}
+	if cb.Shim {
+		fields = append(fields, "shim=true")
+	}
+	if cb.BinPath != "" {
+		fields = append(fields, "binPath="+cb.BinPath)
+	}
+	if cb.ProjectFile != "" {
+		fields = append(fields, "projectFile="+cb.ProjectFile)
+	}
+	return crypto.ComputeDigest(fields...)
 }
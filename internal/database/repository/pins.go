@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"cturner8/binmate/internal/database"
+)
+
+type PinsRepository struct {
+	db *database.DB
+}
+
+func NewPinsRepository(db *database.DB) *PinsRepository {
+	return &PinsRepository{db: db}
+}
+
+// Set pins a binary to a version, replacing any existing pin
+func (r *PinsRepository) Set(binaryID int64, version string, source string) error {
+	now := time.Now().Unix()
+
+	_, err := r.db.Exec(`
+INSERT INTO pins (binary_id, version, source, pinned_at)
+VALUES (?, ?, ?, ?)
+ON CONFLICT(binary_id) DO UPDATE SET
+version = excluded.version,
+source = excluded.source,
+pinned_at = excluded.pinned_at
+`, binaryID, version, source, now)
+
+	if err != nil {
+		return fmt.Errorf("failed to set pin: %w", err)
+	}
+
+	return nil
+}
+
+// Get retrieves the pin for a binary
+func (r *PinsRepository) Get(binaryID int64) (*database.Pin, error) {
+	pin := &database.Pin{}
+	err := r.db.QueryRow(`
+SELECT binary_id, version, source, pinned_at
+FROM pins WHERE binary_id = ?
+`, binaryID).Scan(&pin.BinaryID, &pin.Version, &pin.Source, &pin.PinnedAt)
+
+	if err == sql.ErrNoRows {
+		return nil, database.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pin: %w", err)
+	}
+
+	return pin, nil
+}
+
+// Delete removes the pin for a binary
+func (r *PinsRepository) Delete(binaryID int64) error {
+	result, err := r.db.Exec(`DELETE FROM pins WHERE binary_id = ?`, binaryID)
+	if err != nil {
+		return fmt.Errorf("failed to delete pin: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return database.ErrNotFound
+	}
+
+	return nil
+}
@@ -10,6 +10,7 @@ type Service struct {
 	Binaries      *BinariesRepository
 	Installations *InstallationsRepository
 	Versions      *VersionsRepository
+	Pins          *PinsRepository
 	Downloads     *DownloadsRepository
 	Logs          *LogsRepository
 }
@@ -21,6 +22,7 @@ func NewService(db *database.DB) *Service {
 		Binaries:      NewBinariesRepository(db),
 		Installations: NewInstallationsRepository(db),
 		Versions:      NewVersionsRepository(db),
+		Pins:          NewPinsRepository(db),
 		Downloads:     NewDownloadsRepository(db),
 		Logs:          NewLogsRepository(db),
 	}
@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+
+	"cturner8/binmate/internal/database"
+)
+
+// SyncPlan lists the changes syncing binaries from config would make to the database
+type SyncPlan struct {
+	Create    []ConfigBinary     // Binaries in config but not the database
+	Update    []BinaryUpdate     // Binaries whose config changed
+	Remove    []*database.Binary // Config and project binaries no longer in config
+	Unchanged []string           // User IDs of binaries matching config
+}
+
+// BinaryUpdate is a change to a stored binary from config
+type BinaryUpdate struct {
+	Binary  *database.Binary // Binary as stored
+	Config  ConfigBinary
+	Changes []FieldChange // Fields that differ, empty if only the config digest changed
+}
+
+// FieldChange is a binary setting changed by a sync. Values are quoted strings, numbers or
+// booleans, and empty for unset values.
+type FieldChange struct {
+	Field string // Config key, e.g. "assetRegex"
+	Old   string
+	New   string
+}
+
+// Empty reports whether syncing would leave the database unchanged
+func (p *SyncPlan) Empty() bool {
+	return len(p.Create) == 0 && len(p.Update) == 0 && len(p.Remove) == 0
+}
+
+// PlanSync compares config binaries with the database without changing it. projectFile is
+// the project config file read with the config, if any; binaries declared by other project
+// configs are kept as their config was not read.
+func (r *BinariesRepository) PlanSync(configBinaries []ConfigBinary, projectFile string) (*SyncPlan, error) {
+	existing, err := r.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list existing binaries: %w", err)
+	}
+
+	existingMap := make(map[string]*database.Binary)
+	for _, b := range existing {
+		existingMap[b.UserID] = b
+	}
+
+	plan := &SyncPlan{}
+	configUserIDs := make(map[string]bool)
+	for _, cb := range configBinaries {
+		configUserIDs[cb.ID] = true
+
+		existingBinary, exists := existingMap[cb.ID]
+		if !exists {
+			plan.Create = append(plan.Create, cb)
+			continue
+		}
+
+		// Check if config has changed using digest comparison
+		configDigest := cb.digest()
+		if existingBinary.ConfigDigest == configDigest {
+			plan.Unchanged = append(plan.Unchanged, cb.ID)
+			continue
+		}
+
+		desired := *existingBinary
+		applyConfigBinary(&desired, cb, configDigest, existingBinary.ConfigVersion)
+		plan.Update = append(plan.Update, BinaryUpdate{
+			Binary:  existingBinary,
+			Config:  cb,
+			Changes: diffBinaries(existingBinary, &desired),
+		})
+	}
+
+	// Only config-managed binaries are removed, manually added ones are kept
+	for userID, binary := range existingMap {
+		if configUserIDs[userID] {
+			continue
+		}
+		fromProject := binary.Source == "project" && binary.ConfigFile != nil && *binary.ConfigFile == projectFile
+		if binary.Source == "config" || fromProject {
+			plan.Remove = append(plan.Remove, binary)
+		}
+	}
+	sort.Slice(plan.Remove, func(i, j int) bool { return plan.Remove[i].UserID < plan.Remove[j].UserID })
+
+	return plan, nil
+}
+
+// ApplySync makes the changes of a sync plan. Binaries removed from config are deleted,
+// cascading their installations, unless keepRemoved is set.
+func (r *BinariesRepository) ApplySync(plan *SyncPlan, configVersion int, keepRemoved bool) error {
+	for _, userID := range plan.Unchanged {
+		log.Printf("Binary %s unchanged, skipping update", userID)
+	}
+
+	for _, update := range plan.Update {
+		log.Printf("Binary %s changed, updating", update.Config.ID)
+
+		applyConfigBinary(update.Binary, update.Config, update.Config.digest(), configVersion)
+		if err := r.Update(update.Binary); err != nil {
+			return fmt.Errorf("failed to update binary %s: %w", update.Config.ID, err)
+		}
+	}
+
+	for _, cb := range plan.Create {
+		log.Printf("Binary %s not found, creating", cb.ID)
+
+		binary := &database.Binary{UserID: cb.ID}
+		applyConfigBinary(binary, cb, cb.digest(), configVersion)
+		if err := r.Create(binary); err != nil {
+			return fmt.Errorf("failed to create binary %s: %w", cb.ID, err)
+		}
+	}
+
+	for _, binary := range plan.Remove {
+		if keepRemoved {
+			log.Printf("Binary %s removed from config, keeping", binary.UserID)
+			continue
+		}
+		log.Printf("Binary %s removed from config, deleting", binary.UserID)
+		if err := r.Delete(binary.ID); err != nil {
+			return fmt.Errorf("failed to delete binary %s: %w", binary.UserID, err)
+		}
+	}
+
+	return nil
+}
+
+// Fields returns the settings a binary is created with from config, as changes from unset
+func (cb ConfigBinary) Fields() []FieldChange {
+	binary := &database.Binary{}
+	applyConfigBinary(binary, cb, "", 0)
+	return diffBinaries(&database.Binary{}, binary)
+}
+
+// binaryValues returns the settings of a binary by config key in config order, as changes
+// to the value with Old unset. Unset values are empty.
+func binaryValues(binary *database.Binary) []FieldChange {
+	quote := func(s *string) string {
+		if s == nil || *s == "" {
+			return ""
+		}
+		return strconv.Quote(*s)
+	}
+	keepVersions := ""
+	if binary.KeepVersions != nil {
+		keepVersions = strconv.Itoa(*binary.KeepVersions)
+	}
+	flag := func(b bool) string {
+		if !b {
+			return ""
+		}
+		return "true"
+	}
+
+	return []FieldChange{
+		{Field: "name", New: quote(&binary.Name)},
+		{Field: "alias", New: quote(binary.Alias)},
+		{Field: "provider", New: quote(&binary.Provider)},
+		{Field: "path", New: quote(&binary.ProviderPath)},
+		{Field: "installPath", New: quote(binary.InstallPath)},
+		{Field: "format", New: quote(&binary.Format)},
+		{Field: "assetRegex", New: quote(binary.AssetRegex)},
+		{Field: "releaseRegex", New: quote(binary.ReleaseRegex)},
+		{Field: "authenticated", New: flag(binary.Authenticated)},
+		{Field: "version", New: quote(binary.VersionConstraint)},
+		{Field: "channel", New: quote(binary.Channel)},
+		{Field: "nightlyRegex", New: quote(binary.NightlyRegex)},
+		{Field: "keepVersions", New: keepVersions},
+		{Field: "shim", New: flag(binary.Shim)},
+		{Field: "binPath", New: quote(binary.BinPath)},
+		{Field: "source", New: quote(&binary.Source)},
+	}
+}
+
+// diffBinaries returns the config fields that differ between two binaries
+func diffBinaries(old, new *database.Binary) []FieldChange {
+	oldFields, newFields := binaryValues(old), binaryValues(new)
+	var changes []FieldChange
+	for i, field := range newFields {
+		if oldFields[i].New != field.New {
+			changes = append(changes, FieldChange{Field: field.Field, Old: oldFields[i].New, New: field.New})
+		}
+	}
+	return changes
+}
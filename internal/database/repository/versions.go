@@ -16,11 +16,18 @@ func NewVersionsRepository(db *database.DB) *VersionsRepository {
 	return &VersionsRepository{db: db}
 }
 
-// Set activates a specific version for a binary
+// Set activates a specific version for a binary, recording the activation in its history
+// unless the version was already the most recent activation
 func (r *VersionsRepository) Set(binaryID, installationID int64, symlinkPath string) error {
 	now := time.Now().Unix()
 
-	_, err := r.db.Exec(`
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
 INSERT INTO versions (binary_id, installation_id, activated_at, symlink_path)
 VALUES (?, ?, ?, ?)
 ON CONFLICT(binary_id) DO UPDATE SET
@@ -28,14 +35,51 @@ installation_id = excluded.installation_id,
 activated_at = excluded.activated_at,
 symlink_path = excluded.symlink_path
 `, binaryID, installationID, now, symlinkPath)
-
 	if err != nil {
 		return fmt.Errorf("failed to set active version: %w", err)
 	}
 
+	_, err = tx.Exec(`
+INSERT INTO activations (binary_id, installation_id, activated_at)
+SELECT ?, ?, ?
+WHERE COALESCE((SELECT installation_id FROM activations WHERE binary_id = ? ORDER BY id DESC LIMIT 1), 0) != ?
+`, binaryID, installationID, now, binaryID, installationID)
+	if err != nil {
+		return fmt.Errorf("failed to record activation: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit active version: %w", err)
+	}
+
 	return nil
 }
 
+// History lists the activations of a binary, most recent first.
+// Activations of since-deleted installations are removed with them.
+func (r *VersionsRepository) History(binaryID int64) ([]*database.Activation, error) {
+	rows, err := r.db.Query(`
+SELECT id, binary_id, installation_id, activated_at
+FROM activations WHERE binary_id = ?
+ORDER BY id DESC
+`, binaryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list activation history: %w", err)
+	}
+	defer rows.Close()
+
+	var activations []*database.Activation
+	for rows.Next() {
+		activation := &database.Activation{}
+		if err := rows.Scan(&activation.ID, &activation.BinaryID, &activation.InstallationID, &activation.ActivatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan activation: %w", err)
+		}
+		activations = append(activations, activation)
+	}
+
+	return activations, rows.Err()
+}
+
 // Get retrieves the active version for a binary
 func (r *VersionsRepository) Get(binaryID int64) (*database.Version, error) {
 	version := &database.Version{}
@@ -119,3 +119,65 @@ CREATE INDEX IF NOT EXISTS idx_logs_status_type_timestamp ON logs(operation_stat
 INSERT OR IGNORE INTO migrations (version, applied_at, description)
 VALUES (1, strftime('%s', 'now'), 'Initial schema');
 `
+
+// AddVersionConstraint stores the semver constraint used to resolve the latest release
+const AddVersionConstraint = `
+ALTER TABLE binaries ADD COLUMN version_constraint TEXT;
+`
+
+// AddPins creates the pins table holding binaries at a fixed version
+const AddPins = `
+CREATE TABLE IF NOT EXISTS pins (
+    binary_id INTEGER PRIMARY KEY,
+    version TEXT NOT NULL,
+    source TEXT NOT NULL DEFAULT 'manual',
+    pinned_at INTEGER NOT NULL,
+
+    FOREIGN KEY (binary_id) REFERENCES binaries(id) ON DELETE CASCADE
+);
+`
+
+// AddShims marks binaries whose command dispatches through a shim rather than a symlink
+const AddShims = `
+ALTER TABLE binaries ADD COLUMN shim INTEGER NOT NULL DEFAULT 0;
+`
+
+// AddConfigFiles stores the project config file defining binaries only a project config declares
+const AddConfigFiles = `
+ALTER TABLE binaries ADD COLUMN config_file TEXT;
+`
+
+// AddBinPaths stores the path of the binary inside release archives
+const AddBinPaths = `
+ALTER TABLE binaries ADD COLUMN bin_path TEXT;
+`
+
+// AddKeepVersions stores the per-binary retention policy applied after updates
+const AddKeepVersions = `
+ALTER TABLE binaries ADD COLUMN keep_versions INTEGER;
+`
+
+// AddActivationHistory records every version activation so binaries can be rolled back,
+// seeded with the currently active versions
+const AddActivationHistory = `
+CREATE TABLE IF NOT EXISTS activations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    binary_id INTEGER NOT NULL,
+    installation_id INTEGER NOT NULL,
+    activated_at INTEGER NOT NULL,
+
+    FOREIGN KEY (binary_id) REFERENCES binaries(id) ON DELETE CASCADE,
+    FOREIGN KEY (installation_id) REFERENCES installations(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_activations_binary_id ON activations(binary_id, id DESC);
+
+INSERT INTO activations (binary_id, installation_id, activated_at)
+SELECT binary_id, installation_id, activated_at FROM versions;
+`
+
+// AddReleaseChannels stores the release channel used to resolve the latest release
+const AddReleaseChannels = `
+ALTER TABLE binaries ADD COLUMN channel TEXT;
+ALTER TABLE binaries ADD COLUMN nightly_regex TEXT;
+`
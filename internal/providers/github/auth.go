@@ -10,7 +10,10 @@ import (
 // If authenticated is true, it reads the GITHUB_TOKEN environment variable
 // and adds the Authorization header to all requests.
 func CreateHTTPClient(authenticated bool) (*http.Client, error) {
-	client := &http.Client{}
+	client := &http.Client{
+		Transport: baseTransport(),
+		Timeout:   currentSettings().Timeout,
+	}
 
 	if !authenticated {
 		return client, nil
@@ -24,7 +27,7 @@ func CreateHTTPClient(authenticated bool) (*http.Client, error) {
 	// Create a custom transport that adds the Authorization header
 	transport := &authenticatedTransport{
 		token:     token,
-		transport: http.DefaultTransport,
+		transport: client.Transport,
 	}
 
 	client.Transport = transport
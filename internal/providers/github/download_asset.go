@@ -1,25 +1,38 @@
 package github
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
+
+	"cturner8/binmate/internal/core/progress"
 )
 
-func DownloadAsset(providerPath string, assetId int, assetName string, authenticated bool) (string, error) {
+func DownloadAsset(ctx context.Context, providerPath string, assetId int, assetName string, authenticated bool) (string, error) {
+	return DownloadAssetWithProgress(ctx, providerPath, assetId, assetName, 0, authenticated, nil)
+}
+
+// DownloadAssetWithProgress downloads a release asset, reporting progress to onProgress if provided.
+// The total size is taken from Content-Length, falling back to size (e.g. ReleaseAsset.Size).
+// The asset is written to a new file in the cache directory, which the caller removes once used.
+func DownloadAssetWithProgress(ctx context.Context, providerPath string, assetId int, assetName string, size int64, authenticated bool, onProgress progress.Func) (string, error) {
 	// Create HTTP client with optional authentication
 	client, err := CreateHTTPClient(authenticated)
 	if err != nil {
 		return "", fmt.Errorf("failed to create HTTP client: %w", err)
 	}
+	// Large assets can take longer than the API request timeout to download. Connections that
+	// stall are still caught by ConnectTimeout and ResponseHeaderTimeout, and ctx cancels them.
+	client.Timeout = 0
 
 	// Get the asset via the GitHub API rather than the `BrowserDownloadUrl` to support authentication.
 	// BrowserDownloadUrl is a `github.com` URL which does not accept a bearer token.
 	url := fmt.Sprintf("https://api.github.com/repos/%s/releases/assets/%d", providerPath, assetId)
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return "", fmt.Errorf("failed to create request: %w", err)
 	}
@@ -42,31 +55,33 @@ func DownloadAsset(providerPath string, assetId int, assetName string, authentic
 		return "", fmt.Errorf("unable to locate cache directory: %w", err)
 	}
 
+	// Each download gets its own file, so concurrent installs of the same asset do not collide
 	basePath := filepath.Join(cacheDir, "binmate")
-	destPath := filepath.Join(basePath, assetName)
-	tmp, err := os.CreateTemp(os.TempDir(), assetName+".*")
+	if err := os.MkdirAll(basePath, 0o755); err != nil {
+		return "", fmt.Errorf("create destination path: %w", err)
+	}
+	tmp, err := os.CreateTemp(basePath, assetName+".*")
 	if err != nil {
-		return "", fmt.Errorf("create temp file: %w", err)
+		return "", fmt.Errorf("create download file: %w", err)
+	}
+
+	total := response.ContentLength
+	if total <= 0 {
+		total = size
 	}
+	body := progress.NewReader(response.Body, total, onProgress)
 
-	if _, err := io.Copy(tmp, response.Body); err != nil {
+	if _, err := io.Copy(tmp, body); err != nil {
 		tmp.Close()
 		os.Remove(tmp.Name())
 		return "", fmt.Errorf("write asset: %w", err)
 	}
+	body.Finish()
 
 	if err := tmp.Close(); err != nil {
 		os.Remove(tmp.Name())
-		return "", fmt.Errorf("close temp file: %w", err)
-	}
-
-	if err := os.MkdirAll(basePath, 0o755); err != nil {
-		return "", fmt.Errorf("create destination path: %w", err)
-	}
-	if err := os.Rename(tmp.Name(), destPath); err != nil {
-		os.Remove(tmp.Name())
-		return "", fmt.Errorf("finalise asset: %w", err)
+		return "", fmt.Errorf("close download file: %w", err)
 	}
 
-	return destPath, nil
+	return tmp.Name(), nil
 }
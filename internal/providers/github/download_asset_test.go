@@ -0,0 +1,134 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"cturner8/binmate/internal/database"
+)
+
+// useTestServer routes GitHub API requests to a test server via a mirror
+func useTestServer(t *testing.T, handler http.HandlerFunc) {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	if err := Configure(ClientSettings{Mirrors: []Mirror{{URL: server.URL}}}); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	t.Cleanup(func() { Configure(ClientSettings{}) })
+}
+
+func TestDownloadAsset(t *testing.T) {
+	cacheDir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", cacheDir)
+	t.Setenv("HOME", cacheDir)
+
+	useTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/owner/repo/releases/assets/42" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("asset content"))
+	})
+
+	path, err := DownloadAsset(context.Background(), "owner/repo", 42, "asset.tar.gz", false)
+	if err != nil {
+		t.Fatalf("DownloadAsset() error = %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read downloaded asset: %v", err)
+	}
+	if string(content) != "asset content" {
+		t.Errorf("content = %q, want %q", content, "asset content")
+	}
+
+	// Downloads of the same asset, e.g. by concurrent installs, do not share a path
+	other, err := DownloadAsset(context.Background(), "owner/repo", 42, "asset.tar.gz", false)
+	if err != nil {
+		t.Fatalf("DownloadAsset() error = %v", err)
+	}
+	if other == path {
+		t.Errorf("Expected a new path for each download, got %s twice", path)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("Expected the first download to be kept: %v", err)
+	}
+}
+
+func TestDownloadAsset_IgnoresTimeout(t *testing.T) {
+	cacheDir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", cacheDir)
+	t.Setenv("HOME", cacheDir)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A slow asset takes longer than the API request timeout to download
+		w.Write([]byte("asset "))
+		w.(http.Flusher).Flush()
+		time.Sleep(200 * time.Millisecond)
+		w.Write([]byte("content"))
+	}))
+	t.Cleanup(server.Close)
+
+	settings := ClientSettings{Mirrors: []Mirror{{URL: server.URL}}, Timeout: 50 * time.Millisecond}
+	if err := Configure(settings); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	t.Cleanup(func() { Configure(ClientSettings{}) })
+
+	path, err := DownloadAsset(context.Background(), "owner/repo", 42, "asset.tar.gz", false)
+	if err != nil {
+		t.Fatalf("DownloadAsset() error = %v", err)
+	}
+	if content, _ := os.ReadFile(path); string(content) != "asset content" {
+		t.Errorf("content = %q, want %q", content, "asset content")
+	}
+
+	// API requests are still limited by the timeout
+	binary := &database.Binary{ProviderPath: "owner/repo"}
+	if _, _, err := FetchReleaseAsset(context.Background(), binary, "latest"); err == nil {
+		t.Error("expected API request to time out")
+	}
+}
+
+func TestDownloadAsset_Cancelled(t *testing.T) {
+	cacheDir := t.TempDir()
+	tempDir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", cacheDir)
+	t.Setenv("HOME", cacheDir)
+	t.Setenv("TMPDIR", tempDir)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	useTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "1000000")
+		w.Write([]byte("partial"))
+		w.(http.Flusher).Flush()
+
+		// Cancel mid-download, then stall until the client disconnects
+		cancel()
+		<-r.Context().Done()
+	})
+
+	_, err := DownloadAsset(ctx, "owner/repo", 42, "asset.tar.gz", false)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("DownloadAsset() error = %v, want context.Canceled", err)
+	}
+
+	if entries, _ := os.ReadDir(tempDir); len(entries) != 0 {
+		t.Errorf("expected temp files to be removed, found %d", len(entries))
+	}
+	if _, err := os.Stat(filepath.Join(cacheDir, "binmate", "asset.tar.gz")); !os.IsNotExist(err) {
+		t.Error("partial asset should not be moved into the cache")
+	}
+}
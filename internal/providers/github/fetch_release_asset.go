@@ -1,13 +1,14 @@
 package github
 
 import (
+	"context"
+	"cturner8/binmate/internal/core/semver"
 	"cturner8/binmate/internal/database"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
-	"regexp"
 	"strings"
 )
 
@@ -26,7 +27,10 @@ type Release struct {
 	Assets  []ReleaseAsset `json:"assets"`
 }
 
-func FetchReleaseAsset(binary *database.Binary, version string) (Release, ReleaseAsset, error) {
+// FetchReleaseAsset fetches a release by its exact tag, or the repository's latest release
+// for "latest", and selects the asset matching the binary's platform and format.
+// Versions without their tag prefix are resolved to tags by the install service.
+func FetchReleaseAsset(ctx context.Context, binary *database.Binary, version string) (Release, ReleaseAsset, error) {
 	if binary.ProviderPath == "" {
 		log.Panicln("path is required for binary config")
 	}
@@ -34,31 +38,7 @@ func FetchReleaseAsset(binary *database.Binary, version string) (Release, Releas
 	// default to latest release
 	url := fmt.Sprintf("https://api.github.com/repos/%s/releases/latest", binary.ProviderPath)
 	if version != "latest" {
-		tag := version
-		if binary.ReleaseRegex != nil && *binary.ReleaseRegex != "" {
-			// Apply regex pattern to transform version to GitHub release tag format.
-			// This handles cases where releases use different tag formats (e.g., "v1.0.0" vs "1.0.0").
-			//
-			// Examples:
-			//   - Simple prefix: "^v" validates that version starts with "v", otherwise prepends it
-			//   - Pattern matching: "^cli-v(.+)" would match "cli-v1.0.0" from input "1.0.0"
-			//
-			// The regex is validated at compile time to catch configuration errors early.
-			re, err := regexp.Compile(*binary.ReleaseRegex)
-			if err != nil {
-				return Release{}, ReleaseAsset{}, fmt.Errorf("invalid releaseRegex pattern '%s': %w", *binary.ReleaseRegex, err)
-			}
-
-			// Check if version already matches the expected pattern
-			if !re.MatchString(version) {
-				// Version doesn't match - apply pattern as prefix to transform it
-				// e.g., "1.0.0" with pattern "v" becomes "v1.0.0"
-				tag = *binary.ReleaseRegex + version
-			}
-			// else: version already matches pattern, use as-is
-		}
-
-		url = fmt.Sprintf("https://api.github.com/repos/%s/releases/tags/%s", binary.ProviderPath, tag)
+		url = fmt.Sprintf("https://api.github.com/repos/%s/releases/tags/%s", binary.ProviderPath, version)
 	}
 
 	// Create HTTP client with optional authentication
@@ -67,7 +47,12 @@ func FetchReleaseAsset(binary *database.Binary, version string) (Release, Releas
 		return Release{}, ReleaseAsset{}, fmt.Errorf("failed to create HTTP client: %w", err)
 	}
 
-	response, err := client.Get(url)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return Release{}, ReleaseAsset{}, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	response, err := client.Do(req)
 	if err != nil {
 		return Release{}, ReleaseAsset{}, fmt.Errorf("download asset: %w", err)
 	}
@@ -100,7 +85,12 @@ func FetchReleaseAsset(binary *database.Binary, version string) (Release, Releas
 	filter := NewAssetFilter()
 	filter.Extension = binary.Format // e.g., ".tar.gz", ".zip"
 	if binary.AssetRegex != nil {
-		filter.AssetRegex = *binary.AssetRegex // custom regex if provided
+		releaseRegex := ""
+		if binary.ReleaseRegex != nil {
+			releaseRegex = *binary.ReleaseRegex
+		}
+		// custom regex if provided, with the version of tags such as "bun-v1.2.3" expanded without their prefix
+		filter.AssetRegex = ExpandVersion(*binary.AssetRegex, semver.StripTagPrefix(release.TagName, releaseRegex))
 	}
 
 	// Filter assets based on platform, architecture, and format
@@ -0,0 +1,35 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"cturner8/binmate/internal/database"
+)
+
+func TestFetchReleaseAsset_ExpandsVersion(t *testing.T) {
+	useTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/oven-sh/bun/releases/latest" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"tag_name": "bun-v1.2.3", "assets": [
+			{"id": 1, "name": "bun-v1.2.2-linux-x64.zip"},
+			{"id": 2, "name": "bun-1.2.3-linux-x64.zip"}
+		]}`))
+	})
+
+	assetRegex := `^bun-{{.Version}}-linux-x64\.zip$`
+	releaseRegex := "^bun-v"
+	binary := &database.Binary{ProviderPath: "oven-sh/bun", Format: ".zip", AssetRegex: &assetRegex, ReleaseRegex: &releaseRegex}
+
+	_, asset, err := FetchReleaseAsset(context.Background(), binary, "latest")
+	if err != nil {
+		t.Fatalf("FetchReleaseAsset() error = %v", err)
+	}
+	if asset.Id != 2 {
+		t.Errorf("asset = %s, want bun-1.2.3-linux-x64.zip", asset.Name)
+	}
+}
@@ -17,6 +17,17 @@ type AssetFilter struct {
 	AssetRegex string // optional regex pattern from config
 }
 
+// VersionPlaceholder stands in for the release version in the asset regex of binaries
+// without a pinned version, as their version is only known once a release is fetched
+const VersionPlaceholder = "{{.Version}}"
+
+// ExpandVersion replaces the version placeholder in an asset regex with the version of a
+// release tag, without its leading "v" and quoted to match literally. Tag prefixes matched
+// by a releaseRegex are stripped by the caller.
+func ExpandVersion(assetRegex, tag string) string {
+	return strings.ReplaceAll(assetRegex, VersionPlaceholder, regexp.QuoteMeta(strings.TrimPrefix(tag, "v")))
+}
+
 // NewAssetFilter creates a filter with current platform defaults
 func NewAssetFilter() AssetFilter {
 	return AssetFilter{
@@ -269,6 +269,24 @@ func TestNewAssetFilter(t *testing.T) {
 	}
 }
 
+func TestExpandVersion(t *testing.T) {
+	tests := []struct {
+		regex string
+		tag   string
+		want  string
+	}{
+		{"gh_{{.Version}}_linux_amd64", "v2.40.0", `gh_2\.40\.0_linux_amd64`},
+		{"rg-{{.Version}}-x86_64", "14.1.0", `rg-14\.1\.0-x86_64`},
+		{"linux-amd64", "v1.0.0", "linux-amd64"},
+	}
+
+	for _, tt := range tests {
+		if got := ExpandVersion(tt.regex, tt.tag); got != tt.want {
+			t.Errorf("ExpandVersion(%q, %q) = %q, want %q", tt.regex, tt.tag, got, tt.want)
+		}
+	}
+}
+
 func TestFilterMostRelevant(t *testing.T) {
 	result, err := SelectBestAsset([]ReleaseAsset{
 		{Id: 1, Name: "app-baseline-profile.tar.gz"},
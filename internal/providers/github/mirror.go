@@ -0,0 +1,135 @@
+package github
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// defaultMirrorOrigin is the origin rewritten by a mirror when none is configured
+const defaultMirrorOrigin = "https://api.github.com"
+
+// Mirror rewrites requests for an origin URL prefix to an alternative base URL,
+// e.g. an Artifactory or Nexus "remote GitHub" repository.
+type Mirror struct {
+	Origin      string // URL prefix to rewrite, defaults to https://api.github.com
+	URL         string // Base URL requests are rewritten to
+	ForwardAuth bool   // Whether to forward the GitHub token to the mirror
+}
+
+// normaliseMirrors validates mirror definitions and applies defaults
+func normaliseMirrors(mirrors []Mirror) ([]Mirror, error) {
+	normalised := make([]Mirror, 0, len(mirrors))
+	for i, m := range mirrors {
+		if m.Origin == "" {
+			m.Origin = defaultMirrorOrigin
+		}
+		if m.URL == "" {
+			return nil, fmt.Errorf("mirror %d: url is required", i)
+		}
+
+		for _, raw := range []string{m.Origin, m.URL} {
+			parsed, err := url.Parse(raw)
+			if err != nil {
+				return nil, fmt.Errorf("mirror %d: invalid URL '%s': %w", i, raw, err)
+			}
+			if parsed.Scheme != "http" && parsed.Scheme != "https" {
+				return nil, fmt.Errorf("mirror %d: URL '%s' must use http or https", i, raw)
+			}
+		}
+
+		m.Origin = strings.TrimSuffix(m.Origin, "/")
+		m.URL = strings.TrimSuffix(m.URL, "/")
+		normalised = append(normalised, m)
+	}
+	return normalised, nil
+}
+
+// mirrorTransport is an http.RoundTripper that sends requests through the
+// configured mirrors in order, falling back to the origin if every mirror fails.
+type mirrorTransport struct {
+	mirrors   []Mirror
+	transport http.RoundTripper
+}
+
+func (t *mirrorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	original := req.URL.String()
+
+	var candidates []Mirror
+	for _, m := range t.mirrors {
+		if original == m.Origin || strings.HasPrefix(original, m.Origin+"/") {
+			candidates = append(candidates, m)
+		}
+	}
+
+	// Requests with a body cannot be replayed against the next candidate
+	if len(candidates) == 0 || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+		return t.transport.RoundTrip(req)
+	}
+
+	for _, m := range candidates {
+		mirrored, err := mirrorRequest(req, m, original)
+		if err != nil {
+			log.Printf("Warning: skipping mirror %s: %v", m.URL, err)
+			continue
+		}
+
+		response, err := t.transport.RoundTrip(mirrored)
+		if err == nil && !shouldFallback(response.StatusCode) {
+			return response, nil
+		}
+
+		if err != nil {
+			log.Printf("Mirror %s failed for %s: %v, trying next source", m.URL, original, err)
+		} else {
+			log.Printf("Mirror %s returned %s for %s, trying next source", m.URL, response.Status, original)
+			response.Body.Close()
+		}
+	}
+
+	// Fall back to the origin
+	fallback := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("failed to replay request body: %w", err)
+		}
+		fallback.Body = body
+	}
+	return t.transport.RoundTrip(fallback)
+}
+
+// mirrorRequest clones a request with its URL rewritten to the mirror
+func mirrorRequest(req *http.Request, m Mirror, original string) (*http.Request, error) {
+	target, err := url.Parse(m.URL + strings.TrimPrefix(original, m.Origin))
+	if err != nil {
+		return nil, fmt.Errorf("invalid mirrored URL: %w", err)
+	}
+
+	mirrored := req.Clone(req.Context())
+	mirrored.URL = target
+	mirrored.Host = target.Host
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("failed to replay request body: %w", err)
+		}
+		mirrored.Body = body
+	}
+
+	// Don't leak the GitHub token to third-party hosts unless explicitly allowed
+	if !m.ForwardAuth {
+		mirrored.Header.Del("Authorization")
+	}
+
+	return mirrored, nil
+}
+
+// shouldFallback reports whether a mirror response should be retried against the next source.
+// Mirrors commonly return 404 when the upstream artifact has not been cached.
+func shouldFallback(statusCode int) bool {
+	return statusCode == http.StatusNotFound || statusCode >= http.StatusInternalServerError
+}
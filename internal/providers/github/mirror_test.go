@@ -0,0 +1,182 @@
+package github
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMirrorTransport(t *testing.T) {
+	tests := []struct {
+		name         string
+		mirrorStatus []int // Status returned by each mirror, in order
+		wantBody     string
+		wantHits     []int // Expected request count for each mirror
+		wantOrigin   int   // Expected request count for the origin
+	}{
+		{
+			name:         "first mirror serves request",
+			mirrorStatus: []int{http.StatusOK, http.StatusOK},
+			wantBody:     "mirror-0",
+			wantHits:     []int{1, 0},
+			wantOrigin:   0,
+		},
+		{
+			name:         "falls back to second mirror on 404",
+			mirrorStatus: []int{http.StatusNotFound, http.StatusOK},
+			wantBody:     "mirror-1",
+			wantHits:     []int{1, 1},
+			wantOrigin:   0,
+		},
+		{
+			name:         "falls back to origin when all mirrors fail",
+			mirrorStatus: []int{http.StatusBadGateway, http.StatusNotFound},
+			wantBody:     "origin",
+			wantHits:     []int{1, 1},
+			wantOrigin:   1,
+		},
+		{
+			name:         "client errors are returned from mirror",
+			mirrorStatus: []int{http.StatusForbidden},
+			wantBody:     "mirror-0",
+			wantHits:     []int{1},
+			wantOrigin:   0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			originHits := 0
+			origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				originHits++
+				io.WriteString(w, "origin")
+			}))
+			defer origin.Close()
+
+			hits := make([]int, len(tt.mirrorStatus))
+			mirrors := make([]Mirror, len(tt.mirrorStatus))
+			for i, status := range tt.mirrorStatus {
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					hits[i]++
+					if r.URL.Path != "/prefix/repos/owner/repo/releases/latest" {
+						t.Errorf("mirror %d got path %s", i, r.URL.Path)
+					}
+					w.WriteHeader(status)
+					fmt.Fprintf(w, "mirror-%d", i)
+				}))
+				defer server.Close()
+				mirrors[i] = Mirror{Origin: origin.URL, URL: server.URL + "/prefix/"}
+			}
+
+			mirrors, err := normaliseMirrors(mirrors)
+			if err != nil {
+				t.Fatalf("normaliseMirrors() error = %v", err)
+			}
+
+			client := &http.Client{Transport: &mirrorTransport{mirrors: mirrors, transport: http.DefaultTransport}}
+			resp, err := client.Get(origin.URL + "/repos/owner/repo/releases/latest")
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			defer resp.Body.Close()
+
+			body, _ := io.ReadAll(resp.Body)
+			if string(body) != tt.wantBody {
+				t.Errorf("body = %q, want %q", body, tt.wantBody)
+			}
+			for i, want := range tt.wantHits {
+				if hits[i] != want {
+					t.Errorf("mirror %d hits = %d, want %d", i, hits[i], want)
+				}
+			}
+			if originHits != tt.wantOrigin {
+				t.Errorf("origin hits = %d, want %d", originHits, tt.wantOrigin)
+			}
+		})
+	}
+}
+
+func TestMirrorTransportAuthorization(t *testing.T) {
+	tests := []struct {
+		name        string
+		forwardAuth bool
+		wantAuth    string
+	}{
+		{name: "strips token by default", forwardAuth: false, wantAuth: ""},
+		{name: "forwards token when enabled", forwardAuth: true, wantAuth: "Bearer secret"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotAuth string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotAuth = r.Header.Get("Authorization")
+			}))
+			defer server.Close()
+
+			transport := &authenticatedTransport{
+				token: "secret",
+				transport: &mirrorTransport{
+					mirrors:   []Mirror{{Origin: "https://api.github.com", URL: server.URL, ForwardAuth: tt.forwardAuth}},
+					transport: http.DefaultTransport,
+				},
+			}
+			client := &http.Client{Transport: transport}
+
+			resp, err := client.Get("https://api.github.com/repos/owner/repo")
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			resp.Body.Close()
+
+			if gotAuth != tt.wantAuth {
+				t.Errorf("Authorization = %q, want %q", gotAuth, tt.wantAuth)
+			}
+		})
+	}
+}
+
+func TestNormaliseMirrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		mirrors    []Mirror
+		wantOrigin string
+		wantErr    bool
+	}{
+		{
+			name:       "defaults origin to GitHub API",
+			mirrors:    []Mirror{{URL: "https://artifactory.example.com/github/"}},
+			wantOrigin: "https://api.github.com",
+		},
+		{
+			name:    "missing url",
+			mirrors: []Mirror{{Origin: "https://github.com"}},
+			wantErr: true,
+		},
+		{
+			name:    "unsupported scheme",
+			mirrors: []Mirror{{URL: "ftp://mirror.example.com"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normaliseMirrors(tt.mirrors)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("normaliseMirrors() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got[0].Origin != tt.wantOrigin {
+				t.Errorf("Origin = %q, want %q", got[0].Origin, tt.wantOrigin)
+			}
+			if got[0].URL != "https://artifactory.example.com/github" {
+				t.Errorf("URL = %q, trailing slash should be trimmed", got[0].URL)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package github
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// noProxyList matches request hosts that should bypass the configured proxy.
+// Entries follow the common NO_PROXY conventions: "*" matches everything, a
+// domain matches itself and its subdomains (a leading "." is optional), an
+// optional ":port" restricts the match to that port, and CIDR ranges match IPs.
+type noProxyList struct {
+	all      bool
+	networks []*net.IPNet
+	hosts    []noProxyHost
+}
+
+type noProxyHost struct {
+	domain string
+	port   string
+}
+
+// parseNoProxy parses no-proxy entries
+func parseNoProxy(entries []string) (noProxyList, error) {
+	var list noProxyList
+	for _, entry := range entries {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+		if entry == "*" {
+			list.all = true
+			continue
+		}
+		if strings.Contains(entry, "/") {
+			_, network, err := net.ParseCIDR(entry)
+			if err != nil {
+				return noProxyList{}, fmt.Errorf("invalid no-proxy CIDR '%s': %w", entry, err)
+			}
+			list.networks = append(list.networks, network)
+			continue
+		}
+
+		host, port := entry, ""
+		if h, p, err := net.SplitHostPort(entry); err == nil {
+			host, port = h, p
+		}
+		list.hosts = append(list.hosts, noProxyHost{
+			domain: strings.TrimPrefix(host, "."),
+			port:   port,
+		})
+	}
+	return list, nil
+}
+
+// matches reports whether the URL should bypass the proxy
+func (l noProxyList) matches(u *url.URL) bool {
+	if l.all {
+		return true
+	}
+
+	host := strings.ToLower(u.Hostname())
+	port := u.Port()
+	if port == "" {
+		switch u.Scheme {
+		case "https":
+			port = "443"
+		case "http":
+			port = "80"
+		}
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		for _, network := range l.networks {
+			if network.Contains(ip) {
+				return true
+			}
+		}
+	}
+
+	for _, h := range l.hosts {
+		if h.port != "" && h.port != port {
+			continue
+		}
+		if host == h.domain || strings.HasSuffix(host, "."+h.domain) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,11 +1,13 @@
 package github
 
 import (
+	"context"
 	"cturner8/binmate/internal/database"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"regexp"
 	"sort"
 	"strings"
 	"time"
@@ -34,7 +36,7 @@ type RepositoryInfo struct {
 }
 
 // FetchReleaseNotes fetches the release notes for a specific version
-func FetchReleaseNotes(binary *database.Binary, version string) (ReleaseInfo, error) {
+func FetchReleaseNotes(ctx context.Context, binary *database.Binary, version string) (ReleaseInfo, error) {
 	if binary.ProviderPath == "" {
 		return ReleaseInfo{}, fmt.Errorf("path is required for binary config")
 	}
@@ -47,7 +49,7 @@ func FetchReleaseNotes(binary *database.Binary, version string) (ReleaseInfo, er
 		url = fmt.Sprintf("https://api.github.com/repos/%s/releases/tags/%s", binary.ProviderPath, version)
 	}
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return ReleaseInfo{}, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -58,7 +60,7 @@ func FetchReleaseNotes(binary *database.Binary, version string) (ReleaseInfo, er
 	client, err := CreateHTTPClient(binary.Authenticated)
 	if err != nil {
 		// If authentication fails, fall back to unauthenticated
-		client = &http.Client{}
+		client, _ = CreateHTTPClient(false)
 	}
 
 	resp, err := client.Do(req)
@@ -80,8 +82,16 @@ func FetchReleaseNotes(binary *database.Binary, version string) (ReleaseInfo, er
 	return releaseInfo, nil
 }
 
-// ListAvailableVersions fetches all available release versions for a binary
-func ListAvailableVersions(binary *database.Binary, limit int) ([]ReleaseInfo, error) {
+// releasesPerPage is the page size requested when listing releases (GitHub's maximum)
+const releasesPerPage = 100
+
+// maxListPages bounds the pages of releases listed for available versions, as a releaseRegex
+// matching few tags would otherwise walk the repository's whole release history
+const maxListPages = 5
+
+// ListAvailableVersions fetches up to limit available release versions for a binary,
+// listing at most maxListPages pages of releases
+func ListAvailableVersions(ctx context.Context, binary *database.Binary, limit int) ([]ReleaseInfo, error) {
 	if binary.ProviderPath == "" {
 		return nil, fmt.Errorf("path is required for binary config")
 	}
@@ -90,11 +100,152 @@ func ListAvailableVersions(binary *database.Binary, limit int) ([]ReleaseInfo, e
 		limit = 30 // Default limit
 	}
 
-	url := fmt.Sprintf("https://api.github.com/repos/%s/releases?per_page=%d", binary.ProviderPath, limit)
+	pattern, err := TagPattern(binary)
+	if err != nil {
+		return nil, err
+	}
+
+	// Filtered pages hold fewer matches, so full pages are listed when a pattern is set
+	perPage := min(limit, releasesPerPage)
+	if pattern != nil {
+		perPage = releasesPerPage
+	}
 
-	req, err := http.NewRequest("GET", url, nil)
+	// Filter out drafts and other components' tags, following pagination until the limit is reached
+	var filteredReleases []ReleaseInfo
+	pages := 0
+	err = walkReleasePages(ctx, binary, perPage, func(releases []ReleaseInfo) bool {
+		pages++
+		for _, release := range releases {
+			if !release.Draft && (pattern == nil || pattern.MatchString(release.TagName)) {
+				filteredReleases = append(filteredReleases, release)
+			}
+			if len(filteredReleases) == limit {
+				return false
+			}
+		}
+		return pages < maxListPages
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
+	}
+
+	// Sort by published date (newest first)
+	sort.Slice(filteredReleases, func(i, j int) bool {
+		return filteredReleases[i].PublishedAt.After(filteredReleases[j].PublishedAt)
+	})
+
+	return filteredReleases, nil
+}
+
+// TagPattern compiles the binary's releaseRegex, which selects the release tags belonging
+// to the binary (e.g. "^bun-v" in a repository publishing several components).
+// It returns nil if no pattern is configured.
+func TagPattern(binary *database.Binary) (*regexp.Regexp, error) {
+	if binary.ReleaseRegex == nil || *binary.ReleaseRegex == "" {
+		return nil, nil
+	}
+
+	re, err := regexp.Compile(*binary.ReleaseRegex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid releaseRegex pattern '%s': %w", *binary.ReleaseRegex, err)
+	}
+	return re, nil
+}
+
+// ReleasePage is a single page of releases with the URL of the following page
+type ReleasePage struct {
+	Releases []ReleaseInfo
+	NextURL  string // Empty on the last page
+}
+
+// ListReleasesPage fetches one page of non-draft releases whose tags match the binary's
+// releaseRegex, newest first. A page may be empty when no tags on it match.
+// An empty pageURL fetches the first page; pass ReleasePage.NextURL to load the next one.
+func ListReleasesPage(ctx context.Context, binary *database.Binary, pageURL string, perPage int) (ReleasePage, error) {
+	if binary.ProviderPath == "" {
+		return ReleasePage{}, fmt.Errorf("path is required for binary config")
+	}
+
+	pattern, err := TagPattern(binary)
+	if err != nil {
+		return ReleasePage{}, err
+	}
+
+	if pageURL == "" {
+		if perPage <= 0 || perPage > releasesPerPage {
+			perPage = releasesPerPage
+		}
+		pageURL = fmt.Sprintf("https://api.github.com/repos/%s/releases?per_page=%d", binary.ProviderPath, perPage)
+	}
+
+	releases, next, err := fetchReleasePage(ctx, binary, pageURL)
+	if err != nil {
+		return ReleasePage{}, err
+	}
+
+	page := ReleasePage{NextURL: next}
+	for _, release := range releases {
+		if !release.Draft && (pattern == nil || pattern.MatchString(release.TagName)) {
+			page.Releases = append(page.Releases, release)
+		}
+	}
+	return page, nil
+}
+
+// WalkReleases calls fn for every release of a binary, newest first, following
+// pagination until fn returns false or no pages remain
+func WalkReleases(ctx context.Context, binary *database.Binary, fn func(ReleaseInfo) bool) error {
+	if binary.ProviderPath == "" {
+		return fmt.Errorf("path is required for binary config")
+	}
+
+	return walkReleases(ctx, binary, releasesPerPage, fn)
+}
+
+func walkReleases(ctx context.Context, binary *database.Binary, perPage int, fn func(ReleaseInfo) bool) error {
+	return walkReleasePages(ctx, binary, perPage, func(releases []ReleaseInfo) bool {
+		for _, release := range releases {
+			if !fn(release) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// WalkReleasePages calls fn for each page of a binary's releases, newest first, until fn
+// returns false or no pages remain. Each page is one API request.
+func WalkReleasePages(ctx context.Context, binary *database.Binary, fn func([]ReleaseInfo) bool) error {
+	if binary.ProviderPath == "" {
+		return fmt.Errorf("path is required for binary config")
+	}
+
+	return walkReleasePages(ctx, binary, releasesPerPage, fn)
+}
+
+func walkReleasePages(ctx context.Context, binary *database.Binary, perPage int, fn func([]ReleaseInfo) bool) error {
+	url := fmt.Sprintf("https://api.github.com/repos/%s/releases?per_page=%d", binary.ProviderPath, perPage)
+
+	for url != "" {
+		releases, next, err := fetchReleasePage(ctx, binary, url)
+		if err != nil {
+			return err
+		}
+		if !fn(releases) {
+			return nil
+		}
+		url = next
+	}
+
+	return nil
+}
+
+// fetchReleasePage fetches a single page of releases, returning the URL of the next page if any
+func fetchReleasePage(ctx context.Context, binary *database.Binary, url string) ([]ReleaseInfo, string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Accept", "application/vnd.github+json")
@@ -103,50 +254,59 @@ func ListAvailableVersions(binary *database.Binary, limit int) ([]ReleaseInfo, e
 	client, err := CreateHTTPClient(binary.Authenticated)
 	if err != nil {
 		// If authentication fails, fall back to unauthenticated
-		client = &http.Client{}
+		client, _ = CreateHTTPClient(false)
 	}
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch releases: %w", err)
+		return nil, "", fmt.Errorf("failed to fetch releases: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("GitHub API returned %d: %s", resp.StatusCode, string(body))
+		return nil, "", fmt.Errorf("GitHub API returned %d: %s", resp.StatusCode, string(body))
 	}
 
 	var releases []ReleaseInfo
 	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
-		return nil, fmt.Errorf("failed to decode releases: %w", err)
+		return nil, "", fmt.Errorf("failed to decode releases: %w", err)
 	}
 
-	// Filter out drafts
-	var filteredReleases []ReleaseInfo
-	for _, release := range releases {
-		if !release.Draft {
-			filteredReleases = append(filteredReleases, release)
+	return releases, nextPageURL(resp.Header.Get("Link")), nil
+}
+
+// nextPageURL extracts the rel="next" URL from a Link response header
+func nextPageURL(link string) string {
+	for _, part := range strings.Split(link, ",") {
+		segments := strings.Split(part, ";")
+		if len(segments) < 2 {
+			continue
 		}
-	}
 
-	// Sort by published date (newest first)
-	sort.Slice(filteredReleases, func(i, j int) bool {
-		return filteredReleases[i].PublishedAt.After(filteredReleases[j].PublishedAt)
-	})
+		target := strings.TrimSpace(segments[0])
+		if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+			continue
+		}
 
-	return filteredReleases, nil
+		for _, param := range segments[1:] {
+			if strings.ReplaceAll(strings.TrimSpace(param), " ", "") == `rel="next"` {
+				return target[1 : len(target)-1]
+			}
+		}
+	}
+	return ""
 }
 
 // GetRepositoryInfo fetches basic repository information
-func GetRepositoryInfo(binary *database.Binary) (RepositoryInfo, error) {
+func GetRepositoryInfo(ctx context.Context, binary *database.Binary) (RepositoryInfo, error) {
 	if binary.ProviderPath == "" {
 		return RepositoryInfo{}, fmt.Errorf("path is required for binary config")
 	}
 
 	url := fmt.Sprintf("https://api.github.com/repos/%s", binary.ProviderPath)
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return RepositoryInfo{}, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -157,7 +317,7 @@ func GetRepositoryInfo(binary *database.Binary) (RepositoryInfo, error) {
 	client, err := CreateHTTPClient(binary.Authenticated)
 	if err != nil {
 		// If authentication fails, fall back to unauthenticated
-		client = &http.Client{}
+		client, _ = CreateHTTPClient(false)
 	}
 
 	resp, err := client.Do(req)
@@ -180,7 +340,7 @@ func GetRepositoryInfo(binary *database.Binary) (RepositoryInfo, error) {
 }
 
 // StarRepository stars the configured repository for the authenticated GitHub user.
-func StarRepository(binary *database.Binary) error {
+func StarRepository(ctx context.Context, binary *database.Binary) error {
 	if binary.ProviderPath == "" {
 		return fmt.Errorf("path is required for binary config")
 	}
@@ -191,7 +351,7 @@ func StarRepository(binary *database.Binary) error {
 	}
 
 	url := fmt.Sprintf("https://api.github.com/user/starred/%s/%s", pathParts[0], pathParts[1])
-	req, err := http.NewRequest("PUT", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "PUT", url, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
@@ -0,0 +1,179 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"cturner8/binmate/internal/database"
+)
+
+func TestNextPageURL(t *testing.T) {
+	tests := []struct {
+		name string
+		link string
+		want string
+	}{
+		{name: "empty header", link: "", want: ""},
+		{
+			name: "next and last",
+			link: `<https://api.github.com/repositories/1/releases?page=2>; rel="next", <https://api.github.com/repositories/1/releases?page=5>; rel="last"`,
+			want: "https://api.github.com/repositories/1/releases?page=2",
+		},
+		{
+			name: "last page",
+			link: `<https://api.github.com/repositories/1/releases?page=4>; rel="prev", <https://api.github.com/repositories/1/releases?page=1>; rel="first"`,
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextPageURL(tt.link); got != tt.want {
+				t.Errorf("nextPageURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWalkReleases(t *testing.T) {
+	const total = 5
+	requests := 0
+
+	useTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page < 1 {
+			page = 1
+		}
+
+		if page < total {
+			w.Header().Set("Link", fmt.Sprintf(`<https://api.github.com/repos/owner/repo/releases?page=%d>; rel="next"`, page+1))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `[{"tag_name": "v1.0.%d"}]`, total-page)
+	})
+
+	binary := &database.Binary{ProviderPath: "owner/repo"}
+
+	var tags []string
+	err := WalkReleases(context.Background(), binary, func(release ReleaseInfo) bool {
+		tags = append(tags, release.TagName)
+		return release.TagName != "v1.0.2"
+	})
+	if err != nil {
+		t.Fatalf("WalkReleases() error = %v", err)
+	}
+
+	want := []string{"v1.0.4", "v1.0.3", "v1.0.2"}
+	if fmt.Sprint(tags) != fmt.Sprint(want) {
+		t.Errorf("tags = %v, want %v", tags, want)
+	}
+	if requests != len(want) {
+		t.Errorf("requests = %d, want %d (walk should stop early)", requests, len(want))
+	}
+}
+
+func TestListAvailableVersions_ReleaseRegexBoundsScan(t *testing.T) {
+	requests := 0
+	useTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page < 1 {
+			page = 1
+		}
+
+		w.Header().Set("Link", fmt.Sprintf(`<https://api.github.com/repos/owner/repo/releases?page=%d>; rel="next"`, page+1))
+		w.Header().Set("Content-Type", "application/json")
+		if page == 2 {
+			fmt.Fprint(w, `[{"tag_name": "bun-v1.0.0"}, {"tag_name": "cli-v2.0.0"}]`)
+			return
+		}
+		fmt.Fprintf(w, `[{"tag_name": "cli-v1.%d.0"}]`, page)
+	})
+
+	releaseRegex := "^bun-v"
+	binary := &database.Binary{ProviderPath: "owner/repo", ReleaseRegex: &releaseRegex}
+
+	releases, err := ListAvailableVersions(context.Background(), binary, 10)
+	if err != nil {
+		t.Fatalf("ListAvailableVersions() error = %v", err)
+	}
+	if len(releases) != 1 || releases[0].TagName != "bun-v1.0.0" {
+		t.Errorf("releases = %+v, want only bun-v1.0.0", releases)
+	}
+	if requests != maxListPages {
+		t.Errorf("requests = %d, want %d (listing should stop)", requests, maxListPages)
+	}
+}
+
+func TestListReleasesPage(t *testing.T) {
+	useTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `[{"tag_name": "v1.0.0"}]`)
+			return
+		}
+		if got := r.URL.Query().Get("per_page"); got != "2" {
+			t.Errorf("per_page = %q, want %q", got, "2")
+		}
+		w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/releases?per_page=2&page=2>; rel="next"`)
+		fmt.Fprint(w, `[{"tag_name": "v1.2.0-draft", "draft": true}, {"tag_name": "v1.1.0"}]`)
+	})
+
+	binary := &database.Binary{ProviderPath: "owner/repo"}
+
+	first, err := ListReleasesPage(context.Background(), binary, "", 2)
+	if err != nil {
+		t.Fatalf("ListReleasesPage() error = %v", err)
+	}
+	if len(first.Releases) != 1 || first.Releases[0].TagName != "v1.1.0" {
+		t.Errorf("first page releases = %+v, want only v1.1.0 (drafts skipped)", first.Releases)
+	}
+	if first.NextURL == "" {
+		t.Fatal("first page NextURL is empty, want a next page")
+	}
+
+	second, err := ListReleasesPage(context.Background(), binary, first.NextURL, 2)
+	if err != nil {
+		t.Fatalf("ListReleasesPage() error = %v", err)
+	}
+	if len(second.Releases) != 1 || second.Releases[0].TagName != "v1.0.0" {
+		t.Errorf("second page releases = %+v, want v1.0.0", second.Releases)
+	}
+	if second.NextURL != "" {
+		t.Errorf("second page NextURL = %q, want empty on the last page", second.NextURL)
+	}
+}
+
+func TestListReleasesPage_ReleaseRegex(t *testing.T) {
+	useTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `[{"tag_name": "cli-v2.0.0"}, {"tag_name": "bun-v1.1.0"}, {"tag_name": "bun-v1.0.0"}]`)
+	})
+
+	releaseRegex := "^bun-v"
+	binary := &database.Binary{ProviderPath: "owner/repo", ReleaseRegex: &releaseRegex}
+
+	page, err := ListReleasesPage(context.Background(), binary, "", 0)
+	if err != nil {
+		t.Fatalf("ListReleasesPage() error = %v", err)
+	}
+
+	var tags []string
+	for _, release := range page.Releases {
+		tags = append(tags, release.TagName)
+	}
+	want := []string{"bun-v1.1.0", "bun-v1.0.0"}
+	if fmt.Sprint(tags) != fmt.Sprint(want) {
+		t.Errorf("tags = %v, want %v", tags, want)
+	}
+
+	invalid := "("
+	binary.ReleaseRegex = &invalid
+	if _, err := ListReleasesPage(context.Background(), binary, "", 0); err == nil {
+		t.Error("Expected error for invalid releaseRegex, got none")
+	}
+}
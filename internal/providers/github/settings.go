@@ -0,0 +1,148 @@
+package github
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+)
+
+// ClientSettings controls how HTTP clients for the GitHub provider are built
+type ClientSettings struct {
+	Mirrors               []Mirror      // Mirrors tried in order before falling back to the origin
+	Proxy                 string        // Proxy URL for all requests, overrides HTTP(S)_PROXY environment variables
+	NoProxy               []string      // Hosts, domains or CIDR ranges that bypass Proxy, or HTTP(S)_PROXY if unset
+	CACertFiles           []string      // Extra PEM files trusted in addition to the system roots
+	Timeout               time.Duration // Overall timeout of API requests, including reading the response body; not applied to downloads
+	ConnectTimeout        time.Duration // Timeout for establishing TCP connections
+	ResponseHeaderTimeout time.Duration // Timeout waiting for response headers after sending a request
+}
+
+var (
+	settingsMu sync.RWMutex
+	settings   ClientSettings
+	transport  http.RoundTripper = http.DefaultTransport
+
+	// environmentProxy picks the proxy for requests when Proxy is unset
+	environmentProxy = http.ProxyFromEnvironment
+)
+
+// Configure applies client settings to all subsequently created HTTP clients
+func Configure(s ClientSettings) error {
+	mirrors, err := normaliseMirrors(s.Mirrors)
+	if err != nil {
+		return err
+	}
+	s.Mirrors = mirrors
+
+	base, err := NewTransport(s)
+	if err != nil {
+		return err
+	}
+
+	settingsMu.Lock()
+	defer settingsMu.Unlock()
+	settings = s
+	transport = base
+	return nil
+}
+
+// NewTransport builds the network transport for the given settings, without mirrors.
+// http.DefaultTransport is used when no network settings are configured.
+func NewTransport(s ClientSettings) (http.RoundTripper, error) {
+	if s.Proxy == "" && len(s.NoProxy) == 0 && len(s.CACertFiles) == 0 &&
+		s.ConnectTimeout == 0 && s.ResponseHeaderTimeout == 0 {
+		return http.DefaultTransport, nil
+	}
+
+	base := http.DefaultTransport.(*http.Transport).Clone()
+
+	if s.Proxy != "" || len(s.NoProxy) > 0 {
+		proxy := environmentProxy
+		if s.Proxy != "" {
+			proxyURL, err := url.Parse(s.Proxy)
+			if err != nil || proxyURL.Host == "" {
+				return nil, fmt.Errorf("invalid proxy URL '%s'", s.Proxy)
+			}
+			proxy = http.ProxyURL(proxyURL)
+		}
+		noProxy, err := parseNoProxy(s.NoProxy)
+		if err != nil {
+			return nil, err
+		}
+		base.Proxy = func(req *http.Request) (*url.URL, error) {
+			if noProxy.matches(req.URL) {
+				return nil, nil
+			}
+			return proxy(req)
+		}
+	}
+
+	if len(s.CACertFiles) > 0 {
+		pool, err := loadCertPool(s.CACertFiles)
+		if err != nil {
+			return nil, err
+		}
+		base.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+
+	if s.ConnectTimeout > 0 {
+		dialer := &net.Dialer{
+			Timeout:   s.ConnectTimeout,
+			KeepAlive: 30 * time.Second,
+		}
+		base.DialContext = dialer.DialContext
+	}
+
+	if s.ResponseHeaderTimeout > 0 {
+		base.ResponseHeaderTimeout = s.ResponseHeaderTimeout
+	}
+
+	return base, nil
+}
+
+// loadCertPool returns the system certificate pool extended with the given PEM files
+func loadCertPool(files []string) (*x509.CertPool, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+
+	for _, file := range files {
+		pem, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate file: %w", err)
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no valid PEM certificates found in '%s'", file)
+		}
+	}
+
+	return pool, nil
+}
+
+// currentSettings returns the active client settings
+func currentSettings() ClientSettings {
+	settingsMu.RLock()
+	defer settingsMu.RUnlock()
+	return settings
+}
+
+// baseTransport builds the transport shared by authenticated and anonymous clients
+func baseTransport() http.RoundTripper {
+	settingsMu.RLock()
+	defer settingsMu.RUnlock()
+
+	if len(settings.Mirrors) == 0 {
+		return transport
+	}
+	return &mirrorTransport{
+		mirrors:   settings.Mirrors,
+		transport: transport,
+	}
+}
@@ -0,0 +1,167 @@
+package github
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNoProxyMatches(t *testing.T) {
+	list, err := parseNoProxy([]string{"localhost", ".internal.example.com", "example.org:8443", "10.0.0.0/8"})
+	if err != nil {
+		t.Fatalf("parseNoProxy() error = %v", err)
+	}
+
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"http://localhost:8080/path", true},
+		{"https://internal.example.com", true},
+		{"https://artifactory.internal.example.com", true},
+		{"https://example.com", false},
+		{"https://example.org:8443", true},
+		{"https://example.org", false},
+		{"https://10.1.2.3", true},
+		{"https://192.168.0.1", false},
+		{"https://api.github.com", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			u, _ := url.Parse(tt.url)
+			if got := list.matches(u); got != tt.want {
+				t.Errorf("matches(%s) = %v, want %v", tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseNoProxyInvalidCIDR(t *testing.T) {
+	if _, err := parseNoProxy([]string{"10.0.0.0/99"}); err == nil {
+		t.Error("expected error for invalid CIDR")
+	}
+}
+
+func TestNewTransportProxy(t *testing.T) {
+	transport, err := NewTransport(ClientSettings{
+		Proxy:   "http://proxy.example.com:3128",
+		NoProxy: []string{"localhost"},
+	})
+	if err != nil {
+		t.Fatalf("NewTransport() error = %v", err)
+	}
+
+	proxy := transport.(*http.Transport).Proxy
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://api.github.com/repos", "http://proxy.example.com:3128"},
+		{"http://localhost/repos", ""},
+	}
+	for _, tt := range tests {
+		req, _ := http.NewRequest("GET", tt.url, nil)
+		got, err := proxy(req)
+		if err != nil {
+			t.Fatalf("Proxy() error = %v", err)
+		}
+		gotURL := ""
+		if got != nil {
+			gotURL = got.String()
+		}
+		if gotURL != tt.want {
+			t.Errorf("Proxy(%s) = %q, want %q", tt.url, gotURL, tt.want)
+		}
+	}
+}
+
+func TestNewTransportNoProxyWithoutProxy(t *testing.T) {
+	envProxy, _ := url.Parse("http://env-proxy.example.com:3128")
+	original := environmentProxy
+	environmentProxy = http.ProxyURL(envProxy)
+	t.Cleanup(func() { environmentProxy = original })
+
+	transport, err := NewTransport(ClientSettings{NoProxy: []string{"localhost"}})
+	if err != nil {
+		t.Fatalf("NewTransport() error = %v", err)
+	}
+
+	proxy := transport.(*http.Transport).Proxy
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://api.github.com/repos", envProxy.String()},
+		{"http://localhost/repos", ""},
+	}
+	for _, tt := range tests {
+		req, _ := http.NewRequest("GET", tt.url, nil)
+		got, err := proxy(req)
+		if err != nil {
+			t.Fatalf("Proxy() error = %v", err)
+		}
+		gotURL := ""
+		if got != nil {
+			gotURL = got.String()
+		}
+		if gotURL != tt.want {
+			t.Errorf("Proxy(%s) = %q, want %q", tt.url, gotURL, tt.want)
+		}
+	}
+}
+
+func TestNewTransportCACertFiles(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	// Without the extra CA the self-signed server is rejected
+	if _, err := (&http.Client{Transport: http.DefaultTransport}).Get(server.URL); err == nil {
+		t.Fatal("expected TLS verification error without custom CA")
+	}
+
+	certFile := filepath.Join(t.TempDir(), "ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(certFile, certPEM, 0644); err != nil {
+		t.Fatalf("failed to write CA file: %v", err)
+	}
+
+	transport, err := NewTransport(ClientSettings{CACertFiles: []string{certFile}})
+	if err != nil {
+		t.Fatalf("NewTransport() error = %v", err)
+	}
+
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	if err != nil {
+		t.Fatalf("expected request to succeed with custom CA, got %v", err)
+	}
+	resp.Body.Close()
+}
+
+func TestNewTransportErrors(t *testing.T) {
+	invalidPEM := filepath.Join(t.TempDir(), "invalid.pem")
+	if err := os.WriteFile(invalidPEM, []byte("not a certificate"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		settings ClientSettings
+	}{
+		{name: "invalid proxy", settings: ClientSettings{Proxy: "://bad"}},
+		{name: "missing CA file", settings: ClientSettings{CACertFiles: []string{filepath.Join(t.TempDir(), "missing.pem")}}},
+		{name: "invalid CA file", settings: ClientSettings{CACertFiles: []string{invalidPEM}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewTransport(tt.settings); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ type BinaryWithMetadata struct {
 	ActiveVersion      string
 	InstallCount       int
 	ActiveInstallation *database.Installation
+	PinnedVersion      string // Empty unless the binary is pinned
 }
 
 // getBinariesWithMetadata fetches all binaries with their metadata using the repository method
@@ -39,6 +40,7 @@ func getBinariesWithMetadata(dbService *repository.Service) ([]BinaryWithMetadat
 			ActiveVersion:      detail.ActiveVersion,
 			InstallCount:       detail.InstallCount,
 			ActiveInstallation: detail.ActiveInstallation,
+			PinnedVersion:      detail.PinnedVersion,
 		}
 	}
 
@@ -97,6 +99,37 @@ func filterBinaries(binaries []BinaryWithMetadata, pattern string) []BinaryWithM
 	return filtered
 }
 
+// filterReleases filters releases by tag or name, using the same matching rules as filterBinaries
+func filterReleases(releases []githubReleaseInfo, pattern string) []githubReleaseInfo {
+	if pattern == "" {
+		return releases
+	}
+
+	var re *regexp.Regexp
+	if containsRegexChars(pattern) {
+		var err error
+		if re, err = regexp.Compile("(?i)" + pattern); err != nil {
+			return nil
+		}
+	}
+
+	var filtered []githubReleaseInfo
+	for _, release := range releases {
+		var matched bool
+		if re != nil {
+			matched = re.MatchString(release.TagName) || re.MatchString(release.Name)
+		} else {
+			matched = strings.Contains(strings.ToLower(release.TagName), strings.ToLower(pattern)) ||
+				strings.Contains(strings.ToLower(release.Name), strings.ToLower(pattern))
+		}
+
+		if matched {
+			filtered = append(filtered, release)
+		}
+	}
+	return filtered
+}
+
 // containsRegexChars checks if string contains regex special characters
 func containsRegexChars(s string) bool {
 	specialChars := []string{".", "*", "+", "?", "^", "$", "[", "]", "(", ")", "{", "}", "|", "\\"}
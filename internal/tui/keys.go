@@ -21,6 +21,7 @@ const (
 	keyInstall   = "i"
 	keyUpdate    = "u"
 	keyUpdateAll = "U"
+	keyRollback  = "R" // 'R' for rollback to the previous version
 	keyRemove    = "r"
 	keyCheck     = "c"
 	keyImport    = "m" // 'm' for iMport
@@ -52,7 +53,7 @@ func getHelpText(view viewState) string {
 	case viewBinariesList:
 		return "↑/↓: navigate • enter: versions • /: search • f: filter • o: sort order • n: next sort • a: add • i: install • u: update • r: remove • q: quit"
 	case viewVersions:
-		return "↑/↓: navigate • s/enter: switch • i: install • u: update • c: check • d: delete • l: release notes • g: repo info • v: versions • esc: back • q: quit"
+		return "↑/↓: navigate • s/enter: switch • i: install • u: update • R: rollback • c: check • d: delete • l: release notes • g: repo info • v: versions • esc: back • q: quit"
 	case viewAddBinaryURL:
 		return "Type URL • enter: parse • esc: cancel • q: quit"
 	case viewAddBinaryForm:
@@ -66,7 +67,7 @@ func getHelpText(view viewState) string {
 	case viewReleaseNotes:
 		return "esc: back • q: quit"
 	case viewAvailableVersions:
-		return "↑/↓: navigate • enter/l: release notes • i: install selected • esc: back • q: quit"
+		return "↑/↓: navigate • enter/l: release notes • i: install selected • /: search tags • esc: back • q: quit"
 	case viewRepositoryInfo:
 		return "s: star repository • esc: back • q: quit"
 	default:
@@ -1,6 +1,11 @@
 package tui
 
-import "cturner8/binmate/internal/database"
+import (
+	coreprogress "cturner8/binmate/internal/core/progress"
+	"cturner8/binmate/internal/database"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
 
 // Custom messages for Bubble Tea
 type (
@@ -29,6 +34,11 @@ type (
 	}
 
 	// versionDeletedMsg is sent when a version is deleted
+	versionRolledBackMsg struct {
+		installation *database.Installation // The re-activated installation
+		err          error
+	}
+
 	versionDeletedMsg struct {
 		installationID int64
 		err            error
@@ -41,6 +51,12 @@ type (
 		err          error
 	}
 
+	// downloadProgressMsg is sent while an install download is in progress
+	downloadProgressMsg struct {
+		progress coreprogress.Progress
+		next     <-chan tea.Msg // Channel delivering the next install update
+	}
+
 	// binaryUpdatedMsg is sent when a binary is updated
 	binaryUpdatedMsg struct {
 		binaryID   string
@@ -1,15 +1,20 @@
 package tui
 
 import (
+	"context"
+
 	"cturner8/binmate/internal/core/config"
+	coreprogress "cturner8/binmate/internal/core/progress"
 	"cturner8/binmate/internal/database"
 	"cturner8/binmate/internal/database/repository"
 
+	"github.com/charmbracelet/bubbles/progress"
 	"github.com/charmbracelet/bubbles/textinput"
 )
 
 type model struct {
 	// Services
+	ctx       context.Context // Cancelled when the program exits
 	dbService *repository.Service
 	config    *config.Config
 
@@ -60,7 +65,12 @@ type model struct {
 	installBinaryID      string
 	installVersionInput  textinput.Model
 	installingInProgress bool
-	installReturnView    viewState // Track which view to return to after install
+	installReturnView    viewState             // Track which view to return to after install
+	installProgressBar   progress.Model        // Download progress bar
+	installProgress      coreprogress.Progress // Latest download progress update
+	installCancel        context.CancelFunc    // Cancels the in-flight install
+	cancellingInstall    bool                  // True once cancellation of the install was requested
+	quitAfterInstall     bool                  // Quit once the cancelled install has cleaned up
 
 	// Remove confirmation state
 	confirmingRemove bool
@@ -77,7 +87,12 @@ type model struct {
 	// GitHub views state
 	githubReleaseInfo           *githubReleaseInfo
 	githubAvailableVers         []githubReleaseInfo
-	selectedAvailableVersionIdx int
+	selectedAvailableVersionIdx int    // Index into the filtered available versions
+	githubVersionsNextURL       string // Next page of releases, empty once every page is loaded
+	githubLoadingMore           bool
+	versionSearchMode           bool
+	versionSearchQuery          string
+	versionSearchInput          textinput.Model
 	githubRepoInfo              *githubRepositoryInfo
 	githubLoading               bool
 	githubError                 string
@@ -122,7 +137,16 @@ type githubRepositoryInfo struct {
 	HTMLURL     string
 }
 
-func initialModel(dbService *repository.Service, cfg *config.Config) model {
+// newInstallProgressBar creates the progress bar shown while downloading an install
+func newInstallProgressBar() progress.Model {
+	return progress.New(progress.WithDefaultGradient(), progress.WithWidth(50))
+}
+
+func initialModel(ctx context.Context, dbService *repository.Service, cfg *config.Config) model {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
 	// Create URL text input
 	urlInput := textinput.New()
 	urlInput.Placeholder = "https://github.com/owner/repo/releases/download/v1.0.0/binary.tar.gz"
@@ -162,7 +186,13 @@ func initialModel(dbService *repository.Service, cfg *config.Config) model {
 	searchInput.CharLimit = 128
 	searchInput.Width = 60
 
+	versionSearchInput := textinput.New()
+	versionSearchInput.Placeholder = "Search by tag (regex supported)..."
+	versionSearchInput.CharLimit = 128
+	versionSearchInput.Width = 60
+
 	return model{
+		ctx:                 ctx,
 		dbService:           dbService,
 		config:              cfg,
 		currentView:         viewBinariesList,
@@ -170,12 +200,14 @@ func initialModel(dbService *repository.Service, cfg *config.Config) model {
 		urlTextInput:        urlInput,
 		formInputs:          []textinput.Model{},
 		installVersionInput: versionInput,
+		installProgressBar:  newInstallProgressBar(),
 		importPathInput:     importPathInput,
 		importNameInput:     importNameInput,
 		importURLInput:      importURLInput,
 		importVersionInput:  importVersionInput,
 		searchTextInput:     searchInput,
 		searchMode:          false,
+		versionSearchInput:  versionSearchInput,
 		filteredBinaries:    []BinaryWithMetadata{},
 		activeFilters:       make(map[string]string),
 		sortMode:            "name",
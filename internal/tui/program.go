@@ -1,11 +1,13 @@
 package tui
 
 import (
+	"context"
+
 	"cturner8/binmate/internal/core/config"
 	"cturner8/binmate/internal/database/repository"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
-func InitProgram(dbService *repository.Service, cfg *config.Config) *tea.Program {
-	return tea.NewProgram(initialModel(dbService, cfg))
+func InitProgram(ctx context.Context, dbService *repository.Service, cfg *config.Config) *tea.Program {
+	return tea.NewProgram(initialModel(ctx, dbService, cfg))
 }
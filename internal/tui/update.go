@@ -1,9 +1,12 @@
 package tui
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/progress"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 
@@ -11,6 +14,8 @@ import (
 	configPkg "cturner8/binmate/internal/core/config"
 	"cturner8/binmate/internal/core/crypto"
 	installSvc "cturner8/binmate/internal/core/install"
+	"cturner8/binmate/internal/core/parallel"
+	coreprogress "cturner8/binmate/internal/core/progress"
 	urlparser "cturner8/binmate/internal/core/url"
 	versionSvc "cturner8/binmate/internal/core/version"
 	"cturner8/binmate/internal/database"
@@ -65,9 +70,35 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case downloadProgressMsg:
+		m.installProgress = msg.progress
+		var cmd tea.Cmd
+		if msg.progress.Total > 0 {
+			cmd = m.installProgressBar.SetPercent(msg.progress.Fraction())
+		}
+		return m, tea.Batch(cmd, waitForInstallUpdate(msg.next))
+
+	case progress.FrameMsg:
+		progressModel, cmd := m.installProgressBar.Update(msg)
+		if pm, ok := progressModel.(progress.Model); ok {
+			m.installProgressBar = pm
+		}
+		return m, cmd
+
 	case binaryInstalledMsg:
 		m.installingInProgress = false
-		if msg.err != nil {
+		m.cancellingInstall = false
+		if m.installCancel != nil {
+			m.installCancel()
+			m.installCancel = nil
+		}
+		if m.quitAfterInstall {
+			return m, tea.Quit
+		}
+		if errors.Is(msg.err, context.Canceled) {
+			m.errorMessage = "Installation cancelled"
+			m.successMessage = ""
+		} else if msg.err != nil {
 			m.errorMessage = msg.err.Error()
 			m.successMessage = ""
 		} else {
@@ -186,6 +217,19 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case versionRolledBackMsg:
+		if msg.err != nil {
+			m.errorMessage = msg.err.Error()
+			m.successMessage = ""
+		} else {
+			m.errorMessage = ""
+			m.successMessage = fmt.Sprintf("Rolled back to version %s", installSvc.DisplayVersion(m.selectedBinary, msg.installation.Version))
+			// Reload versions to update active indicator
+			m.loading = true
+			return m, loadVersions(m.dbService, m.selectedBinary.ID)
+		}
+		return m, nil
+
 	case versionDeletedMsg:
 		if msg.err != nil {
 			m.errorMessage = msg.err.Error()
@@ -219,12 +263,29 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case githubAvailableVersionsMsg:
+		if msg.appended {
+			// Ignore pages that arrive after the user has left the view
+			if m.currentView != viewAvailableVersions || !m.githubLoadingMore {
+				return m, nil
+			}
+			m.githubLoadingMore = false
+			if msg.err != nil {
+				m.errorMessage = fmt.Sprintf("Failed to load more versions: %v", msg.err)
+				return m, nil
+			}
+			m.githubAvailableVers = append(m.githubAvailableVers, msg.versions...)
+			m.githubVersionsNextURL = msg.next
+			return m.loadMoreVersionsIfNeeded()
+		}
+
 		m.githubLoading = false
 		if msg.err != nil {
 			m.githubError = msg.err.Error()
 		} else {
 			m.githubAvailableVers = msg.versions
+			m.githubVersionsNextURL = msg.next
 			m.selectedAvailableVersionIdx = 0
+			return m.loadMoreVersionsIfNeeded()
 		}
 		return m, nil
 
@@ -542,14 +603,14 @@ func (m model) updateBinariesList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				m.errorMessage = ""
 				m.successMessage = ""
 				m.loading = true
-				return m, updateAllBinaries(m.dbService, selectedBinariesList)
+				return m, updateAllBinaries(m.ctx, m.dbService, m.config, selectedBinariesList)
 			}
 		} else if len(binariesToShow) > 0 && m.selectedIndex < len(binariesToShow) {
 			// Single update
 			selectedBinary := binariesToShow[m.selectedIndex]
 			m.errorMessage = ""
 			m.successMessage = ""
-			return m, updateBinary(m.dbService, selectedBinary.Binary.UserID)
+			return m, updateBinary(m.ctx, m.dbService, selectedBinary.Binary.UserID)
 		}
 
 	case keyUpdateAll:
@@ -558,7 +619,7 @@ func (m model) updateBinariesList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.errorMessage = ""
 			m.successMessage = ""
 			m.loading = true
-			return m, updateAllBinaries(m.dbService, m.binaries)
+			return m, updateAllBinaries(m.ctx, m.dbService, m.config, m.binaries)
 		}
 
 	case keyRemove:
@@ -590,7 +651,7 @@ func (m model) updateBinariesList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.errorMessage = ""
 			m.successMessage = ""
 			m.loading = true
-			return m, checkForUpdates(m.dbService, selectedBinary.Binary.UserID)
+			return m, checkForUpdates(m.ctx, m.dbService, selectedBinary.Binary.UserID)
 		}
 
 	case keyImport:
@@ -688,7 +749,15 @@ func (m model) updateVersions(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if m.selectedBinary != nil {
 			m.errorMessage = ""
 			m.successMessage = ""
-			return m, updateBinary(m.dbService, m.selectedBinary.UserID)
+			return m, updateBinary(m.ctx, m.dbService, m.selectedBinary.UserID)
+		}
+
+	case keyRollback:
+		// Roll back to the previously active version
+		if m.selectedBinary != nil {
+			m.errorMessage = ""
+			m.successMessage = ""
+			return m, rollbackVersion(m.dbService, m.selectedBinary)
 		}
 
 	case keyCheck:
@@ -697,7 +766,7 @@ func (m model) updateVersions(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.errorMessage = ""
 			m.successMessage = ""
 			m.loading = true
-			return m, checkForUpdates(m.dbService, m.selectedBinary.UserID)
+			return m, checkForUpdates(m.ctx, m.dbService, m.selectedBinary.UserID)
 		}
 
 	case keyDelete, keyDelete2:
@@ -713,7 +782,7 @@ func (m model) updateVersions(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 
-			return m, deleteVersion(m.dbService, selectedInstallation)
+			return m, deleteVersion(m.dbService, m.selectedBinary.UserID, selectedInstallation)
 		}
 
 	case keyReleaseNotes:
@@ -727,7 +796,7 @@ func (m model) updateVersions(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.currentView = viewReleaseNotes
 			m.githubLoading = true
 			m.githubError = ""
-			return m, fetchReleaseNotes(m.selectedBinary, version, getDateFormat(m.config))
+			return m, fetchReleaseNotes(m.ctx, m.selectedBinary, version, getDateFormat(m.config))
 		}
 
 	case keyRepoInfo:
@@ -736,7 +805,7 @@ func (m model) updateVersions(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.currentView = viewRepositoryInfo
 			m.githubLoading = true
 			m.githubError = ""
-			return m, fetchRepositoryInfo(m.selectedBinary)
+			return m, fetchRepositoryInfo(m.ctx, m.selectedBinary)
 		}
 
 	case keyAvailVersions:
@@ -744,9 +813,13 @@ func (m model) updateVersions(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if m.selectedBinary != nil && m.selectedBinary.Provider == "github" {
 			m.currentView = viewAvailableVersions
 			m.selectedAvailableVersionIdx = 0
+			m.githubVersionsNextURL = ""
+			m.githubLoadingMore = false
+			m.versionSearchQuery = ""
+			m.versionSearchInput.Reset()
 			m.githubLoading = true
 			m.githubError = ""
-			return m, fetchAvailableVersions(m.selectedBinary, getDateFormat(m.config))
+			return m, fetchAvailableVersions(m.ctx, m.selectedBinary, "", getDateFormat(m.config))
 		}
 
 	case keyEsc:
@@ -1064,14 +1137,8 @@ func (m model) updatePlaceholderView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 // switchVersion switches the active version of a binary
 func switchVersion(dbService *repository.Service, binary *database.Binary, installation *database.Installation) tea.Cmd {
 	return func() tea.Msg {
-		// Handle optional InstallPath
-		customInstallPath := ""
-		if binary.InstallPath != nil {
-			customInstallPath = *binary.InstallPath
-		}
-
-		// Update the symlink
-		symlinkPath, err := versionSvc.SetActiveVersion(installation.InstalledPath, customInstallPath, binary.Name, binary.Alias)
+		// Update the symlink or shim
+		symlinkPath, err := versionSvc.LinkVersion(binary, installation.InstalledPath)
 		if err != nil {
 			return versionSwitchedMsg{err: fmt.Errorf("failed to set active version: %w", err)}
 		}
@@ -1085,28 +1152,42 @@ func switchVersion(dbService *repository.Service, binary *database.Binary, insta
 	}
 }
 
+// rollbackVersion re-activates the previously active version of a binary
+func rollbackVersion(dbService *repository.Service, binary *database.Binary) tea.Cmd {
+	return func() tea.Msg {
+		result, err := versionSvc.RollbackVersion(binary.UserID, 1, dbService)
+		if err != nil {
+			return versionRolledBackMsg{err: fmt.Errorf("failed to roll back: %w", err)}
+		}
+		return versionRolledBackMsg{installation: result.To}
+	}
+}
+
 // deleteVersion deletes an installation
-func deleteVersion(dbService *repository.Service, installation *database.Installation) tea.Cmd {
+func deleteVersion(dbService *repository.Service, binaryID string, installation *database.Installation) tea.Cmd {
 	return func() tea.Msg {
-		// Delete from database
-		if err := dbService.Installations.Delete(installation.ID); err != nil {
+		// Removes the installation record and its managed files
+		if _, err := versionSvc.UninstallVersion(binaryID, installation.Version, dbService); err != nil {
 			return versionDeletedMsg{err: fmt.Errorf("failed to delete version: %w", err)}
 		}
 
-		// Optionally delete files (for now we'll leave the files)
-		// In a future enhancement, we can add a confirmation dialog
-
 		return versionDeletedMsg{installationID: installation.ID, err: nil}
 	}
 }
 
 // updateInstallBinary handles updates for the install binary view
 func (m model) updateInstallBinary(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	// Don't process keys if installing
+	// Only cancellation keys are processed while installing
 	if m.installingInProgress {
 		switch msg.String() {
+		case keyEsc:
+			m.cancelInstall()
+			return m, nil
 		case keyQuit, keyCtrlC:
-			return m, tea.Quit
+			// Wait for the cancelled install to clean up before quitting
+			m.cancelInstall()
+			m.quitAfterInstall = true
+			return m, nil
 		}
 		return m, nil
 	}
@@ -1132,10 +1213,15 @@ func (m model) updateInstallBinary(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			version = "latest"
 		}
 
+		ctx, cancel := context.WithCancel(m.ctx)
+		m.installCancel = cancel
 		m.installingInProgress = true
+		m.cancellingInstall = false
+		m.installProgress = coreprogress.Progress{}
+		m.installProgressBar = newInstallProgressBar()
 		m.errorMessage = ""
 		m.successMessage = ""
-		return m, installBinary(m.dbService, m.installBinaryID, version)
+		return m, installBinary(ctx, m.dbService, m.installBinaryID, version)
 
 	case keyQuit, keyCtrlC:
 		return m, tea.Quit
@@ -1147,25 +1233,60 @@ func (m model) updateInstallBinary(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
-// installBinary installs a binary version
-func installBinary(dbService *repository.Service, binaryID string, version string) tea.Cmd {
+// installBinary installs a binary version, streaming download progress back to the model.
+// Progress updates are dropped while the model has one left to read, so a slow render never
+// holds up the download; the final binaryInstalledMsg is always delivered.
+func installBinary(ctx context.Context, dbService *repository.Service, binaryID string, version string) tea.Cmd {
 	return func() tea.Msg {
-		// Use the install service to install the binary
-		result, err := installSvc.InstallBinary(binaryID, version, dbService)
-		if err != nil {
-			return binaryInstalledMsg{err: fmt.Errorf("failed to install binary: %w", err)}
-		}
+		updates := make(chan tea.Msg, 1)
 
-		return binaryInstalledMsg{
-			binary:       result.Binary,
-			installation: result.Installation,
-			err:          nil,
-		}
+		go func() {
+			defer close(updates)
+
+			opts := installSvc.InstallOptions{
+				Progress: func(p coreprogress.Progress) {
+					select {
+					case updates <- downloadProgressMsg{progress: p, next: updates}:
+					default:
+					}
+				},
+			}
+
+			// Use the install service to install the binary
+			result, err := installSvc.InstallBinaryWithOptions(ctx, binaryID, version, dbService, opts)
+			if err != nil {
+				updates <- binaryInstalledMsg{err: fmt.Errorf("failed to install binary: %w", err)}
+				return
+			}
+
+			updates <- binaryInstalledMsg{
+				binary:       result.Binary,
+				installation: result.Installation,
+				err:          nil,
+			}
+		}()
+
+		return <-updates
+	}
+}
+
+// cancelInstall cancels the in-flight install, if any
+func (m *model) cancelInstall() {
+	if m.installCancel != nil {
+		m.installCancel()
+		m.cancellingInstall = true
+	}
+}
+
+// waitForInstallUpdate waits for the next progress or completion message from an install
+func waitForInstallUpdate(updates <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return <-updates
 	}
 }
 
 // updateBinary updates a binary to the latest version
-func updateBinary(dbService *repository.Service, binaryID string) tea.Cmd {
+func updateBinary(ctx context.Context, dbService *repository.Service, binaryID string) tea.Cmd {
 	return func() tea.Msg {
 		// Get current active version if any
 		currentVersion := "none"
@@ -1181,7 +1302,7 @@ func updateBinary(dbService *repository.Service, binaryID string) tea.Cmd {
 		}
 
 		// Use the install service to update to latest
-		result, err := installSvc.UpdateToLatest(binaryID, dbService)
+		result, err := installSvc.UpdateToLatest(ctx, binaryID, dbService)
 		if err != nil {
 			return binaryUpdatedMsg{
 				binaryID: binaryID,
@@ -1198,14 +1319,32 @@ func updateBinary(dbService *repository.Service, binaryID string) tea.Cmd {
 	}
 }
 
-// updateAllBinaries updates all binaries to their latest versions
-func updateAllBinaries(dbService *repository.Service, binaries []BinaryWithMetadata) tea.Cmd {
+// updateAllBinaries updates all binaries to their latest versions, skipping pinned binaries
+func updateAllBinaries(ctx context.Context, dbService *repository.Service, cfg *configPkg.Config, binaries []BinaryWithMetadata) tea.Cmd {
 	return func() tea.Msg {
-		updatedCount := 0
-		failedCount := 0
+		var global configPkg.GlobalConfig
+		if cfg != nil {
+			global = cfg.Global
+		}
 
+		var unpinned []BinaryWithMetadata
 		for _, b := range binaries {
-			_, err := installSvc.UpdateToLatest(b.Binary.UserID, dbService)
+			if b.PinnedVersion == "" {
+				unpinned = append(unpinned, b)
+			}
+		}
+		pinnedCount := len(binaries) - len(unpinned)
+		binaries = unpinned
+
+		workers := configPkg.ResolveConcurrency(0, global)
+		errs := parallel.Map(binaries, workers, func(b BinaryWithMetadata) error {
+			_, err := installSvc.UpdateToLatest(ctx, b.Binary.UserID, dbService)
+			return err
+		})
+
+		updatedCount := 0
+		failedCount := 0
+		for _, err := range errs {
 			if err != nil {
 				failedCount++
 			} else {
@@ -1215,7 +1354,7 @@ func updateAllBinaries(dbService *repository.Service, binaries []BinaryWithMetad
 
 		// Return a success message with the summary
 		return successMsg{
-			message: fmt.Sprintf("Updated %d binaries (%d failed)", updatedCount, failedCount),
+			message: fmt.Sprintf("Updated %d binaries (%d pinned, %d failed)", updatedCount, pinnedCount, failedCount),
 		}
 	}
 }
@@ -1263,7 +1402,7 @@ func importBinaryWithOptions(dbService *repository.Service, path string, name st
 }
 
 // checkForUpdates checks if updates are available for a binary
-func checkForUpdates(dbService *repository.Service, binaryID string) tea.Cmd {
+func checkForUpdates(ctx context.Context, dbService *repository.Service, binaryID string) tea.Cmd {
 	return func() tea.Msg {
 		// Get the binary configuration
 		binaryConfig, err := dbService.Binaries.GetByUserID(binaryID)
@@ -1281,9 +1420,8 @@ func checkForUpdates(dbService *repository.Service, binaryID string) tea.Cmd {
 			}
 		}
 
-		// Import github provider
-		// Fetch latest release
-		release, _, err := github.FetchReleaseAsset(binaryConfig, "latest")
+		// Fetch latest release, honouring any version constraint
+		release, _, err := installSvc.ResolveRelease(ctx, binaryConfig, "latest")
 		if err != nil {
 			return updateCheckMsg{
 				binaryID: binaryID,
@@ -1307,15 +1445,16 @@ func checkForUpdates(dbService *repository.Service, binaryID string) tea.Cmd {
 		_, err = dbService.Installations.Get(binaryConfig.ID, latestVersion)
 		isLatestInstalled := err == nil
 
-		// Determine if update is needed: latest not installed, or current is not latest
-		hasUpdate := !isLatestInstalled && currentVersion != latestVersion && currentVersion != "none"
+		// Determine if update is needed: latest not installed and newer than the current version
+		isNewer := currentVersion != "none" && installSvc.CompareVersions(binaryConfig, latestVersion, currentVersion) > 0
+		hasUpdate := !isLatestInstalled && isNewer
 
 		return updateCheckMsg{
 			binaryID:        binaryID,
 			currentVersion:  currentVersion,
 			latestVersion:   latestVersion,
 			hasUpdate:       hasUpdate,
-			latestInstalled: isLatestInstalled && currentVersion != latestVersion,
+			latestInstalled: isLatestInstalled && isNewer,
 			err:             nil,
 		}
 	}
@@ -1434,6 +1573,31 @@ func syncConfig(dbService *repository.Service, cfg *configPkg.Config) tea.Cmd {
 
 // updateGitHubView handles updates for GitHub-related views
 func (m model) updateGitHubView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Handle tag search input in the available versions view
+	if m.versionSearchMode {
+		switch msg.String() {
+		case keyEsc:
+			m.versionSearchMode = false
+			m.versionSearchInput.Reset()
+			m.versionSearchInput.Blur()
+			m.versionSearchQuery = ""
+			m.selectedAvailableVersionIdx = 0
+			return m, nil
+		case keyEnter:
+			m.versionSearchQuery = m.versionSearchInput.Value()
+			m.versionSearchInput.Blur()
+			m.versionSearchMode = false
+			m.selectedAvailableVersionIdx = 0
+			return m.loadMoreVersionsIfNeeded()
+		default:
+			var cmd tea.Cmd
+			m.versionSearchInput, cmd = m.versionSearchInput.Update(msg)
+			return m, cmd
+		}
+	}
+
+	versions := m.displayedAvailableVersions()
+
 	switch msg.String() {
 	case keyUp:
 		if m.currentView == viewAvailableVersions && m.selectedAvailableVersionIdx > 0 {
@@ -1442,17 +1606,26 @@ func (m model) updateGitHubView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case keyDown:
-		if m.currentView == viewAvailableVersions && m.selectedAvailableVersionIdx < len(m.githubAvailableVers)-1 {
+		if m.currentView == viewAvailableVersions && m.selectedAvailableVersionIdx < len(versions)-1 {
 			m.selectedAvailableVersionIdx++
 		}
+		return m.loadMoreVersionsIfNeeded()
+
+	case keySearch:
+		if m.currentView == viewAvailableVersions && !m.githubLoading {
+			m.versionSearchMode = true
+			m.versionSearchInput.SetValue(m.versionSearchQuery)
+			m.versionSearchInput.Focus()
+			return m, textinput.Blink
+		}
 		return m, nil
 
 	case keyInstall:
 		if m.currentView == viewAvailableVersions &&
 			m.selectedBinary != nil &&
-			len(m.githubAvailableVers) > 0 &&
-			m.selectedAvailableVersionIdx < len(m.githubAvailableVers) {
-			selectedRelease := m.githubAvailableVers[m.selectedAvailableVersionIdx]
+			len(versions) > 0 &&
+			m.selectedAvailableVersionIdx < len(versions) {
+			selectedRelease := versions[m.selectedAvailableVersionIdx]
 			version := selectedRelease.TagName
 			if version == "" {
 				version = "latest"
@@ -1470,14 +1643,14 @@ func (m model) updateGitHubView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case keyReleaseNotes, keyEnter:
 		if m.currentView == viewAvailableVersions &&
 			m.selectedBinary != nil &&
-			len(m.githubAvailableVers) > 0 &&
-			m.selectedAvailableVersionIdx < len(m.githubAvailableVers) {
-			selectedRelease := m.githubAvailableVers[m.selectedAvailableVersionIdx]
+			len(versions) > 0 &&
+			m.selectedAvailableVersionIdx < len(versions) {
+			selectedRelease := versions[m.selectedAvailableVersionIdx]
 			m.currentView = viewReleaseNotes
 			m.githubLoading = true
 			m.githubError = ""
 			m.githubReleaseInfo = nil
-			return m, fetchReleaseNotes(m.selectedBinary, selectedRelease.TagName, getDateFormat(m.config))
+			return m, fetchReleaseNotes(m.ctx, m.selectedBinary, selectedRelease.TagName, getDateFormat(m.config))
 		}
 		return m, nil
 
@@ -1485,7 +1658,7 @@ func (m model) updateGitHubView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if m.currentView == viewRepositoryInfo && m.selectedBinary != nil && m.selectedBinary.Provider == "github" {
 			m.errorMessage = ""
 			m.successMessage = ""
-			return m, starRepository(m.selectedBinary)
+			return m, starRepository(m.ctx, m.selectedBinary)
 		}
 		return m, nil
 
@@ -1498,13 +1671,24 @@ func (m model) updateGitHubView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		if m.currentView == viewAvailableVersions && m.versionSearchQuery != "" {
+			// Clear the tag search before leaving the view
+			m.versionSearchQuery = ""
+			m.versionSearchInput.Reset()
+			m.selectedAvailableVersionIdx = 0
+			return m, nil
+		}
+
 		// Return to versions view
 		m.currentView = viewVersions
 		m.githubReleaseInfo = nil
 		m.githubAvailableVers = nil
+		m.githubVersionsNextURL = ""
+		m.githubLoadingMore = false
 		m.githubRepoInfo = nil
 		m.selectedAvailableVersionIdx = 0
 		m.githubError = ""
+		m.errorMessage = ""
 		return m, nil
 
 	case keyQuit, keyCtrlC:
@@ -1522,6 +1706,8 @@ type githubRepoInfoMsg struct {
 
 type githubAvailableVersionsMsg struct {
 	versions []githubReleaseInfo
+	next     string // URL of the next page, empty on the last page
+	appended bool   // Whether this is a further page of an already loaded list
 	err      error
 }
 
@@ -1535,9 +1721,9 @@ type githubRepoStarredMsg struct {
 }
 
 // starRepository stars a GitHub repository for the authenticated user.
-func starRepository(binary *database.Binary) tea.Cmd {
+func starRepository(ctx context.Context, binary *database.Binary) tea.Cmd {
 	return func() tea.Msg {
-		if err := github.StarRepository(binary); err != nil {
+		if err := github.StarRepository(ctx, binary); err != nil {
 			return githubRepoStarredMsg{err: fmt.Errorf("failed to star repository: %w", err)}
 		}
 		return githubRepoStarredMsg{err: nil}
@@ -1545,9 +1731,9 @@ func starRepository(binary *database.Binary) tea.Cmd {
 }
 
 // fetchRepositoryInfo fetches repository information from GitHub
-func fetchRepositoryInfo(binary *database.Binary) tea.Cmd {
+func fetchRepositoryInfo(ctx context.Context, binary *database.Binary) tea.Cmd {
 	return func() tea.Msg {
-		repoInfo, err := github.GetRepositoryInfo(binary)
+		repoInfo, err := github.GetRepositoryInfo(ctx, binary)
 		if err != nil {
 			return githubRepoInfoMsg{err: err}
 		}
@@ -1565,16 +1751,44 @@ func fetchRepositoryInfo(binary *database.Binary) tea.Cmd {
 	}
 }
 
-// fetchAvailableVersions fetches available versions from GitHub
-func fetchAvailableVersions(binary *database.Binary, dateFormat string) tea.Cmd {
+// availableVersionsPageSize is the number of releases fetched per page in the available versions view
+const availableVersionsPageSize = 30
+
+// availableVersionsLoadAhead is how close to the end of the loaded list the cursor gets before the next page is fetched
+const availableVersionsLoadAhead = 5
+
+// displayedAvailableVersions returns the loaded available versions after applying the tag search
+func (m model) displayedAvailableVersions() []githubReleaseInfo {
+	return filterReleases(m.githubAvailableVers, m.versionSearchQuery)
+}
+
+// loadMoreVersionsIfNeeded fetches the next page of releases when the cursor nears the
+// end of the displayed list, so searches keep loading pages until enough tags match
+func (m model) loadMoreVersionsIfNeeded() (tea.Model, tea.Cmd) {
+	if m.githubVersionsNextURL == "" || m.githubLoadingMore || m.selectedBinary == nil {
+		return m, nil
+	}
+	if m.selectedAvailableVersionIdx < len(m.displayedAvailableVersions())-availableVersionsLoadAhead {
+		return m, nil
+	}
+
+	m.githubLoadingMore = true
+	m.errorMessage = ""
+	return m, fetchAvailableVersions(m.ctx, m.selectedBinary, m.githubVersionsNextURL, getDateFormat(m.config))
+}
+
+// fetchAvailableVersions fetches a page of available versions from GitHub.
+// An empty pageURL fetches the first page.
+func fetchAvailableVersions(ctx context.Context, binary *database.Binary, pageURL string, dateFormat string) tea.Cmd {
 	return func() tea.Msg {
-		releases, err := github.ListAvailableVersions(binary, 20)
+		appended := pageURL != ""
+		page, err := github.ListReleasesPage(ctx, binary, pageURL, availableVersionsPageSize)
 		if err != nil {
-			return githubAvailableVersionsMsg{err: err}
+			return githubAvailableVersionsMsg{appended: appended, err: err}
 		}
 
 		var versions []githubReleaseInfo
-		for _, release := range releases {
+		for _, release := range page.Releases {
 			versions = append(versions, githubReleaseInfo{
 				Name:        release.Name,
 				TagName:     release.TagName,
@@ -1585,14 +1799,14 @@ func fetchAvailableVersions(binary *database.Binary, dateFormat string) tea.Cmd
 			})
 		}
 
-		return githubAvailableVersionsMsg{versions: versions}
+		return githubAvailableVersionsMsg{versions: versions, next: page.NextURL, appended: appended}
 	}
 }
 
 // fetchReleaseNotes fetches release notes from GitHub for a specific version
-func fetchReleaseNotes(binary *database.Binary, version string, dateFormat string) tea.Cmd {
+func fetchReleaseNotes(ctx context.Context, binary *database.Binary, version string, dateFormat string) tea.Cmd {
 	return func() tea.Msg {
-		releaseInfo, err := github.FetchReleaseNotes(binary, version)
+		releaseInfo, err := github.FetchReleaseNotes(ctx, binary, version)
 		if err != nil {
 			return githubReleaseNotesMsg{err: err}
 		}
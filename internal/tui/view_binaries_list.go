@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 
+	installSvc "cturner8/binmate/internal/core/install"
+
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -182,7 +184,11 @@ func (m model) renderBinariesList() string {
 
 		name := truncateText(selectionIndicator+binary.Binary.Name, nameWidth)
 		provider := truncateText(binary.Binary.Provider, providerWidth)
-		version := truncateText(binary.ActiveVersion, versionWidth)
+		activeVersion := installSvc.DisplayVersion(binary.Binary, binary.ActiveVersion)
+		if binary.PinnedVersion != "" {
+			activeVersion += " (pinned)"
+		}
+		version := truncateText(activeVersion, versionWidth)
 		count := fmt.Sprintf("%d", binary.InstallCount)
 
 		row := []string{
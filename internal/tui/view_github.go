@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 
+	installSvc "cturner8/binmate/internal/core/install"
+
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -114,34 +116,68 @@ func (m model) renderAvailableVersions() string {
 		return b.String()
 	}
 
+	// Show tag search input or the active search
+	if m.versionSearchMode {
+		b.WriteString(headerStyle.Render("🔍 Search Tags"))
+		b.WriteString("\n\n")
+		b.WriteString(m.versionSearchInput.View())
+		b.WriteString("\n\n")
+		b.WriteString(helpStyle.Render("Type to search (regex supported) • Enter: apply filter • Esc: cancel"))
+		b.WriteString("\n\n")
+	} else if m.versionSearchQuery != "" {
+		b.WriteString(fmt.Sprintf("🔍 Search: \"%s\" (esc to clear)\n\n", m.versionSearchQuery))
+	}
+
+	versions := m.displayedAvailableVersions()
+
 	// Show versions if available
-	if len(m.githubAvailableVers) > 0 {
-		// Calculate column widths
-		availableWidth := m.width
-		if availableWidth == 0 {
-			availableWidth = defaultTerminalWidth
+	if len(versions) > 0 {
+		// Mark versions that are already installed
+		installed := make(map[string]bool)
+		for _, installation := range m.installations {
+			installed[installation.Version] = true
+		}
+		activeVersion := ""
+		if m.selectedBinary != nil {
+			if active, _ := getActiveVersion(m.dbService, m.selectedBinary.ID); active != nil {
+				activeVersion = active.Version
+			}
 		}
 
 		versionWidth := 20
 		dateWidth := 20
 		typeWidth := 15
+		statusWidth := 12
 
 		// Headers
 		headers := []string{
 			tableHeaderStyle.Width(versionWidth).Render("Version"),
 			tableHeaderStyle.Width(dateWidth).Render("Published"),
 			tableHeaderStyle.Width(typeWidth).Render("Type"),
+			tableHeaderStyle.Width(statusWidth).Render("Status"),
 		}
 		b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, headers...))
 		b.WriteString("\n")
 
 		// Separator
-		b.WriteString(strings.Repeat("─", versionWidth+dateWidth+typeWidth+4))
+		b.WriteString(strings.Repeat("─", versionWidth+dateWidth+typeWidth+statusWidth+4))
 		b.WriteString("\n")
 
+		// Only render the rows that fit on screen, keeping the selection visible
+		visibleRows := len(versions)
+		if m.height > 0 {
+			visibleRows = max(m.height-14, 5)
+		}
+		start := 0
+		if m.selectedAvailableVersionIdx >= visibleRows {
+			start = m.selectedAvailableVersionIdx - visibleRows + 1
+		}
+		end := min(start+visibleRows, len(versions))
+
 		// Rows
-		for i, release := range m.githubAvailableVers {
-			version := truncateText(release.TagName, versionWidth)
+		for i := start; i < end; i++ {
+			release := versions[i]
+			version := truncateText(installSvc.DisplayVersion(m.selectedBinary, release.TagName), versionWidth)
 			date := truncateText(release.PublishedAt, dateWidth)
 			releaseType := "Release"
 			if release.Prerelease {
@@ -149,6 +185,13 @@ func (m model) renderAvailableVersions() string {
 			}
 			releaseType = truncateText(releaseType, typeWidth)
 
+			status := ""
+			if release.TagName == activeVersion {
+				status = "✓ Active"
+			} else if installed[release.TagName] {
+				status = "Installed"
+			}
+
 			rowStyle := normalStyle
 			if i == m.selectedAvailableVersionIdx {
 				rowStyle = selectedStyle
@@ -158,16 +201,37 @@ func (m model) renderAvailableVersions() string {
 				rowStyle.Width(versionWidth).Render(version),
 				rowStyle.Width(dateWidth).Render(date),
 				rowStyle.Width(typeWidth).Render(releaseType),
+				rowStyle.Width(statusWidth).Render(status),
 			}
 
 			b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, row...))
 			b.WriteString("\n")
 		}
+
+		b.WriteString("\n")
+		summary := fmt.Sprintf("Showing %d-%d of %d loaded", start+1, end, len(versions))
+		if m.githubVersionsNextURL != "" {
+			summary += " (more available)"
+		}
+		b.WriteString(summary)
+		b.WriteString("\n")
+	} else if m.githubLoadingMore {
+		b.WriteString(emptyStateStyle.Render("No matching versions loaded yet"))
+		b.WriteString("\n")
 	} else {
 		b.WriteString(emptyStateStyle.Render("No versions available"))
 		b.WriteString("\n\n")
 	}
 
+	if m.githubLoadingMore {
+		b.WriteString(loadingStyle.Render("Loading more versions..."))
+		b.WriteString("\n")
+	}
+	if m.errorMessage != "" {
+		b.WriteString(errorStyle.Render("Error: " + m.errorMessage))
+		b.WriteString("\n")
+	}
+
 	b.WriteString("\n")
 	b.WriteString(helpStyle.Render(getHelpText(m.currentView)))
 
@@ -3,6 +3,10 @@ package tui
 import (
 	"fmt"
 	"strings"
+	"time"
+
+	"cturner8/binmate/internal/core/format"
+	coreprogress "cturner8/binmate/internal/core/progress"
 )
 
 // renderInstallBinary renders the install binary view
@@ -34,7 +38,21 @@ func (m model) renderInstallBinary() string {
 	if m.installingInProgress {
 		b.WriteString(loadingStyle.Render(fmt.Sprintf("Installing %s...", binaryName)))
 		b.WriteString("\n\n")
+		if p := m.installProgress; p.Downloaded > 0 {
+			if p.Total > 0 {
+				b.WriteString(m.installProgressBar.View())
+				b.WriteString("\n")
+			}
+			b.WriteString(helpStyle.Render(renderDownloadStats(p)))
+			b.WriteString("\n\n")
+		}
+		if m.cancellingInstall {
+			b.WriteString(loadingStyle.Render("Cancelling and cleaning up..."))
+			return b.String()
+		}
 		b.WriteString(helpStyle.Render("This may take a few moments depending on file size and network speed"))
+		b.WriteString("\n\n")
+		b.WriteString(helpStyle.Render("esc: cancel install • q: cancel and quit"))
 		return b.String()
 	}
 
@@ -51,3 +69,18 @@ func (m model) renderInstallBinary() string {
 
 	return b.String()
 }
+
+// renderDownloadStats formats downloaded bytes, rate and ETA for the install view
+func renderDownloadStats(p coreprogress.Progress) string {
+	stats := format.FormatBytes(p.Downloaded)
+	if p.Total > 0 {
+		stats += " / " + format.FormatBytes(p.Total)
+	}
+	if p.Rate > 0 {
+		stats += fmt.Sprintf(" • %s/s", format.FormatBytes(int64(p.Rate)))
+	}
+	if p.ETA > 0 && !p.Done {
+		stats += fmt.Sprintf(" • ETA %s", p.ETA.Round(time.Second))
+	}
+	return stats
+}
@@ -161,6 +161,7 @@ func (m model) renderHelp() string {
 	b.WriteString("\n")
 	b.WriteString("  ↑/↓      Navigate through installed versions\n")
 	b.WriteString("  s/Enter  Switch to selected version\n")
+	b.WriteString("  R        Roll back to previously active version\n")
 	b.WriteString("  d        Delete selected version\n")
 	b.WriteString("  Esc      Return to binaries list\n")
 	b.WriteString("\n")
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"cturner8/binmate/internal/core/format"
+	installSvc "cturner8/binmate/internal/core/install"
 
 	"github.com/charmbracelet/lipgloss"
 )
@@ -113,13 +114,13 @@ func (m model) renderVersions() string {
 		}
 
 		// Version
-		version := truncateText(installation.Version, versionWidth)
+		version := truncateText(installSvc.DisplayVersion(m.selectedBinary, installation.Version), versionWidth)
 
 		// Installed date
 		installedDate := format.FormatTimestamp(installation.InstalledAt, dateFormat)
 
 		// File size (human-readable)
-		size := formatBytes(installation.FileSize)
+		size := format.FormatBytes(installation.FileSize)
 
 		// Install path (truncate from beginning, keep end)
 		path := truncatePathEnd(installation.InstalledPath, pathWidth)
@@ -141,21 +142,3 @@ func (m model) renderVersions() string {
 
 	return b.String()
 }
-
-// formatBytes converts bytes to human-readable format
-func formatBytes(bytes int64) string {
-	const unit = 1024
-	if bytes < unit {
-		return fmt.Sprintf("%d B", bytes)
-	}
-
-	// Unit prefixes: Kilo, Mega, Giga, Tera, Peta, Exa
-	const unitPrefixes = "KMGTPE"
-
-	div, exp := int64(unit), 0
-	for n := bytes / unit; n >= unit; n /= unit {
-		div *= unit
-		exp++
-	}
-	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), unitPrefixes[exp])
-}
@@ -3,7 +3,14 @@ Copyright © 2026 cturner8
 */
 package main
 
-import "cturner8/binmate/cmd"
+import (
+	_ "embed"
+
+	"cturner8/binmate/cmd"
+)
+
+//go:embed schema.json
+var schema []byte
 
 var (
 	version = "dev"
@@ -13,5 +20,6 @@ var (
 
 func main() {
 	cmd.SetBuildMetadata(version, commit, date)
+	cmd.SetSchema(schema)
 	cmd.Execute()
 }